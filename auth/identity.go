@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Identity describes the verified caller of a request. A device calling with
+// its own key has DeviceID set and acts on behalf of the device's owner UserID.
+type Identity struct {
+	UserID   string
+	DeviceID string
+	Claims   map[string]string
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying the given identity.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity stored in ctx, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// IsDevice reports whether the caller is a device rather than a user.
+func (i *Identity) IsDevice() bool {
+	return i.DeviceID != ""
+}
+
+// AdminRole is the role claim value that grants access to every user's devices.
+const AdminRole = "admin"
+
+// IsAdmin reports whether the identity carries the admin role, either as the
+// "role" claim or as one of the comma-separated "roles" claim values.
+func (i *Identity) IsAdmin() bool {
+	if i.Claims["role"] == AdminRole {
+		return true
+	}
+
+	for _, role := range strings.Split(i.Claims["roles"], ",") {
+		if strings.TrimSpace(role) == AdminRole {
+			return true
+		}
+	}
+
+	return false
+}
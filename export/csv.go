@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+var csvHeader = []string{"time", "latitude", "longitude", "altitude", "accuracy", "speed", "heading"}
+
+// csvEncoder writes a row per location after a header row.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (*csvEncoder, error) {
+	e := &csvEncoder{w: csv.NewWriter(w)}
+	if err := e.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Encode implements Encoder.
+func (e *csvEncoder) Encode(location *model.Location) error {
+	return e.w.Write([]string{
+		formatTime(location.Timestamp),
+		formatFloat(location.Latitude),
+		formatFloat(location.Longitude),
+		formatFloat(location.Altitude),
+		formatFloat(location.Accuracy),
+		formatFloat(location.Speed),
+		formatFloat(location.Heading),
+	})
+}
+
+// Close implements Encoder.
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
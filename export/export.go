@@ -0,0 +1,78 @@
+// Package export encodes location history into file formats understood by
+// mapping and hiking apps. Encoders write each location as it is passed in, so
+// a history of any length can be streamed without holding it in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+// Format is a file format locations can be exported to.
+type Format string
+
+const (
+	GPX     Format = "gpx"     // GPX 1.1 track
+	KML     Format = "kml"     // KML 2.2 line string
+	GeoJSON Format = "geojson" // GeoJSON FeatureCollection of points
+	CSV     Format = "csv"     // Comma-separated values with a header row
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{GPX, KML, GeoJSON, CSV}
+
+// Encoder writes a sequence of locations in one format.
+type Encoder interface {
+	// Encode writes a location. Locations are expected oldest first.
+	Encode(location *model.Location) error
+	// Close writes whatever ends the document. It does not close the underlying writer.
+	Close() error
+}
+
+// NewEncoder writes the beginning of a document in format to w and returns the
+// encoder for its locations. The name, such as the device name, titles the
+// track in formats that have a title.
+func NewEncoder(format Format, w io.Writer, name string) (Encoder, error) {
+	switch format {
+	case GPX:
+		return newGPXEncoder(w, name)
+	case KML:
+		return newKMLEncoder(w, name)
+	case GeoJSON:
+		return newGeoJSONEncoder(w, name)
+	case CSV:
+		return newCSVEncoder(w)
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ContentType returns the media type of documents in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case GPX:
+		return "application/gpx+xml"
+	case KML:
+		return "application/vnd.google-earth.kml+xml"
+	case GeoJSON:
+		return "application/geo+json"
+	case CSV:
+		return "text/csv"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// formatFloat formats a number with as many digits as needed to read it back exactly.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatTime formats a Unix timestamp as an RFC 3339 time in UTC.
+func formatTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,87 @@
+package export_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BerryTracer/device-service/export"
+	"github.com/BerryTracer/device-service/model"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+var locations = []*model.Location{
+	{Latitude: 47.2692, Longitude: 11.4041, Altitude: 574.5, Accuracy: 5, Speed: 0, Heading: 0, Timestamp: 1700000000},
+	{Latitude: 47.27, Longitude: 11.41, Altitude: 580, Accuracy: 3.2, Speed: 1.4, Heading: 45, Timestamp: 1700000060},
+	{Latitude: 47.2711, Longitude: 11.4187, Altitude: 591.25, Accuracy: 4, Speed: 1.6, Heading: 90.5, Timestamp: 1700000120},
+}
+
+func TestEncoders(t *testing.T) {
+	for _, format := range export.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			got := encode(t, format, "Hike <Innsbruck & Hungerburg>", locations)
+			compareGolden(t, filepath.Join("testdata", "track."+string(format)), got)
+		})
+	}
+}
+
+func TestEncoders_Empty(t *testing.T) {
+	for _, format := range export.Formats {
+		t.Run(string(format), func(t *testing.T) {
+			got := encode(t, format, "", nil)
+			compareGolden(t, filepath.Join("testdata", "empty."+string(format)), got)
+		})
+	}
+}
+
+func TestNewEncoder_UnknownFormat(t *testing.T) {
+	if _, err := export.NewEncoder("shp", &bytes.Buffer{}, ""); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func encode(t *testing.T, format export.Format, name string, locations []*model.Location) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	encoder, err := export.NewEncoder(format, &buffer, name)
+	if err != nil {
+		t.Fatalf("failed to create encoder: %v", err)
+	}
+
+	for _, location := range locations {
+		if err := encoder.Encode(location); err != nil {
+			t.Fatalf("failed to encode location: %v", err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("failed to close encoder: %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+// compareGolden compares output with the golden file, rewriting the file first
+// when the tests run with -update.
+func compareGolden(t *testing.T, golden string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s; run go test ./export -update if the change is intended\ngot:\n%s", golden, got)
+	}
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+// geoJSONFeature is a location as a GeoJSON point feature.
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONPoint      `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [3]float64 `json:"coordinates"` // Longitude, latitude, altitude
+}
+
+type geoJSONProperties struct {
+	Time     string  `json:"time"`
+	Accuracy float64 `json:"accuracy"`
+	Speed    float64 `json:"speed"`
+	Heading  float64 `json:"heading"`
+}
+
+// geoJSONEncoder writes a FeatureCollection with a point feature per location.
+type geoJSONEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func newGeoJSONEncoder(w io.Writer, name string) (*geoJSONEncoder, error) {
+	header := `{"type":"FeatureCollection",`
+	if name != "" {
+		encoded, err := marshalJSON(name)
+		if err != nil {
+			return nil, err
+		}
+		header += `"name":` + string(encoded) + `,`
+	}
+	header += `"features":[`
+
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	return &geoJSONEncoder{w: w}, nil
+}
+
+// Encode implements Encoder.
+func (e *geoJSONEncoder) Encode(location *model.Location) error {
+	feature, err := marshalJSON(geoJSONFeature{
+		Type: "Feature",
+		Geometry: geoJSONPoint{
+			Type:        "Point",
+			Coordinates: [3]float64{location.Longitude, location.Latitude, location.Altitude},
+		},
+		Properties: geoJSONProperties{
+			Time:     formatTime(location.Timestamp),
+			Accuracy: location.Accuracy,
+			Speed:    location.Speed,
+			Heading:  location.Heading,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	separator := "\n"
+	if e.count > 0 {
+		separator = ",\n"
+	}
+	e.count++
+
+	_, err = io.WriteString(e.w, separator+string(feature))
+	return err
+}
+
+// Close implements Encoder.
+func (e *geoJSONEncoder) Close() error {
+	_, err := io.WriteString(e.w, "\n]}\n")
+	return err
+}
+
+// marshalJSON is json.Marshal without escaping HTML characters, which have no
+// special meaning in a downloaded file.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+const gpxHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="BerryTracer" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+`
+
+// gpxEncoder writes a GPX 1.1 document with a single track segment.
+type gpxEncoder struct {
+	w io.Writer
+}
+
+func newGPXEncoder(w io.Writer, name string) (*gpxEncoder, error) {
+	header := gpxHeader
+	if name != "" {
+		header += "    <name>" + escapeXML(name) + "</name>\n"
+	}
+	header += "    <trkseg>\n"
+
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	return &gpxEncoder{w: w}, nil
+}
+
+// Encode implements Encoder.
+func (e *gpxEncoder) Encode(location *model.Location) error {
+	_, err := fmt.Fprintf(e.w, "      <trkpt lat=\"%s\" lon=\"%s\"><ele>%s</ele><time>%s</time></trkpt>\n",
+		formatFloat(location.Latitude), formatFloat(location.Longitude), formatFloat(location.Altitude), formatTime(location.Timestamp))
+	return err
+}
+
+// Close implements Encoder.
+func (e *gpxEncoder) Close() error {
+	_, err := io.WriteString(e.w, "    </trkseg>\n  </trk>\n</gpx>\n")
+	return err
+}
+
+// escapeXML escapes text for use in XML character data.
+func escapeXML(text string) string {
+	var escaped strings.Builder
+	// Writing to a strings.Builder cannot fail.
+	_ = xml.EscapeText(&escaped, []byte(text))
+	return escaped.String()
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+const kmlHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2">
+  <Document>
+`
+
+// kmlEncoder writes a KML 2.2 document with the path as a single line string.
+// KML lists the times of a track apart from its coordinates, which cannot be
+// streamed, so only the positions are exported.
+type kmlEncoder struct {
+	w io.Writer
+}
+
+func newKMLEncoder(w io.Writer, name string) (*kmlEncoder, error) {
+	header := kmlHeader
+	if name != "" {
+		header += "    <name>" + escapeXML(name) + "</name>\n"
+	}
+	header += "    <Placemark>\n      <LineString>\n        <coordinates>\n"
+
+	if _, err := io.WriteString(w, header); err != nil {
+		return nil, err
+	}
+
+	return &kmlEncoder{w: w}, nil
+}
+
+// Encode implements Encoder.
+func (e *kmlEncoder) Encode(location *model.Location) error {
+	_, err := fmt.Fprintf(e.w, "          %s,%s,%s\n",
+		formatFloat(location.Longitude), formatFloat(location.Latitude), formatFloat(location.Altitude))
+	return err
+}
+
+// Close implements Encoder.
+func (e *kmlEncoder) Close() error {
+	_, err := io.WriteString(e.w, "        </coordinates>\n      </LineString>\n    </Placemark>\n  </Document>\n</kml>\n")
+	return err
+}
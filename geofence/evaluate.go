@@ -0,0 +1,57 @@
+package geofence
+
+// EventType is the kind of transition of a device relative to a fence.
+type EventType string
+
+const (
+	Enter EventType = "enter"
+	Exit  EventType = "exit"
+	Dwell EventType = "dwell"
+)
+
+// Event is a transition detected at a position.
+type Event struct {
+	Type  EventType
+	Point Point
+	Time  int64 // Unix timestamp (seconds since epoch)
+}
+
+// State is what is known about a device relative to a fence. The zero State
+// means the device has not been seen yet and is treated as outside.
+type State struct {
+	Inside    bool
+	Since     int64 // Time of the position at which the device entered
+	Dwelled   bool  // Whether the dwell event for the current visit was emitted
+	UpdatedAt int64 // Time of the last position evaluated
+}
+
+// Evaluate applies the position p seen at time t to state and returns the new
+// state with the events it caused. A dwell event is emitted once per visit when
+// the device has been inside for at least dwell seconds; a dwell of 0 disables it.
+// Positions older than the state are ignored, so a late report cannot undo a
+// newer transition.
+func Evaluate(shape Shape, dwell int64, state State, p Point, t int64) (State, []Event) {
+	if t < state.UpdatedAt {
+		return state, nil
+	}
+
+	var events []Event
+	inside := shape.Contains(p)
+
+	switch {
+	case inside && !state.Inside:
+		state = State{Inside: true, Since: t}
+		events = append(events, Event{Type: Enter, Point: p, Time: t})
+	case !inside && state.Inside:
+		state = State{}
+		events = append(events, Event{Type: Exit, Point: p, Time: t})
+	}
+
+	if state.Inside && !state.Dwelled && dwell > 0 && t-state.Since >= dwell {
+		state.Dwelled = true
+		events = append(events, Event{Type: Dwell, Point: p, Time: t})
+	}
+
+	state.UpdatedAt = t
+	return state, events
+}
@@ -0,0 +1,67 @@
+package geofence_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/BerryTracer/device-service/geofence"
+)
+
+func TestEvaluate(t *testing.T) {
+	fence := geofence.Circle{Center: geofence.Point{Lat: 0, Lon: 0}, Radius: 1000}
+	inside := geofence.Point{Lat: 0, Lon: 0.001}
+	outside := geofence.Point{Lat: 0, Lon: 0.1}
+
+	steps := []struct {
+		point geofence.Point
+		time  int64
+		want  []geofence.EventType
+	}{
+		{point: outside, time: 100, want: nil},
+		{point: inside, time: 200, want: []geofence.EventType{geofence.Enter}},
+		{point: inside, time: 400, want: nil},
+		{point: inside, time: 500, want: []geofence.EventType{geofence.Dwell}},
+		{point: inside, time: 900, want: nil},
+		{point: outside, time: 300, want: nil}, // Older than the state, ignored
+		{point: outside, time: 1000, want: []geofence.EventType{geofence.Exit}},
+		{point: inside, time: 1100, want: []geofence.EventType{geofence.Enter}},
+	}
+
+	var state geofence.State
+	for i, step := range steps {
+		var events []geofence.Event
+		state, events = geofence.Evaluate(fence, 300, state, step.point, step.time)
+
+		var got []geofence.EventType
+		for _, event := range events {
+			got = append(got, event.Type)
+			if event.Time != step.time || event.Point != step.point {
+				t.Errorf("step %d: event %+v does not carry the position", i, event)
+			}
+		}
+
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: events = %v, want %v", i, got, step.want)
+		}
+	}
+
+	if !state.Inside || state.Since != 1100 || state.Dwelled {
+		t.Errorf("unexpected final state %+v", state)
+	}
+}
+
+func TestEvaluate_DwellOnEntry(t *testing.T) {
+	fence := geofence.Circle{Center: geofence.Point{Lat: 0, Lon: 0}, Radius: 1000}
+
+	// Without a dwell time no dwell event is emitted.
+	_, events := geofence.Evaluate(fence, 0, geofence.State{Inside: true, Since: 0, UpdatedAt: 10}, fence.Center, 1000)
+	if len(events) != 0 {
+		t.Errorf("expected no events without a dwell time, got %v", events)
+	}
+
+	// A device that was already inside long enough dwells on its next position.
+	state, events := geofence.Evaluate(fence, 60, geofence.State{Inside: true, Since: 0, UpdatedAt: 10}, fence.Center, 60)
+	if len(events) != 1 || events[0].Type != geofence.Dwell || !state.Dwelled {
+		t.Errorf("expected a dwell event, got %v (%+v)", events, state)
+	}
+}
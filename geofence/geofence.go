@@ -0,0 +1,136 @@
+// Package geofence decides whether positions lie inside circular and polygonal
+// fences and derives enter, exit and dwell events from a sequence of positions.
+// It has no dependencies on storage or transport.
+package geofence
+
+import (
+	"math"
+)
+
+// earthRadius is the mean radius of the Earth in meters.
+const earthRadius = 6371008.8
+
+// epsilon is the tolerance, in degrees, within which a point counts as lying on
+// a polygon edge. It is roughly 1 cm at the equator.
+const epsilon = 1e-7
+
+// Point is a position in degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Shape is an area on the Earth's surface.
+type Shape interface {
+	// Contains reports whether p lies inside the shape or on its boundary.
+	Contains(p Point) bool
+}
+
+// Circle is the area within Radius meters of Center.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Contains implements Shape.
+func (c Circle) Contains(p Point) bool {
+	return Distance(c.Center, p) <= c.Radius
+}
+
+// Polygon is the area enclosed by Vertices. The polygon is closed implicitly,
+// and the first vertex may be repeated at the end.
+//
+// Edges are straight lines in latitude/longitude, which is accurate for fences
+// of a few kilometers. An edge always takes the shorter way around the globe, so
+// a polygon may cross the antimeridian, but it may not enclose a pole.
+type Polygon struct {
+	Vertices []Point
+}
+
+// Contains implements Shape.
+func (g Polygon) Contains(p Point) bool {
+	if len(g.Vertices) < 3 {
+		return false
+	}
+
+	vertices := unwrap(g.Vertices)
+
+	// After unwrapping, the polygon may extend beyond ±180°, so the point is
+	// also tried one turn to either side.
+	for _, lon := range []float64{p.Lon, p.Lon + 360, p.Lon - 360} {
+		if contains(vertices, Point{Lat: p.Lat, Lon: lon}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwrap returns the vertices with longitudes shifted by whole turns so that
+// consecutive vertices are never more than 180° apart.
+func unwrap(vertices []Point) []Point {
+	unwrapped := make([]Point, len(vertices))
+	unwrapped[0] = vertices[0]
+	for i := 1; i < len(vertices); i++ {
+		lon := vertices[i].Lon
+		previous := unwrapped[i-1].Lon
+		for lon-previous > 180 {
+			lon -= 360
+		}
+		for lon-previous < -180 {
+			lon += 360
+		}
+		unwrapped[i] = Point{Lat: vertices[i].Lat, Lon: lon}
+	}
+	return unwrapped
+}
+
+// contains reports whether p lies inside or on the boundary of the planar
+// polygon, using the even-odd rule.
+func contains(vertices []Point, p Point) bool {
+	inside := false
+	for i, j := 0, len(vertices)-1; i < len(vertices); j, i = i, i+1 {
+		a, b := vertices[j], vertices[i]
+
+		if onSegment(a, b, p) {
+			return true
+		}
+
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) {
+			lon := a.Lon + (p.Lat-a.Lat)*(b.Lon-a.Lon)/(b.Lat-a.Lat)
+			if p.Lon < lon {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// onSegment reports whether p lies on the segment from a to b.
+func onSegment(a, b, p Point) bool {
+	cross := (b.Lon-a.Lon)*(p.Lat-a.Lat) - (b.Lat-a.Lat)*(p.Lon-a.Lon)
+	length := math.Hypot(b.Lon-a.Lon, b.Lat-a.Lat)
+	if length == 0 {
+		return math.Abs(p.Lon-a.Lon) <= epsilon && math.Abs(p.Lat-a.Lat) <= epsilon
+	}
+	if math.Abs(cross)/length > epsilon {
+		return false
+	}
+
+	return p.Lon >= math.Min(a.Lon, b.Lon)-epsilon && p.Lon <= math.Max(a.Lon, b.Lon)+epsilon &&
+		p.Lat >= math.Min(a.Lat, b.Lat)-epsilon && p.Lat <= math.Max(a.Lat, b.Lat)+epsilon
+}
+
+// Distance returns the great-circle distance between a and b in meters.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
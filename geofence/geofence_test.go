@@ -0,0 +1,104 @@
+package geofence_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/BerryTracer/device-service/geofence"
+)
+
+func TestPolygon_Contains(t *testing.T) {
+	square := geofence.Polygon{Vertices: []geofence.Point{
+		{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 10, Lon: 10}, {Lat: 10, Lon: 0},
+	}}
+
+	// A U shape with a notch between longitudes 4 and 6 above latitude 4.
+	concave := geofence.Polygon{Vertices: []geofence.Point{
+		{Lat: 0, Lon: 0}, {Lat: 0, Lon: 10}, {Lat: 10, Lon: 10}, {Lat: 10, Lon: 6},
+		{Lat: 4, Lon: 6}, {Lat: 4, Lon: 4}, {Lat: 10, Lon: 4}, {Lat: 10, Lon: 0},
+	}}
+
+	// The same square with the first vertex repeated to close it.
+	closed := geofence.Polygon{Vertices: append(append([]geofence.Point{}, square.Vertices...), square.Vertices[0])}
+
+	// A box from 170°E to 170°W crossing the antimeridian.
+	antimeridian := geofence.Polygon{Vertices: []geofence.Point{
+		{Lat: 10, Lon: 170}, {Lat: 10, Lon: -170}, {Lat: -10, Lon: -170}, {Lat: -10, Lon: 170},
+	}}
+
+	tests := []struct {
+		name    string
+		polygon geofence.Polygon
+		point   geofence.Point
+		want    bool
+	}{
+		{name: "inside", polygon: square, point: geofence.Point{Lat: 5, Lon: 5}, want: true},
+		{name: "outside", polygon: square, point: geofence.Point{Lat: 5, Lon: 15}, want: false},
+		{name: "on an edge", polygon: square, point: geofence.Point{Lat: 5, Lon: 0}, want: true},
+		{name: "on the top edge", polygon: square, point: geofence.Point{Lat: 10, Lon: 5}, want: true},
+		{name: "on a vertex", polygon: square, point: geofence.Point{Lat: 10, Lon: 10}, want: true},
+		{name: "just outside an edge", polygon: square, point: geofence.Point{Lat: 5, Lon: -0.0001}, want: false},
+		{name: "level with a vertex outside", polygon: square, point: geofence.Point{Lat: 10, Lon: -5}, want: false},
+		{name: "concave inside", polygon: concave, point: geofence.Point{Lat: 2, Lon: 5}, want: true},
+		{name: "concave notch", polygon: concave, point: geofence.Point{Lat: 7, Lon: 5}, want: false},
+		{name: "concave notch edge", polygon: concave, point: geofence.Point{Lat: 4, Lon: 5}, want: true},
+		{name: "closed ring", polygon: closed, point: geofence.Point{Lat: 5, Lon: 5}, want: true},
+		{name: "antimeridian east side", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: 175}, want: true},
+		{name: "antimeridian west side", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: -175}, want: true},
+		{name: "antimeridian at 180", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: 180}, want: true},
+		{name: "antimeridian at -180", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: -180}, want: true},
+		{name: "antimeridian edge", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: -170}, want: true},
+		{name: "antimeridian corner edge", polygon: antimeridian, point: geofence.Point{Lat: 10, Lon: 180}, want: true},
+		{name: "antimeridian outside east", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: 160}, want: false},
+		{name: "antimeridian outside west", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: -160}, want: false},
+		{name: "antimeridian prime meridian", polygon: antimeridian, point: geofence.Point{Lat: 0, Lon: 0}, want: false},
+		{name: "too few vertices", polygon: geofence.Polygon{Vertices: square.Vertices[:2]}, point: geofence.Point{Lat: 0, Lon: 5}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.polygon.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCircle_Contains(t *testing.T) {
+	berlin := geofence.Circle{Center: geofence.Point{Lat: 52.5200, Lon: 13.4050}, Radius: 1000}
+	dateLine := geofence.Circle{Center: geofence.Point{Lat: 0, Lon: 179.999}, Radius: 500}
+
+	tests := []struct {
+		name   string
+		circle geofence.Circle
+		point  geofence.Point
+		want   bool
+	}{
+		{name: "center", circle: berlin, point: berlin.Center, want: true},
+		{name: "inside", circle: berlin, point: geofence.Point{Lat: 52.5245, Lon: 13.4050}, want: true},
+		{name: "outside", circle: berlin, point: geofence.Point{Lat: 52.5380, Lon: 13.4050}, want: false},
+		{name: "across the antimeridian", circle: dateLine, point: geofence.Point{Lat: 0, Lon: -179.999}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.circle.Contains(tt.point); got != tt.want {
+				t.Errorf("Contains(%+v) = %v, want %v", tt.point, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	// One degree of latitude is about 111.2 km.
+	got := geofence.Distance(geofence.Point{Lat: 0, Lon: 0}, geofence.Point{Lat: 1, Lon: 0})
+	if math.Abs(got-111195) > 10 {
+		t.Errorf("Distance = %f, want about 111195", got)
+	}
+
+	// The distance across the antimeridian is the short way around.
+	got = geofence.Distance(geofence.Point{Lat: 0, Lon: 179.5}, geofence.Point{Lat: 0, Lon: -179.5})
+	if math.Abs(got-111195) > 10 {
+		t.Errorf("Distance across the antimeridian = %f, want about 111195", got)
+	}
+}
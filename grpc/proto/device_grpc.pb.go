@@ -22,14 +22,122 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DeviceServiceClient interface {
-	// Create a new device
-	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error)
+	// Create a new device. The server assigns id and registration_date and
+	// returns the created device.
+	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*Device, error)
 	// Get a device by its ID
 	GetDeviceById(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
 	// Get a device by its serial number
 	GetDeviceBySerialNumber(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
-	// Get devices by a user ID
+	// Get devices by a user ID, or the caller's own devices when the ID is empty
 	GetDevicesByUserId(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceList, error)
+	// List a page of the devices a user owns or that are shared with them,
+	// optionally filtered and ordered
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// Find the owned and shared devices whose last location is within a radius
+	// of a point, nearest first
+	FindDevicesNear(ctx context.Context, in *FindDevicesNearRequest, opts ...grpc.CallOption) (*FindDevicesNearResponse, error)
+	// Update an existing device. Fails with ABORTED if device.etag is set and
+	// the device was changed since; the current etag is in the error's metadata.
+	// Returns the updated device with its new etag.
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Update only the fields of a device listed in the update mask. Returns the
+	// updated device with its new etag.
+	PatchDevice(ctx context.Context, in *PatchDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Move a device to the trash by its ID. It is hidden from reads and
+	// listings and purged once the retention period has passed.
+	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error)
+	// List the devices in the trash, the most recently deleted first
+	ListDeletedDevices(ctx context.Context, in *ListDeletedDevicesRequest, opts ...grpc.CallOption) (*DeviceList, error)
+	// Take a device out of the trash. Fails with ALREADY_EXISTS if its serial
+	// number has been registered again in the meantime.
+	RestoreDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Issue a new key for a device to authenticate its own calls with; the previous key stops working
+	RotateDeviceKey(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceKeyResponse, error)
+	// Revoke the key of a device, so that it can no longer authenticate
+	RevokeDeviceKey(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error)
+	// Create unowned devices with one-time claim codes. Admins only.
+	PreregisterDevices(ctx context.Context, in *PreregisterDevicesRequest, opts ...grpc.CallOption) (*PreregisterDevicesResponse, error)
+	// Become the owner of a preregistered device with its serial number and claim code
+	ClaimDevice(ctx context.Context, in *ClaimDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Offer a device to another user, replacing any earlier offer. Returns the device with its transfer.
+	StartDeviceTransfer(ctx context.Context, in *StartDeviceTransferRequest, opts ...grpc.CallOption) (*Device, error)
+	// Accept a transfer offered to the caller and become the owner of the device
+	AcceptDeviceTransfer(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Reject a transfer offered to the caller; the device stays with its owner
+	RejectDeviceTransfer(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error)
+	// List the devices offered to the caller that can still be accepted
+	ListIncomingTransfers(ctx context.Context, in *ListIncomingTransfersRequest, opts ...grpc.CallOption) (*DeviceList, error)
+	// Grant another user VIEWER or EDITOR access to a device, replacing their earlier access. Returns the device with its shares.
+	ShareDevice(ctx context.Context, in *ShareDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	// Withdraw a user's access to a device; users may also remove their own access
+	UnshareDevice(ctx context.Context, in *UnshareDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error)
+	// List the devices shared with the caller, with the caller's role
+	ListSharedDevices(ctx context.Context, in *ListSharedDevicesRequest, opts ...grpc.CallOption) (*DeviceList, error)
+	// Create a device group. The server assigns id and created_at and returns the created group.
+	CreateDeviceGroup(ctx context.Context, in *CreateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error)
+	// Get a device group by its ID
+	GetDeviceGroup(ctx context.Context, in *DeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error)
+	// List the device groups of a user by name, or the caller's own groups when the ID is empty
+	ListDeviceGroups(ctx context.Context, in *ListDeviceGroupsRequest, opts ...grpc.CallOption) (*ListDeviceGroupsResponse, error)
+	// Rename a device group or change its description
+	UpdateDeviceGroup(ctx context.Context, in *UpdateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error)
+	// Delete a device group; its devices are kept
+	DeleteDeviceGroup(ctx context.Context, in *DeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error)
+	// Add devices of the group owner to a device group
+	AddDevicesToGroup(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error)
+	// Remove devices from a device group
+	RemoveDevicesFromGroup(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error)
+	// Move every device of a group to another lifecycle status, reporting the devices that cannot make the transition
+	ChangeGroupStatus(ctx context.Context, in *ChangeGroupStatusRequest, opts ...grpc.CallOption) (*ChangeGroupStatusResponse, error)
+	// Add a device type to the catalog; admins only
+	CreateDeviceType(ctx context.Context, in *CreateDeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error)
+	// Get a device type of the catalog
+	GetDeviceType(ctx context.Context, in *DeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error)
+	// List the whole device-type catalog
+	ListDeviceTypes(ctx context.Context, in *ListDeviceTypesRequest, opts ...grpc.CallOption) (*ListDeviceTypesResponse, error)
+	// Replace a device type of the catalog; admins only
+	UpdateDeviceType(ctx context.Context, in *UpdateDeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error)
+	// Delete a device type that no device has; admins only
+	DeleteDeviceType(ctx context.Context, in *DeviceTypeRequest, opts ...grpc.CallOption) (*DeviceTypeResponse, error)
+	// Add tags and set labels on a device, keeping its other tags and labels
+	SetDeviceLabels(ctx context.Context, in *SetDeviceLabelsRequest, opts ...grpc.CallOption) (*Device, error)
+	// Remove tags and labels from a device
+	RemoveDeviceLabels(ctx context.Context, in *RemoveDeviceLabelsRequest, opts ...grpc.CallOption) (*Device, error)
+	// Move a device to another lifecycle status, recording who changed it and why.
+	// Fails with ABORTED if etag is set and the device was changed since.
+	ChangeDeviceStatus(ctx context.Context, in *ChangeDeviceStatusRequest, opts ...grpc.CallOption) (*Device, error)
+	// Stream created, updated and deleted events for the devices a user owns or
+	// that are shared with them
+	WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (DeviceService_WatchDevicesClient, error)
+	// Report one or more telemetry samples of a device
+	ReportTelemetry(ctx context.Context, in *ReportTelemetryRequest, opts ...grpc.CallOption) (*ReportTelemetryResponse, error)
+	// Report batches of telemetry samples over a single stream
+	ReportTelemetryStream(ctx context.Context, opts ...grpc.CallOption) (DeviceService_ReportTelemetryStreamClient, error)
+	// Get the telemetry samples of a device within a time range, optionally downsampled
+	GetTelemetryHistory(ctx context.Context, in *GetTelemetryHistoryRequest, opts ...grpc.CallOption) (*TelemetryHistory, error)
+	// Report one or more positions of a device
+	ReportLocation(ctx context.Context, in *ReportLocationRequest, opts ...grpc.CallOption) (*ReportLocationResponse, error)
+	// Get a page of the positions of a device within a time range, oldest first
+	GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*GetLocationHistoryResponse, error)
+	// Stream the positions of a device within a time range as a GPX, KML, GeoJSON or CSV document
+	ExportLocationHistory(ctx context.Context, in *ExportLocationHistoryRequest, opts ...grpc.CallOption) (DeviceService_ExportLocationHistoryClient, error)
+	// Create a geofence. The server assigns id and created_at and returns the created geofence.
+	CreateGeofence(ctx context.Context, in *CreateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	// Get a geofence by its ID
+	GetGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	// List the geofences of a user, or the caller's own geofences when the ID is empty
+	ListGeofences(ctx context.Context, in *ListGeofencesRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error)
+	// Replace the shape, name, devices and dwell time of a geofence
+	UpdateGeofence(ctx context.Context, in *UpdateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	// Delete a geofence by its ID
+	DeleteGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*GeofenceResponse, error)
+	// List the enter, exit and dwell events of a geofence, oldest first
+	ListGeofenceEvents(ctx context.Context, in *ListGeofenceEventsRequest, opts ...grpc.CallOption) (*ListGeofenceEventsResponse, error)
+	// Get a trip or stop of a device by its ID
+	GetTrip(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*Trip, error)
+	// List a page of the completed trips of a device, optionally with its stops, oldest first
+	ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error)
 }
 
 type deviceServiceClient struct {
@@ -40,154 +148,1817 @@ func NewDeviceServiceClient(cc grpc.ClientConnInterface) DeviceServiceClient {
 	return &deviceServiceClient{cc}
 }
 
-func (c *deviceServiceClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error) {
-	out := new(DeviceResponse)
+func (c *deviceServiceClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
 	err := c.cc.Invoke(ctx, "/service.DeviceService/CreateDevice", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDeviceById(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDeviceBySerialNumber(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceBySerialNumber", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDevicesByUserId(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceList, error) {
+	out := new(DeviceList)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDevicesByUserId", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) FindDevicesNear(ctx context.Context, in *FindDevicesNearRequest, opts ...grpc.CallOption) (*FindDevicesNearResponse, error) {
+	out := new(FindDevicesNearResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/FindDevicesNear", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/UpdateDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) PatchDevice(ctx context.Context, in *PatchDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/PatchDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error) {
+	out := new(DeviceResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/DeleteDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListDeletedDevices(ctx context.Context, in *ListDeletedDevicesRequest, opts ...grpc.CallOption) (*DeviceList, error) {
+	out := new(DeviceList)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListDeletedDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RestoreDevice(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RestoreDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RotateDeviceKey(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceKeyResponse, error) {
+	out := new(DeviceKeyResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RotateDeviceKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RevokeDeviceKey(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error) {
+	out := new(DeviceResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RevokeDeviceKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) PreregisterDevices(ctx context.Context, in *PreregisterDevicesRequest, opts ...grpc.CallOption) (*PreregisterDevicesResponse, error) {
+	out := new(PreregisterDevicesResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/PreregisterDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ClaimDevice(ctx context.Context, in *ClaimDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ClaimDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) StartDeviceTransfer(ctx context.Context, in *StartDeviceTransferRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/StartDeviceTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) AcceptDeviceTransfer(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/AcceptDeviceTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RejectDeviceTransfer(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error) {
+	out := new(DeviceResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RejectDeviceTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListIncomingTransfers(ctx context.Context, in *ListIncomingTransfersRequest, opts ...grpc.CallOption) (*DeviceList, error) {
+	out := new(DeviceList)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListIncomingTransfers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ShareDevice(ctx context.Context, in *ShareDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ShareDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UnshareDevice(ctx context.Context, in *UnshareDeviceRequest, opts ...grpc.CallOption) (*DeviceResponse, error) {
+	out := new(DeviceResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/UnshareDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListSharedDevices(ctx context.Context, in *ListSharedDevicesRequest, opts ...grpc.CallOption) (*DeviceList, error) {
+	out := new(DeviceList)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListSharedDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) CreateDeviceGroup(ctx context.Context, in *CreateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error) {
+	out := new(DeviceGroup)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/CreateDeviceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDeviceGroup(ctx context.Context, in *DeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error) {
+	out := new(DeviceGroup)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListDeviceGroups(ctx context.Context, in *ListDeviceGroupsRequest, opts ...grpc.CallOption) (*ListDeviceGroupsResponse, error) {
+	out := new(ListDeviceGroupsResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListDeviceGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateDeviceGroup(ctx context.Context, in *UpdateDeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroup, error) {
+	out := new(DeviceGroup)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/UpdateDeviceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) DeleteDeviceGroup(ctx context.Context, in *DeviceGroupRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error) {
+	out := new(DeviceGroupResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/DeleteDeviceGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) AddDevicesToGroup(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error) {
+	out := new(DeviceGroupResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/AddDevicesToGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RemoveDevicesFromGroup(ctx context.Context, in *GroupMembershipRequest, opts ...grpc.CallOption) (*DeviceGroupResponse, error) {
+	out := new(DeviceGroupResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RemoveDevicesFromGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ChangeGroupStatus(ctx context.Context, in *ChangeGroupStatusRequest, opts ...grpc.CallOption) (*ChangeGroupStatusResponse, error) {
+	out := new(ChangeGroupStatusResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ChangeGroupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) CreateDeviceType(ctx context.Context, in *CreateDeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error) {
+	out := new(DeviceType)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/CreateDeviceType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetDeviceType(ctx context.Context, in *DeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error) {
+	out := new(DeviceType)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListDeviceTypes(ctx context.Context, in *ListDeviceTypesRequest, opts ...grpc.CallOption) (*ListDeviceTypesResponse, error) {
+	out := new(ListDeviceTypesResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListDeviceTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateDeviceType(ctx context.Context, in *UpdateDeviceTypeRequest, opts ...grpc.CallOption) (*DeviceType, error) {
+	out := new(DeviceType)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/UpdateDeviceType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) DeleteDeviceType(ctx context.Context, in *DeviceTypeRequest, opts ...grpc.CallOption) (*DeviceTypeResponse, error) {
+	out := new(DeviceTypeResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/DeleteDeviceType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) SetDeviceLabels(ctx context.Context, in *SetDeviceLabelsRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/SetDeviceLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) RemoveDeviceLabels(ctx context.Context, in *RemoveDeviceLabelsRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/RemoveDeviceLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ChangeDeviceStatus(ctx context.Context, in *ChangeDeviceStatusRequest, opts ...grpc.CallOption) (*Device, error) {
+	out := new(Device)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ChangeDeviceStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) WatchDevices(ctx context.Context, in *WatchDevicesRequest, opts ...grpc.CallOption) (DeviceService_WatchDevicesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DeviceService_ServiceDesc.Streams[0], "/service.DeviceService/WatchDevices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deviceServiceWatchDevicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DeviceService_WatchDevicesClient interface {
+	Recv() (*DeviceEvent, error)
+	grpc.ClientStream
+}
+
+type deviceServiceWatchDevicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *deviceServiceWatchDevicesClient) Recv() (*DeviceEvent, error) {
+	m := new(DeviceEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deviceServiceClient) ReportTelemetry(ctx context.Context, in *ReportTelemetryRequest, opts ...grpc.CallOption) (*ReportTelemetryResponse, error) {
+	out := new(ReportTelemetryResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ReportTelemetry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ReportTelemetryStream(ctx context.Context, opts ...grpc.CallOption) (DeviceService_ReportTelemetryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DeviceService_ServiceDesc.Streams[1], "/service.DeviceService/ReportTelemetryStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deviceServiceReportTelemetryStreamClient{stream}
+	return x, nil
+}
+
+type DeviceService_ReportTelemetryStreamClient interface {
+	Send(*ReportTelemetryRequest) error
+	CloseAndRecv() (*ReportTelemetryResponse, error)
+	grpc.ClientStream
+}
+
+type deviceServiceReportTelemetryStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *deviceServiceReportTelemetryStreamClient) Send(m *ReportTelemetryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deviceServiceReportTelemetryStreamClient) CloseAndRecv() (*ReportTelemetryResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ReportTelemetryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deviceServiceClient) GetTelemetryHistory(ctx context.Context, in *GetTelemetryHistoryRequest, opts ...grpc.CallOption) (*TelemetryHistory, error) {
+	out := new(TelemetryHistory)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetTelemetryHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ReportLocation(ctx context.Context, in *ReportLocationRequest, opts ...grpc.CallOption) (*ReportLocationResponse, error) {
+	out := new(ReportLocationResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ReportLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetLocationHistory(ctx context.Context, in *GetLocationHistoryRequest, opts ...grpc.CallOption) (*GetLocationHistoryResponse, error) {
+	out := new(GetLocationHistoryResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetLocationHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ExportLocationHistory(ctx context.Context, in *ExportLocationHistoryRequest, opts ...grpc.CallOption) (DeviceService_ExportLocationHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DeviceService_ServiceDesc.Streams[2], "/service.DeviceService/ExportLocationHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deviceServiceExportLocationHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DeviceService_ExportLocationHistoryClient interface {
+	Recv() (*ExportLocationHistoryResponse, error)
+	grpc.ClientStream
+}
+
+type deviceServiceExportLocationHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *deviceServiceExportLocationHistoryClient) Recv() (*ExportLocationHistoryResponse, error) {
+	m := new(ExportLocationHistoryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deviceServiceClient) CreateGeofence(ctx context.Context, in *CreateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/CreateGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListGeofences(ctx context.Context, in *ListGeofencesRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error) {
+	out := new(ListGeofencesResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListGeofences", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) UpdateGeofence(ctx context.Context, in *UpdateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/UpdateGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) DeleteGeofence(ctx context.Context, in *GeofenceRequest, opts ...grpc.CallOption) (*GeofenceResponse, error) {
+	out := new(GeofenceResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/DeleteGeofence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListGeofenceEvents(ctx context.Context, in *ListGeofenceEventsRequest, opts ...grpc.CallOption) (*ListGeofenceEventsResponse, error) {
+	out := new(ListGeofenceEventsResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListGeofenceEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) GetTrip(ctx context.Context, in *TripRequest, opts ...grpc.CallOption) (*Trip, error) {
+	out := new(Trip)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/GetTrip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceServiceClient) ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error) {
+	out := new(ListTripsResponse)
+	err := c.cc.Invoke(ctx, "/service.DeviceService/ListTrips", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeviceServiceServer is the server API for DeviceService service.
+// All implementations must embed UnimplementedDeviceServiceServer
+// for forward compatibility
+type DeviceServiceServer interface {
+	// Create a new device. The server assigns id and registration_date and
+	// returns the created device.
+	CreateDevice(context.Context, *CreateDeviceRequest) (*Device, error)
+	// Get a device by its ID
+	GetDeviceById(context.Context, *DeviceRequest) (*Device, error)
+	// Get a device by its serial number
+	GetDeviceBySerialNumber(context.Context, *DeviceRequest) (*Device, error)
+	// Get devices by a user ID, or the caller's own devices when the ID is empty
+	GetDevicesByUserId(context.Context, *DeviceRequest) (*DeviceList, error)
+	// List a page of the devices a user owns or that are shared with them,
+	// optionally filtered and ordered
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// Find the owned and shared devices whose last location is within a radius
+	// of a point, nearest first
+	FindDevicesNear(context.Context, *FindDevicesNearRequest) (*FindDevicesNearResponse, error)
+	// Update an existing device. Fails with ABORTED if device.etag is set and
+	// the device was changed since; the current etag is in the error's metadata.
+	// Returns the updated device with its new etag.
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*Device, error)
+	// Update only the fields of a device listed in the update mask. Returns the
+	// updated device with its new etag.
+	PatchDevice(context.Context, *PatchDeviceRequest) (*Device, error)
+	// Move a device to the trash by its ID. It is hidden from reads and
+	// listings and purged once the retention period has passed.
+	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeviceResponse, error)
+	// List the devices in the trash, the most recently deleted first
+	ListDeletedDevices(context.Context, *ListDeletedDevicesRequest) (*DeviceList, error)
+	// Take a device out of the trash. Fails with ALREADY_EXISTS if its serial
+	// number has been registered again in the meantime.
+	RestoreDevice(context.Context, *DeviceRequest) (*Device, error)
+	// Issue a new key for a device to authenticate its own calls with; the previous key stops working
+	RotateDeviceKey(context.Context, *DeviceRequest) (*DeviceKeyResponse, error)
+	// Revoke the key of a device, so that it can no longer authenticate
+	RevokeDeviceKey(context.Context, *DeviceRequest) (*DeviceResponse, error)
+	// Create unowned devices with one-time claim codes. Admins only.
+	PreregisterDevices(context.Context, *PreregisterDevicesRequest) (*PreregisterDevicesResponse, error)
+	// Become the owner of a preregistered device with its serial number and claim code
+	ClaimDevice(context.Context, *ClaimDeviceRequest) (*Device, error)
+	// Offer a device to another user, replacing any earlier offer. Returns the device with its transfer.
+	StartDeviceTransfer(context.Context, *StartDeviceTransferRequest) (*Device, error)
+	// Accept a transfer offered to the caller and become the owner of the device
+	AcceptDeviceTransfer(context.Context, *DeviceRequest) (*Device, error)
+	// Reject a transfer offered to the caller; the device stays with its owner
+	RejectDeviceTransfer(context.Context, *DeviceRequest) (*DeviceResponse, error)
+	// List the devices offered to the caller that can still be accepted
+	ListIncomingTransfers(context.Context, *ListIncomingTransfersRequest) (*DeviceList, error)
+	// Grant another user VIEWER or EDITOR access to a device, replacing their earlier access. Returns the device with its shares.
+	ShareDevice(context.Context, *ShareDeviceRequest) (*Device, error)
+	// Withdraw a user's access to a device; users may also remove their own access
+	UnshareDevice(context.Context, *UnshareDeviceRequest) (*DeviceResponse, error)
+	// List the devices shared with the caller, with the caller's role
+	ListSharedDevices(context.Context, *ListSharedDevicesRequest) (*DeviceList, error)
+	// Create a device group. The server assigns id and created_at and returns the created group.
+	CreateDeviceGroup(context.Context, *CreateDeviceGroupRequest) (*DeviceGroup, error)
+	// Get a device group by its ID
+	GetDeviceGroup(context.Context, *DeviceGroupRequest) (*DeviceGroup, error)
+	// List the device groups of a user by name, or the caller's own groups when the ID is empty
+	ListDeviceGroups(context.Context, *ListDeviceGroupsRequest) (*ListDeviceGroupsResponse, error)
+	// Rename a device group or change its description
+	UpdateDeviceGroup(context.Context, *UpdateDeviceGroupRequest) (*DeviceGroup, error)
+	// Delete a device group; its devices are kept
+	DeleteDeviceGroup(context.Context, *DeviceGroupRequest) (*DeviceGroupResponse, error)
+	// Add devices of the group owner to a device group
+	AddDevicesToGroup(context.Context, *GroupMembershipRequest) (*DeviceGroupResponse, error)
+	// Remove devices from a device group
+	RemoveDevicesFromGroup(context.Context, *GroupMembershipRequest) (*DeviceGroupResponse, error)
+	// Move every device of a group to another lifecycle status, reporting the devices that cannot make the transition
+	ChangeGroupStatus(context.Context, *ChangeGroupStatusRequest) (*ChangeGroupStatusResponse, error)
+	// Add a device type to the catalog; admins only
+	CreateDeviceType(context.Context, *CreateDeviceTypeRequest) (*DeviceType, error)
+	// Get a device type of the catalog
+	GetDeviceType(context.Context, *DeviceTypeRequest) (*DeviceType, error)
+	// List the whole device-type catalog
+	ListDeviceTypes(context.Context, *ListDeviceTypesRequest) (*ListDeviceTypesResponse, error)
+	// Replace a device type of the catalog; admins only
+	UpdateDeviceType(context.Context, *UpdateDeviceTypeRequest) (*DeviceType, error)
+	// Delete a device type that no device has; admins only
+	DeleteDeviceType(context.Context, *DeviceTypeRequest) (*DeviceTypeResponse, error)
+	// Add tags and set labels on a device, keeping its other tags and labels
+	SetDeviceLabels(context.Context, *SetDeviceLabelsRequest) (*Device, error)
+	// Remove tags and labels from a device
+	RemoveDeviceLabels(context.Context, *RemoveDeviceLabelsRequest) (*Device, error)
+	// Move a device to another lifecycle status, recording who changed it and why.
+	// Fails with ABORTED if etag is set and the device was changed since.
+	ChangeDeviceStatus(context.Context, *ChangeDeviceStatusRequest) (*Device, error)
+	// Stream created, updated and deleted events for the devices a user owns or
+	// that are shared with them
+	WatchDevices(*WatchDevicesRequest, DeviceService_WatchDevicesServer) error
+	// Report one or more telemetry samples of a device
+	ReportTelemetry(context.Context, *ReportTelemetryRequest) (*ReportTelemetryResponse, error)
+	// Report batches of telemetry samples over a single stream
+	ReportTelemetryStream(DeviceService_ReportTelemetryStreamServer) error
+	// Get the telemetry samples of a device within a time range, optionally downsampled
+	GetTelemetryHistory(context.Context, *GetTelemetryHistoryRequest) (*TelemetryHistory, error)
+	// Report one or more positions of a device
+	ReportLocation(context.Context, *ReportLocationRequest) (*ReportLocationResponse, error)
+	// Get a page of the positions of a device within a time range, oldest first
+	GetLocationHistory(context.Context, *GetLocationHistoryRequest) (*GetLocationHistoryResponse, error)
+	// Stream the positions of a device within a time range as a GPX, KML, GeoJSON or CSV document
+	ExportLocationHistory(*ExportLocationHistoryRequest, DeviceService_ExportLocationHistoryServer) error
+	// Create a geofence. The server assigns id and created_at and returns the created geofence.
+	CreateGeofence(context.Context, *CreateGeofenceRequest) (*Geofence, error)
+	// Get a geofence by its ID
+	GetGeofence(context.Context, *GeofenceRequest) (*Geofence, error)
+	// List the geofences of a user, or the caller's own geofences when the ID is empty
+	ListGeofences(context.Context, *ListGeofencesRequest) (*ListGeofencesResponse, error)
+	// Replace the shape, name, devices and dwell time of a geofence
+	UpdateGeofence(context.Context, *UpdateGeofenceRequest) (*Geofence, error)
+	// Delete a geofence by its ID
+	DeleteGeofence(context.Context, *GeofenceRequest) (*GeofenceResponse, error)
+	// List the enter, exit and dwell events of a geofence, oldest first
+	ListGeofenceEvents(context.Context, *ListGeofenceEventsRequest) (*ListGeofenceEventsResponse, error)
+	// Get a trip or stop of a device by its ID
+	GetTrip(context.Context, *TripRequest) (*Trip, error)
+	// List a page of the completed trips of a device, optionally with its stops, oldest first
+	ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error)
+	mustEmbedUnimplementedDeviceServiceServer()
+}
+
+// UnimplementedDeviceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDeviceServiceServer struct {
+}
+
+func (UnimplementedDeviceServiceServer) CreateDevice(context.Context, *CreateDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDeviceById(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceById not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDeviceBySerialNumber(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceBySerialNumber not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDevicesByUserId(context.Context, *DeviceRequest) (*DeviceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevicesByUserId not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) FindDevicesNear(context.Context, *FindDevicesNearRequest) (*FindDevicesNearResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDevicesNear not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateDevice(context.Context, *UpdateDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) PatchDevice(context.Context, *PatchDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDeletedDevices(context.Context, *ListDeletedDevicesRequest) (*DeviceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) RestoreDevice(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) RotateDeviceKey(context.Context, *DeviceRequest) (*DeviceKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateDeviceKey not implemented")
+}
+func (UnimplementedDeviceServiceServer) RevokeDeviceKey(context.Context, *DeviceRequest) (*DeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDeviceKey not implemented")
+}
+func (UnimplementedDeviceServiceServer) PreregisterDevices(context.Context, *PreregisterDevicesRequest) (*PreregisterDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreregisterDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) ClaimDevice(context.Context, *ClaimDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) StartDeviceTransfer(context.Context, *StartDeviceTransferRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) AcceptDeviceTransfer(context.Context, *DeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) RejectDeviceTransfer(context.Context, *DeviceRequest) (*DeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectDeviceTransfer not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListIncomingTransfers(context.Context, *ListIncomingTransfersRequest) (*DeviceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncomingTransfers not implemented")
+}
+func (UnimplementedDeviceServiceServer) ShareDevice(context.Context, *ShareDeviceRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) UnshareDevice(context.Context, *UnshareDeviceRequest) (*DeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareDevice not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListSharedDevices(context.Context, *ListSharedDevicesRequest) (*DeviceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSharedDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) CreateDeviceGroup(context.Context, *CreateDeviceGroupRequest) (*DeviceGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDeviceGroup(context.Context, *DeviceGroupRequest) (*DeviceGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDeviceGroups(context.Context, *ListDeviceGroupsRequest) (*ListDeviceGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceGroups not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateDeviceGroup(context.Context, *UpdateDeviceGroupRequest) (*DeviceGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) DeleteDeviceGroup(context.Context, *DeviceGroupRequest) (*DeviceGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) AddDevicesToGroup(context.Context, *GroupMembershipRequest) (*DeviceGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDevicesToGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) RemoveDevicesFromGroup(context.Context, *GroupMembershipRequest) (*DeviceGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDevicesFromGroup not implemented")
+}
+func (UnimplementedDeviceServiceServer) ChangeGroupStatus(context.Context, *ChangeGroupStatusRequest) (*ChangeGroupStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeGroupStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) CreateDeviceType(context.Context, *CreateDeviceTypeRequest) (*DeviceType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDeviceType not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetDeviceType(context.Context, *DeviceTypeRequest) (*DeviceType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceType not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListDeviceTypes(context.Context, *ListDeviceTypesRequest) (*ListDeviceTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceTypes not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateDeviceType(context.Context, *UpdateDeviceTypeRequest) (*DeviceType, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDeviceType not implemented")
+}
+func (UnimplementedDeviceServiceServer) DeleteDeviceType(context.Context, *DeviceTypeRequest) (*DeviceTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeviceType not implemented")
+}
+func (UnimplementedDeviceServiceServer) SetDeviceLabels(context.Context, *SetDeviceLabelsRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDeviceLabels not implemented")
+}
+func (UnimplementedDeviceServiceServer) RemoveDeviceLabels(context.Context, *RemoveDeviceLabelsRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDeviceLabels not implemented")
+}
+func (UnimplementedDeviceServiceServer) ChangeDeviceStatus(context.Context, *ChangeDeviceStatusRequest) (*Device, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeDeviceStatus not implemented")
+}
+func (UnimplementedDeviceServiceServer) WatchDevices(*WatchDevicesRequest, DeviceService_WatchDevicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDevices not implemented")
+}
+func (UnimplementedDeviceServiceServer) ReportTelemetry(context.Context, *ReportTelemetryRequest) (*ReportTelemetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTelemetry not implemented")
+}
+func (UnimplementedDeviceServiceServer) ReportTelemetryStream(DeviceService_ReportTelemetryStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReportTelemetryStream not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetTelemetryHistory(context.Context, *GetTelemetryHistoryRequest) (*TelemetryHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTelemetryHistory not implemented")
+}
+func (UnimplementedDeviceServiceServer) ReportLocation(context.Context, *ReportLocationRequest) (*ReportLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportLocation not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetLocationHistory(context.Context, *GetLocationHistoryRequest) (*GetLocationHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLocationHistory not implemented")
+}
+func (UnimplementedDeviceServiceServer) ExportLocationHistory(*ExportLocationHistoryRequest, DeviceService_ExportLocationHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportLocationHistory not implemented")
+}
+func (UnimplementedDeviceServiceServer) CreateGeofence(context.Context, *CreateGeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGeofence not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetGeofence(context.Context, *GeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeofence not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListGeofences(context.Context, *ListGeofencesRequest) (*ListGeofencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGeofences not implemented")
+}
+func (UnimplementedDeviceServiceServer) UpdateGeofence(context.Context, *UpdateGeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGeofence not implemented")
+}
+func (UnimplementedDeviceServiceServer) DeleteGeofence(context.Context, *GeofenceRequest) (*GeofenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGeofence not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListGeofenceEvents(context.Context, *ListGeofenceEventsRequest) (*ListGeofenceEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGeofenceEvents not implemented")
+}
+func (UnimplementedDeviceServiceServer) GetTrip(context.Context, *TripRequest) (*Trip, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrip not implemented")
+}
+func (UnimplementedDeviceServiceServer) ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrips not implemented")
+}
+func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
+
+// UnsafeDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeviceServiceServer will
+// result in compilation errors.
+type UnsafeDeviceServiceServer interface {
+	mustEmbedUnimplementedDeviceServiceServer()
+}
+
+func RegisterDeviceServiceServer(s grpc.ServiceRegistrar, srv DeviceServiceServer) {
+	s.RegisterService(&DeviceService_ServiceDesc, srv)
+}
+
+func _DeviceService_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/CreateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDeviceById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDeviceById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetDeviceById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDeviceById(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDeviceBySerialNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDeviceBySerialNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetDeviceBySerialNumber",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDeviceBySerialNumber(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDevicesByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDevicesByUserId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetDevicesByUserId",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDevicesByUserId(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_FindDevicesNear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindDevicesNearRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).FindDevicesNear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/FindDevicesNear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).FindDevicesNear(ctx, req.(*FindDevicesNearRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/UpdateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_PatchDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).PatchDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/PatchDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).PatchDevice(ctx, req.(*PatchDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/DeleteDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDeletedDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDeletedDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListDeletedDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDeletedDevices(ctx, req.(*ListDeletedDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RestoreDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RestoreDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RestoreDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RestoreDevice(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RotateDeviceKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RotateDeviceKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RotateDeviceKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RotateDeviceKey(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RevokeDeviceKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RevokeDeviceKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RevokeDeviceKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RevokeDeviceKey(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_PreregisterDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreregisterDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).PreregisterDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/PreregisterDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).PreregisterDevices(ctx, req.(*PreregisterDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ClaimDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ClaimDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ClaimDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ClaimDevice(ctx, req.(*ClaimDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_StartDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDeviceTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).StartDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/StartDeviceTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).StartDeviceTransfer(ctx, req.(*StartDeviceTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AcceptDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AcceptDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/AcceptDeviceTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AcceptDeviceTransfer(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RejectDeviceTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RejectDeviceTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RejectDeviceTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RejectDeviceTransfer(ctx, req.(*DeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListIncomingTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncomingTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListIncomingTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListIncomingTransfers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListIncomingTransfers(ctx, req.(*ListIncomingTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ShareDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ShareDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ShareDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ShareDevice(ctx, req.(*ShareDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UnshareDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UnshareDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/UnshareDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UnshareDevice(ctx, req.(*UnshareDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListSharedDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSharedDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListSharedDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListSharedDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListSharedDevices(ctx, req.(*ListSharedDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_CreateDeviceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreateDeviceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/CreateDeviceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreateDeviceGroup(ctx, req.(*CreateDeviceGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDeviceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDeviceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetDeviceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDeviceGroup(ctx, req.(*DeviceGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDeviceGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDeviceGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListDeviceGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDeviceGroups(ctx, req.(*ListDeviceGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateDeviceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateDeviceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/UpdateDeviceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateDeviceGroup(ctx, req.(*UpdateDeviceGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_DeleteDeviceGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).DeleteDeviceGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/DeleteDeviceGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).DeleteDeviceGroup(ctx, req.(*DeviceGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_AddDevicesToGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).AddDevicesToGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/AddDevicesToGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).AddDevicesToGroup(ctx, req.(*GroupMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RemoveDevicesFromGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RemoveDevicesFromGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RemoveDevicesFromGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RemoveDevicesFromGroup(ctx, req.(*GroupMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ChangeGroupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeGroupStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ChangeGroupStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ChangeGroupStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ChangeGroupStatus(ctx, req.(*ChangeGroupStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_CreateDeviceType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreateDeviceType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/CreateDeviceType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreateDeviceType(ctx, req.(*CreateDeviceTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_GetDeviceType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetDeviceType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetDeviceType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetDeviceType(ctx, req.(*DeviceTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ListDeviceTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListDeviceTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListDeviceTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListDeviceTypes(ctx, req.(*ListDeviceTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_UpdateDeviceType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateDeviceType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/UpdateDeviceType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateDeviceType(ctx, req.(*UpdateDeviceTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_DeleteDeviceType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).DeleteDeviceType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/DeleteDeviceType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).DeleteDeviceType(ctx, req.(*DeviceTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_SetDeviceLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDeviceLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).SetDeviceLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/SetDeviceLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).SetDeviceLabels(ctx, req.(*SetDeviceLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_RemoveDeviceLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).RemoveDeviceLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/RemoveDeviceLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).RemoveDeviceLabels(ctx, req.(*RemoveDeviceLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ChangeDeviceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeDeviceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ChangeDeviceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ChangeDeviceStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ChangeDeviceStatus(ctx, req.(*ChangeDeviceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_WatchDevices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDevicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeviceServiceServer).WatchDevices(m, &deviceServiceWatchDevicesServer{stream})
+}
+
+type DeviceService_WatchDevicesServer interface {
+	Send(*DeviceEvent) error
+	grpc.ServerStream
+}
+
+type deviceServiceWatchDevicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *deviceServiceWatchDevicesServer) Send(m *DeviceEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DeviceService_ReportTelemetry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTelemetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ReportTelemetry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ReportTelemetry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ReportTelemetry(ctx, req.(*ReportTelemetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceService_ReportTelemetryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeviceServiceServer).ReportTelemetryStream(&deviceServiceReportTelemetryStreamServer{stream})
+}
+
+type DeviceService_ReportTelemetryStreamServer interface {
+	SendAndClose(*ReportTelemetryResponse) error
+	Recv() (*ReportTelemetryRequest, error)
+	grpc.ServerStream
+}
+
+type deviceServiceReportTelemetryStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *deviceServiceReportTelemetryStreamServer) SendAndClose(m *ReportTelemetryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deviceServiceReportTelemetryStreamServer) Recv() (*ReportTelemetryRequest, error) {
+	m := new(ReportTelemetryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *deviceServiceClient) GetDeviceById(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
-	out := new(Device)
-	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceById", in, out, opts...)
-	if err != nil {
+func _DeviceService_GetTelemetryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTelemetryHistoryRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetTelemetryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetTelemetryHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetTelemetryHistory(ctx, req.(*GetTelemetryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *deviceServiceClient) GetDeviceBySerialNumber(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*Device, error) {
-	out := new(Device)
-	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDeviceBySerialNumber", in, out, opts...)
-	if err != nil {
+func _DeviceService_ReportLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportLocationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ReportLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ReportLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ReportLocation(ctx, req.(*ReportLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *deviceServiceClient) GetDevicesByUserId(ctx context.Context, in *DeviceRequest, opts ...grpc.CallOption) (*DeviceList, error) {
-	out := new(DeviceList)
-	err := c.cc.Invoke(ctx, "/service.DeviceService/GetDevicesByUserId", in, out, opts...)
-	if err != nil {
+func _DeviceService_GetLocationHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLocationHistoryRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetLocationHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetLocationHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetLocationHistory(ctx, req.(*GetLocationHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DeviceServiceServer is the server API for DeviceService service.
-// All implementations must embed UnimplementedDeviceServiceServer
-// for forward compatibility
-type DeviceServiceServer interface {
-	// Create a new device
-	CreateDevice(context.Context, *CreateDeviceRequest) (*DeviceResponse, error)
-	// Get a device by its ID
-	GetDeviceById(context.Context, *DeviceRequest) (*Device, error)
-	// Get a device by its serial number
-	GetDeviceBySerialNumber(context.Context, *DeviceRequest) (*Device, error)
-	// Get devices by a user ID
-	GetDevicesByUserId(context.Context, *DeviceRequest) (*DeviceList, error)
-	mustEmbedUnimplementedDeviceServiceServer()
+func _DeviceService_ExportLocationHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportLocationHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeviceServiceServer).ExportLocationHistory(m, &deviceServiceExportLocationHistoryServer{stream})
 }
 
-// UnimplementedDeviceServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedDeviceServiceServer struct {
+type DeviceService_ExportLocationHistoryServer interface {
+	Send(*ExportLocationHistoryResponse) error
+	grpc.ServerStream
 }
 
-func (UnimplementedDeviceServiceServer) CreateDevice(context.Context, *CreateDeviceRequest) (*DeviceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDevice not implemented")
+type deviceServiceExportLocationHistoryServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedDeviceServiceServer) GetDeviceById(context.Context, *DeviceRequest) (*Device, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceById not implemented")
+
+func (x *deviceServiceExportLocationHistoryServer) Send(m *ExportLocationHistoryResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedDeviceServiceServer) GetDeviceBySerialNumber(context.Context, *DeviceRequest) (*Device, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDeviceBySerialNumber not implemented")
+
+func _DeviceService_CreateGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).CreateGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/CreateGeofence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).CreateGeofence(ctx, req.(*CreateGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDeviceServiceServer) GetDevicesByUserId(context.Context, *DeviceRequest) (*DeviceList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetDevicesByUserId not implemented")
+
+func _DeviceService_GetGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).GetGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/GetGeofence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).GetGeofence(ctx, req.(*GeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDeviceServiceServer) mustEmbedUnimplementedDeviceServiceServer() {}
 
-// UnsafeDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DeviceServiceServer will
-// result in compilation errors.
-type UnsafeDeviceServiceServer interface {
-	mustEmbedUnimplementedDeviceServiceServer()
+func _DeviceService_ListGeofences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGeofencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).ListGeofences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/ListGeofences",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).ListGeofences(ctx, req.(*ListGeofencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDeviceServiceServer(s grpc.ServiceRegistrar, srv DeviceServiceServer) {
-	s.RegisterService(&DeviceService_ServiceDesc, srv)
+func _DeviceService_UpdateGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceServiceServer).UpdateGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.DeviceService/UpdateGeofence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceServiceServer).UpdateGeofence(ctx, req.(*UpdateGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DeviceService_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceRequest)
+func _DeviceService_DeleteGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeofenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).CreateDevice(ctx, in)
+		return srv.(DeviceServiceServer).DeleteGeofence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/service.DeviceService/CreateDevice",
+		FullMethod: "/service.DeviceService/DeleteGeofence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+		return srv.(DeviceServiceServer).DeleteGeofence(ctx, req.(*GeofenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeviceService_GetDeviceById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeviceRequest)
+func _DeviceService_ListGeofenceEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGeofenceEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).GetDeviceById(ctx, in)
+		return srv.(DeviceServiceServer).ListGeofenceEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/service.DeviceService/GetDeviceById",
+		FullMethod: "/service.DeviceService/ListGeofenceEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).GetDeviceById(ctx, req.(*DeviceRequest))
+		return srv.(DeviceServiceServer).ListGeofenceEvents(ctx, req.(*ListGeofenceEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeviceService_GetDeviceBySerialNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeviceRequest)
+func _DeviceService_GetTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TripRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).GetDeviceBySerialNumber(ctx, in)
+		return srv.(DeviceServiceServer).GetTrip(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/service.DeviceService/GetDeviceBySerialNumber",
+		FullMethod: "/service.DeviceService/GetTrip",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).GetDeviceBySerialNumber(ctx, req.(*DeviceRequest))
+		return srv.(DeviceServiceServer).GetTrip(ctx, req.(*TripRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DeviceService_GetDevicesByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeviceRequest)
+func _DeviceService_ListTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTripsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DeviceServiceServer).GetDevicesByUserId(ctx, in)
+		return srv.(DeviceServiceServer).ListTrips(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/service.DeviceService/GetDevicesByUserId",
+		FullMethod: "/service.DeviceService/ListTrips",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DeviceServiceServer).GetDevicesByUserId(ctx, req.(*DeviceRequest))
+		return srv.(DeviceServiceServer).ListTrips(ctx, req.(*ListTripsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -215,7 +1986,207 @@ var DeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDevicesByUserId",
 			Handler:    _DeviceService_GetDevicesByUserId_Handler,
 		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _DeviceService_ListDevices_Handler,
+		},
+		{
+			MethodName: "FindDevicesNear",
+			Handler:    _DeviceService_FindDevicesNear_Handler,
+		},
+		{
+			MethodName: "UpdateDevice",
+			Handler:    _DeviceService_UpdateDevice_Handler,
+		},
+		{
+			MethodName: "PatchDevice",
+			Handler:    _DeviceService_PatchDevice_Handler,
+		},
+		{
+			MethodName: "DeleteDevice",
+			Handler:    _DeviceService_DeleteDevice_Handler,
+		},
+		{
+			MethodName: "ListDeletedDevices",
+			Handler:    _DeviceService_ListDeletedDevices_Handler,
+		},
+		{
+			MethodName: "RestoreDevice",
+			Handler:    _DeviceService_RestoreDevice_Handler,
+		},
+		{
+			MethodName: "RotateDeviceKey",
+			Handler:    _DeviceService_RotateDeviceKey_Handler,
+		},
+		{
+			MethodName: "RevokeDeviceKey",
+			Handler:    _DeviceService_RevokeDeviceKey_Handler,
+		},
+		{
+			MethodName: "PreregisterDevices",
+			Handler:    _DeviceService_PreregisterDevices_Handler,
+		},
+		{
+			MethodName: "ClaimDevice",
+			Handler:    _DeviceService_ClaimDevice_Handler,
+		},
+		{
+			MethodName: "StartDeviceTransfer",
+			Handler:    _DeviceService_StartDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "AcceptDeviceTransfer",
+			Handler:    _DeviceService_AcceptDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "RejectDeviceTransfer",
+			Handler:    _DeviceService_RejectDeviceTransfer_Handler,
+		},
+		{
+			MethodName: "ListIncomingTransfers",
+			Handler:    _DeviceService_ListIncomingTransfers_Handler,
+		},
+		{
+			MethodName: "ShareDevice",
+			Handler:    _DeviceService_ShareDevice_Handler,
+		},
+		{
+			MethodName: "UnshareDevice",
+			Handler:    _DeviceService_UnshareDevice_Handler,
+		},
+		{
+			MethodName: "ListSharedDevices",
+			Handler:    _DeviceService_ListSharedDevices_Handler,
+		},
+		{
+			MethodName: "CreateDeviceGroup",
+			Handler:    _DeviceService_CreateDeviceGroup_Handler,
+		},
+		{
+			MethodName: "GetDeviceGroup",
+			Handler:    _DeviceService_GetDeviceGroup_Handler,
+		},
+		{
+			MethodName: "ListDeviceGroups",
+			Handler:    _DeviceService_ListDeviceGroups_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceGroup",
+			Handler:    _DeviceService_UpdateDeviceGroup_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceGroup",
+			Handler:    _DeviceService_DeleteDeviceGroup_Handler,
+		},
+		{
+			MethodName: "AddDevicesToGroup",
+			Handler:    _DeviceService_AddDevicesToGroup_Handler,
+		},
+		{
+			MethodName: "RemoveDevicesFromGroup",
+			Handler:    _DeviceService_RemoveDevicesFromGroup_Handler,
+		},
+		{
+			MethodName: "ChangeGroupStatus",
+			Handler:    _DeviceService_ChangeGroupStatus_Handler,
+		},
+		{
+			MethodName: "CreateDeviceType",
+			Handler:    _DeviceService_CreateDeviceType_Handler,
+		},
+		{
+			MethodName: "GetDeviceType",
+			Handler:    _DeviceService_GetDeviceType_Handler,
+		},
+		{
+			MethodName: "ListDeviceTypes",
+			Handler:    _DeviceService_ListDeviceTypes_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceType",
+			Handler:    _DeviceService_UpdateDeviceType_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceType",
+			Handler:    _DeviceService_DeleteDeviceType_Handler,
+		},
+		{
+			MethodName: "SetDeviceLabels",
+			Handler:    _DeviceService_SetDeviceLabels_Handler,
+		},
+		{
+			MethodName: "RemoveDeviceLabels",
+			Handler:    _DeviceService_RemoveDeviceLabels_Handler,
+		},
+		{
+			MethodName: "ChangeDeviceStatus",
+			Handler:    _DeviceService_ChangeDeviceStatus_Handler,
+		},
+		{
+			MethodName: "ReportTelemetry",
+			Handler:    _DeviceService_ReportTelemetry_Handler,
+		},
+		{
+			MethodName: "GetTelemetryHistory",
+			Handler:    _DeviceService_GetTelemetryHistory_Handler,
+		},
+		{
+			MethodName: "ReportLocation",
+			Handler:    _DeviceService_ReportLocation_Handler,
+		},
+		{
+			MethodName: "GetLocationHistory",
+			Handler:    _DeviceService_GetLocationHistory_Handler,
+		},
+		{
+			MethodName: "CreateGeofence",
+			Handler:    _DeviceService_CreateGeofence_Handler,
+		},
+		{
+			MethodName: "GetGeofence",
+			Handler:    _DeviceService_GetGeofence_Handler,
+		},
+		{
+			MethodName: "ListGeofences",
+			Handler:    _DeviceService_ListGeofences_Handler,
+		},
+		{
+			MethodName: "UpdateGeofence",
+			Handler:    _DeviceService_UpdateGeofence_Handler,
+		},
+		{
+			MethodName: "DeleteGeofence",
+			Handler:    _DeviceService_DeleteGeofence_Handler,
+		},
+		{
+			MethodName: "ListGeofenceEvents",
+			Handler:    _DeviceService_ListGeofenceEvents_Handler,
+		},
+		{
+			MethodName: "GetTrip",
+			Handler:    _DeviceService_GetTrip_Handler,
+		},
+		{
+			MethodName: "ListTrips",
+			Handler:    _DeviceService_ListTrips_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDevices",
+			Handler:       _DeviceService_WatchDevices_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReportTelemetryStream",
+			Handler:       _DeviceService_ReportTelemetryStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportLocationHistory",
+			Handler:       _DeviceService_ExportLocationHistory_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "grpc/proto/device.proto",
 }
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	authservice "github.com/BerryTracer/auth-service/grpc/proto"
+	"github.com/BerryTracer/device-service/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPublicMethods are the RPCs that can be called without a token.
+var DefaultPublicMethods = []string{
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// DefaultDeviceMethods are the RPCs a device can call with its own key. The
+// services check that a device only reaches its own data.
+var DefaultDeviceMethods = []string{
+	"/service.DeviceService/ReportTelemetry",
+	"/service.DeviceService/ReportTelemetryStream",
+	"/service.DeviceService/GetTelemetryHistory",
+	"/service.DeviceService/ReportLocation",
+	"/service.DeviceService/GetLocationHistory",
+	"/service.DeviceService/ExportLocationHistory",
+}
+
+// DeviceAuthenticator resolves a device key into the identity of its device.
+type DeviceAuthenticator interface {
+	AuthenticateDevice(ctx context.Context, key string) (*auth.Identity, error)
+}
+
+// AuthInterceptor authenticates every incoming call and stores the caller's
+// identity in the request context. Users send "authorization: Bearer <token>",
+// which is verified against the AuthService; devices send
+// "authorization: Device <key>" and may only call DeviceMethods.
+type AuthInterceptor struct {
+	AuthService         authservice.AuthServiceClient
+	DeviceAuthenticator DeviceAuthenticator
+	PublicMethods       map[string]bool
+	DeviceMethods       map[string]bool
+}
+
+// NewAuthInterceptor returns a new AuthInterceptor that lets publicMethods
+// through unauthenticated and accepts device keys for DefaultDeviceMethods.
+func NewAuthInterceptor(authService authservice.AuthServiceClient, deviceAuthenticator DeviceAuthenticator, publicMethods ...string) *AuthInterceptor {
+	return &AuthInterceptor{
+		AuthService:         authService,
+		DeviceAuthenticator: deviceAuthenticator,
+		PublicMethods:       methodSet(publicMethods),
+		DeviceMethods:       methodSet(DefaultDeviceMethods),
+	}
+}
+
+func methodSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		set[method] = true
+	}
+	return set
+}
+
+// Unary returns a unary server interceptor that authenticates each call.
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns a stream server interceptor that authenticates each stream.
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if i.PublicMethods[fullMethod] {
+		return ctx, nil
+	}
+
+	scheme, token, err := credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(scheme, "Device") {
+		return i.authenticateDevice(ctx, fullMethod, token)
+	}
+
+	if !strings.EqualFold(scheme, "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "authorization header must be a Bearer token or a Device key")
+	}
+
+	tokenResults, err := i.AuthService.VerifyToken(ctx, &authservice.VerifyTokenRequest{
+		Token: token,
+	})
+
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to verify token: %v", err)
+	}
+
+	if !tokenResults.Valid {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	userID := tokenResults.Claims["user_id"]
+	if userID == "" {
+		return nil, status.Error(codes.Unauthenticated, "token has no user_id claim")
+	}
+
+	return auth.NewContext(ctx, &auth.Identity{
+		UserID: userID,
+		Claims: tokenResults.Claims,
+	}), nil
+}
+
+func (i *AuthInterceptor) authenticateDevice(ctx context.Context, fullMethod string, key string) (context.Context, error) {
+	if i.DeviceAuthenticator == nil {
+		return nil, status.Error(codes.Unauthenticated, "device keys are not accepted")
+	}
+
+	identity, err := i.DeviceAuthenticator.AuthenticateDevice(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !i.DeviceMethods[fullMethod] {
+		return nil, status.Error(codes.PermissionDenied, "devices may only report and read their own telemetry and locations")
+	}
+
+	return auth.NewContext(ctx, identity), nil
+}
+
+// credentials extracts the scheme and token from the "authorization: <scheme> <token>" metadata.
+func credentials(ctx context.Context) (string, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "missing metadata from context")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || strings.TrimSpace(token) == "" {
+		return "", "", status.Error(codes.Unauthenticated, "authorization header must be a Bearer token or a Device key")
+	}
+
+	return scheme, strings.TrimSpace(token), nil
+}
+
+// authenticatedStream overrides the context of a server stream with the authenticated one.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	authservice "github.com/BerryTracer/auth-service/grpc/proto"
+	"github.com/BerryTracer/device-service/auth"
+	"github.com/BerryTracer/device-service/grpc/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthService accepts a single token and rejects everything else.
+type fakeAuthService struct {
+	authservice.AuthServiceClient
+	validToken string
+	calls      int
+}
+
+func (f *fakeAuthService) VerifyToken(_ context.Context, in *authservice.VerifyTokenRequest, _ ...grpc.CallOption) (*authservice.VerifyTokenResponse, error) {
+	f.calls++
+	if in.Token != f.validToken {
+		return &authservice.VerifyTokenResponse{Valid: false}, nil
+	}
+
+	return &authservice.VerifyTokenResponse{
+		Valid:  true,
+		Claims: map[string]string{"user_id": "user123"},
+	}, nil
+}
+
+// fakeDeviceAuthenticator accepts a single device key.
+type fakeDeviceAuthenticator struct {
+	validKey string
+}
+
+func (f *fakeDeviceAuthenticator) AuthenticateDevice(_ context.Context, key string) (*auth.Identity, error) {
+	if key != f.validKey {
+		return nil, status.Error(codes.Unauthenticated, "invalid device key")
+	}
+
+	return &auth.Identity{UserID: "user123", DeviceID: "device123"}, nil
+}
+
+func incomingContext(authorization string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", authorization))
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		wantCode   codes.Code
+		wantUser   string
+		wantDevice string
+	}{
+		{name: "valid bearer token", ctx: incomingContext("Bearer good-token"), method: "/service.DeviceService/GetDeviceById", wantCode: codes.OK, wantUser: "user123"},
+		{name: "lowercase scheme", ctx: incomingContext("bearer good-token"), method: "/service.DeviceService/GetDeviceById", wantCode: codes.OK, wantUser: "user123"},
+		{name: "invalid token", ctx: incomingContext("Bearer bad-token"), method: "/service.DeviceService/GetDeviceById", wantCode: codes.Unauthenticated},
+		{name: "missing scheme", ctx: incomingContext("good-token"), method: "/service.DeviceService/GetDevicesByUserId", wantCode: codes.Unauthenticated},
+		{name: "missing metadata", ctx: context.Background(), method: "/service.DeviceService/GetDeviceBySerialNumber", wantCode: codes.Unauthenticated},
+		{name: "public method", ctx: context.Background(), method: "/grpc.health.v1.Health/Check", wantCode: codes.OK},
+		{name: "unknown scheme", ctx: incomingContext("Basic dXNlcjpwYXNz"), method: "/service.DeviceService/GetDeviceById", wantCode: codes.Unauthenticated},
+		{name: "device key", ctx: incomingContext("Device good-key"), method: "/service.DeviceService/ReportTelemetry", wantCode: codes.OK, wantUser: "user123", wantDevice: "device123"},
+		{name: "invalid device key", ctx: incomingContext("Device bad-key"), method: "/service.DeviceService/ReportLocation", wantCode: codes.Unauthenticated},
+		{name: "device key on a user method", ctx: incomingContext("Device good-key"), method: "/service.DeviceService/DeleteDevice", wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authService := &fakeAuthService{validToken: "good-token"}
+			interceptor := server.NewAuthInterceptor(authService, &fakeDeviceAuthenticator{validKey: "good-key"}, server.DefaultPublicMethods...)
+
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				handlerCtx = ctx
+				return "ok", nil
+			}
+
+			_, err := interceptor.Unary()(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("expected code %v, got %v (%v)", tt.wantCode, code, err)
+			}
+
+			if tt.wantUser == "" {
+				return
+			}
+
+			identity, ok := auth.FromContext(handlerCtx)
+			if !ok || identity.UserID != tt.wantUser || identity.DeviceID != tt.wantDevice {
+				t.Errorf("expected identity %q in handler context, got %+v", tt.wantUser, identity)
+			}
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	authService := &fakeAuthService{validToken: "good-token"}
+	interceptor := server.NewAuthInterceptor(authService, nil)
+
+	var identity *auth.Identity
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		identity, _ = auth.FromContext(stream.Context())
+		return nil
+	}
+
+	stream := &fakeServerStream{ctx: incomingContext("Bearer good-token")}
+	err := interceptor.Stream()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/service.DeviceService/Watch"}, handler)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if identity == nil || identity.UserID != "user123" {
+		t.Errorf("expected identity user123 in stream context, got %+v", identity)
+	}
+}
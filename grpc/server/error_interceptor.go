@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/BerryTracer/device-service/service/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// errorCodes maps typed service errors to gRPC status codes.
+var errorCodes = map[errs.Kind]codes.Code{
+	errs.NotFound:           codes.NotFound,
+	errs.AlreadyExists:      codes.AlreadyExists,
+	errs.InvalidArgument:    codes.InvalidArgument,
+	errs.PermissionDenied:   codes.PermissionDenied,
+	errs.Unauthenticated:    codes.Unauthenticated,
+	errs.FailedPrecondition: codes.FailedPrecondition,
+	errs.ResourceExhausted:  codes.ResourceExhausted,
+	errs.Aborted:            codes.Aborted,
+}
+
+// ErrorUnaryInterceptor translates errors returned by unary handlers into gRPC status errors.
+func ErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatusError(info.FullMethod, err)
+		}
+
+		return resp, nil
+	}
+}
+
+// ErrorStreamInterceptor translates errors returned by stream handlers into gRPC status errors.
+func ErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return toStatusError(info.FullMethod, err)
+		}
+
+		return nil
+	}
+}
+
+// toStatusError converts err into a gRPC status error. Typed errors carry an
+// ErrorInfo detail and, for invalid arguments, a BadRequest detail listing the
+// offending fields. Untyped errors are logged and reported as Internal.
+func toStatusError(method string, err error) error {
+	var typed *errs.Error
+	if errors.As(err, &typed) {
+		return typedStatus(typed).Err()
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	log.Printf("DeviceGrpcServer %s failed: %v\n", method, err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+func typedStatus(err *errs.Error) *status.Status {
+	code, ok := errorCodes[err.Kind]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{
+		Reason:   err.Reason,
+		Domain:   errs.Domain,
+		Metadata: err.Metadata,
+	}}
+
+	if len(err.FieldViolations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range err.FieldViolations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st := status.New(code, err.Message)
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+
+	return withDetails
+}
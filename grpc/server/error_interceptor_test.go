@@ -0,0 +1,79 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BerryTracer/device-service/grpc/server"
+	"github.com/BerryTracer/device-service/service/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func callWithError(err error) error {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, err
+	}
+
+	_, callErr := server.ErrorUnaryInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/service.DeviceService/GetDeviceById"}, handler)
+	return callErr
+}
+
+func TestErrorUnaryInterceptor_Codes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "not found", err: errs.New(errs.NotFound, "DEVICE_NOT_FOUND", "device not found"), wantCode: codes.NotFound},
+		{name: "already exists", err: errs.New(errs.AlreadyExists, "DEVICE_ALREADY_EXISTS", "duplicate"), wantCode: codes.AlreadyExists},
+		{name: "permission denied", err: errs.New(errs.PermissionDenied, "DEVICE_ACCESS_DENIED", "denied"), wantCode: codes.PermissionDenied},
+		{name: "unauthenticated", err: errs.New(errs.Unauthenticated, "MISSING_IDENTITY", "who are you"), wantCode: codes.Unauthenticated},
+		{name: "resource exhausted", err: errs.New(errs.ResourceExhausted, "CLAIM_LOCKED", "too many attempts"), wantCode: codes.ResourceExhausted},
+		{name: "aborted", err: errs.New(errs.Aborted, "DEVICE_VERSION_MISMATCH", "stale"), wantCode: codes.Aborted},
+		{name: "status passthrough", err: status.Error(codes.Unavailable, "try later"), wantCode: codes.Unavailable},
+		{name: "context canceled", err: context.Canceled, wantCode: codes.Canceled},
+		{name: "untyped", err: errors.New("insert failed"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callWithError(tt.err)
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, code)
+			}
+		})
+	}
+}
+
+func TestErrorUnaryInterceptor_Details(t *testing.T) {
+	err := callWithError(errs.InvalidField("device.id", "must be a 24 character hex ObjectID"))
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", st.Code())
+	}
+
+	var errorInfo *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			errorInfo = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+
+	if errorInfo == nil || errorInfo.Reason != "INVALID_ARGUMENT" || errorInfo.Domain != errs.Domain {
+		t.Errorf("expected ErrorInfo detail, got %+v", errorInfo)
+	}
+
+	if badRequest == nil || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "device.id" {
+		t.Errorf("expected BadRequest detail for device.id, got %+v", badRequest)
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	gen "github.com/BerryTracer/device-service/grpc/proto"
+)
+
+// exportChunkSize is the largest amount of data sent in one export message.
+const exportChunkSize = 64 * 1024
+
+// exportWriter collects an exported document and sends it over the stream in
+// chunks of exportChunkSize bytes. The content type goes with the first chunk.
+type exportWriter struct {
+	stream      gen.DeviceService_ExportLocationHistoryServer
+	contentType string
+	buffer      []byte
+}
+
+func newExportWriter(stream gen.DeviceService_ExportLocationHistoryServer, contentType string) *exportWriter {
+	return &exportWriter{stream: stream, contentType: contentType}
+}
+
+// Write implements io.Writer.
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+
+	sent := 0
+	for len(w.buffer)-sent >= exportChunkSize {
+		if err := w.send(w.buffer[sent : sent+exportChunkSize]); err != nil {
+			return 0, err
+		}
+		sent += exportChunkSize
+	}
+
+	w.buffer = append(w.buffer[:0], w.buffer[sent:]...)
+	return len(p), nil
+}
+
+// Flush sends the data that does not fill a whole chunk. A document is always
+// sent in at least one message, even when it is empty.
+func (w *exportWriter) Flush() error {
+	if len(w.buffer) == 0 && w.contentType == "" {
+		return nil
+	}
+
+	err := w.send(w.buffer)
+	w.buffer = w.buffer[:0]
+	return err
+}
+
+func (w *exportWriter) send(data []byte) error {
+	response := &gen.ExportLocationHistoryResponse{Data: data, ContentType: w.contentType}
+	w.contentType = ""
+	return w.stream.Send(response)
+}
@@ -0,0 +1,94 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/BerryTracer/device-service/export"
+	gen "github.com/BerryTracer/device-service/grpc/proto"
+	"github.com/BerryTracer/device-service/grpc/server"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service"
+)
+
+// fakeLocationService writes a fixed document for every export.
+type fakeLocationService struct {
+	service.LocationService
+	document []byte
+	format   export.Format
+}
+
+func (s *fakeLocationService) ExportLocationHistory(_ context.Context, _ *model.LocationHistoryQuery, format export.Format, w io.Writer) error {
+	s.format = format
+	// Write in uneven pieces, as the encoders do.
+	for rest := s.document; len(rest) > 0; {
+		n := min(len(rest), 1000)
+		if _, err := w.Write(rest[:n]); err != nil {
+			return err
+		}
+		rest = rest[n:]
+	}
+	return nil
+}
+
+// fakeExportStream records the messages sent to the client.
+type fakeExportStream struct {
+	fakeServerStream
+	sent []*gen.ExportLocationHistoryResponse
+}
+
+func (s *fakeExportStream) Send(response *gen.ExportLocationHistoryResponse) error {
+	// The writer may reuse its buffer after Send returns, as gRPC has encoded the message by then.
+	response.Data = bytes.Clone(response.Data)
+	s.sent = append(s.sent, response)
+	return nil
+}
+
+func TestDeviceGrpcServer_ExportLocationHistory(t *testing.T) {
+	document := bytes.Repeat([]byte("0123456789"), 15000) // 150 kB
+	locationService := &fakeLocationService{document: document}
+	grpcServer := server.NewDeviceGrpcServer(nil, nil, locationService, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	stream := &fakeExportStream{fakeServerStream: fakeServerStream{ctx: context.Background()}}
+
+	err := grpcServer.ExportLocationHistory(&gen.ExportLocationHistoryRequest{Format: gen.ExportLocationHistoryRequest_GEOJSON}, stream)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if locationService.format != export.GeoJSON {
+		t.Errorf("expected the GeoJSON format, got %q", locationService.format)
+	}
+
+	// 150 kB are sent as two full chunks of 64 KiB and the rest.
+	if len(stream.sent) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(stream.sent))
+	}
+
+	var received []byte
+	for i, chunk := range stream.sent {
+		received = append(received, chunk.Data...)
+		if (i == 0) != (chunk.ContentType == "application/geo+json") {
+			t.Errorf("chunk %d: unexpected content type %q", i, chunk.ContentType)
+		}
+	}
+
+	if !bytes.Equal(received, document) {
+		t.Error("expected the chunks to add up to the document")
+	}
+}
+
+func TestDeviceGrpcServer_ExportLocationHistory_Empty(t *testing.T) {
+	grpcServer := server.NewDeviceGrpcServer(nil, nil, &fakeLocationService{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	stream := &fakeExportStream{fakeServerStream: fakeServerStream{ctx: context.Background()}}
+
+	if err := grpcServer.ExportLocationHistory(&gen.ExportLocationHistoryRequest{Format: gen.ExportLocationHistoryRequest_CSV}, stream); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Even an empty document is announced with its content type.
+	if len(stream.sent) != 1 || stream.sent[0].ContentType != "text/csv" || len(stream.sent[0].Data) != 0 {
+		t.Errorf("expected a single empty chunk, got %v", stream.sent)
+	}
+}
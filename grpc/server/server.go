@@ -3,27 +3,52 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
 	"log"
 	"net"
 
 	authservice "github.com/BerryTracer/auth-service/grpc/proto"
+	"github.com/BerryTracer/device-service/export"
 	gen "github.com/BerryTracer/device-service/grpc/proto"
 	"github.com/BerryTracer/device-service/model"
 	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type DeviceGrpcServer struct {
-	DeviceService service.DeviceService
-	AuthService   authservice.AuthServiceClient
+	DeviceService       service.DeviceService
+	TelemetryService    service.TelemetryService
+	LocationService     service.LocationService
+	GeofenceService     service.GeofenceService
+	TripService         service.TripService
+	CredentialService   service.CredentialService
+	ProvisioningService service.ProvisioningService
+	TransferService     service.TransferService
+	SharingService      service.SharingService
+	GroupService        service.GroupService
+	DeviceTypeService   service.DeviceTypeService
+	AuthService         authservice.AuthServiceClient
 	gen.UnimplementedDeviceServiceServer
 }
 
-func NewDeviceGrpcServer(deviceService service.DeviceService, authService authservice.AuthServiceClient) *DeviceGrpcServer {
+func NewDeviceGrpcServer(deviceService service.DeviceService, telemetryService service.TelemetryService, locationService service.LocationService, geofenceService service.GeofenceService, tripService service.TripService, credentialService service.CredentialService, provisioningService service.ProvisioningService, transferService service.TransferService, sharingService service.SharingService, groupService service.GroupService, deviceTypeService service.DeviceTypeService, authService authservice.AuthServiceClient) *DeviceGrpcServer {
 	return &DeviceGrpcServer{
-		DeviceService: deviceService,
-		AuthService:   authService,
+		DeviceService:       deviceService,
+		TelemetryService:    telemetryService,
+		LocationService:     locationService,
+		GeofenceService:     geofenceService,
+		TripService:         tripService,
+		CredentialService:   credentialService,
+		ProvisioningService: provisioningService,
+		TransferService:     transferService,
+		SharingService:      sharingService,
+		GroupService:        groupService,
+		DeviceTypeService:   deviceTypeService,
+		AuthService:         authService,
 	}
 }
 
@@ -34,8 +59,13 @@ func (s *DeviceGrpcServer) Run(port string) error {
 		return err
 	}
 
-	server := grpc.NewServer()                 // Consider adding any gRPC options here, if needed
-	gen.RegisterDeviceServiceServer(server, s) // Register your Device service with the gRPC server
+	authInterceptor := NewAuthInterceptor(s.AuthService, s.CredentialService, DefaultPublicMethods...)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(ErrorUnaryInterceptor(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(ErrorStreamInterceptor(), authInterceptor.Stream()),
+	)
+	gen.RegisterDeviceServiceServer(server, s)                // Register your Device service with the gRPC server
+	healthpb.RegisterHealthServer(server, health.NewServer()) // Health checks are served without authentication
 
 	log.Printf("DeviceGrpcServer listening on port %s\n", port)
 	if err := server.Serve(lis); err != nil {
@@ -45,108 +75,1159 @@ func (s *DeviceGrpcServer) Run(port string) error {
 	return nil
 }
 
-func (s *DeviceGrpcServer) CreateDevice(ctx context.Context, req *gen.CreateDeviceRequest) (*gen.DeviceResponse, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, errors.New("missing metadata from context")
+func (s *DeviceGrpcServer) CreateDevice(ctx context.Context, req *gen.CreateDeviceRequest) (*gen.Device, error) {
+	device := toModelDevice(req.Device)
+
+	err := s.DeviceService.CreateDevice(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) GetDeviceById(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
+	device, err := s.DeviceService.GetDeviceById(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) GetDeviceBySerialNumber(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
+	device, err := s.DeviceService.GetDeviceBySerialNumber(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) GetDevicesByUserId(ctx context.Context, req *gen.DeviceRequest) (*gen.DeviceList, error) {
+	devices, err := s.DeviceService.GetDevicesByUserId(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList []*gen.Device
+
+	for _, device := range devices {
+		deviceList = append(deviceList, toProtoDevice(device))
+	}
+
+	return &gen.DeviceList{
+		Devices: deviceList,
+	}, nil
+}
+
+func (s *DeviceGrpcServer) ListDevices(ctx context.Context, req *gen.ListDevicesRequest) (*gen.ListDevicesResponse, error) {
+	query := &model.ListDevicesQuery{
+		UserID:    req.UserId,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		OrderBy:   req.OrderBy,
+		Filter:    toModelDeviceFilter(req.Filter),
+	}
+
+	page, err := s.DeviceService.ListDevices(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList []*gen.Device
+
+	for _, device := range page.Devices {
+		deviceList = append(deviceList, toProtoDevice(device))
+	}
+
+	return &gen.ListDevicesResponse{
+		Devices:       deviceList,
+		NextPageToken: page.NextPageToken,
+		TotalSize:     page.TotalSize,
+	}, nil
+}
+
+func (s *DeviceGrpcServer) FindDevicesNear(ctx context.Context, req *gen.FindDevicesNearRequest) (*gen.FindDevicesNearResponse, error) {
+	devices, err := s.DeviceService.FindDevicesNear(ctx, &model.NearbyDevicesQuery{
+		UserID:    req.UserId,
+		Latitude:  req.Point.GetLatitude(),
+		Longitude: req.Point.GetLongitude(),
+		Radius:    req.Radius,
+		Filter:    toModelDeviceFilter(req.Filter),
+		Limit:     int(req.Limit),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.FindDevicesNearResponse{}
+	for _, nearby := range devices {
+		response.Devices = append(response.Devices, &gen.NearbyDevice{
+			Device:   toProtoDevice(nearby.Device),
+			Distance: nearby.Distance,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) UpdateDevice(ctx context.Context, req *gen.UpdateDeviceRequest) (*gen.Device, error) {
+	device := toModelDevice(req.Device)
+
+	version, err := toModelVersion("device.etag", req.Device.GetEtag())
+	if err != nil {
+		return nil, err
+	}
+	device.Version = version
+
+	updated, err := s.DeviceService.UpdateDevice(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(updated), nil
+}
+
+func (s *DeviceGrpcServer) PatchDevice(ctx context.Context, req *gen.PatchDeviceRequest) (*gen.Device, error) {
+	device := toModelDevice(req.Device)
+
+	version, err := toModelVersion("device.etag", req.Device.GetEtag())
+	if err != nil {
+		return nil, err
+	}
+	device.Version = version
+
+	updated, err := s.DeviceService.PatchDevice(ctx, device, req.UpdateMask.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(updated), nil
+}
+
+func (s *DeviceGrpcServer) RotateDeviceKey(ctx context.Context, req *gen.DeviceRequest) (*gen.DeviceKeyResponse, error) {
+	key, credential, err := s.CredentialService.RotateDeviceKey(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceKeyResponse{Id: req.Id, DeviceKey: key, IssuedAt: credential.IssuedAt}, nil
+}
+
+func (s *DeviceGrpcServer) RevokeDeviceKey(ctx context.Context, req *gen.DeviceRequest) (*gen.DeviceResponse, error) {
+	if err := s.CredentialService.RevokeDeviceKey(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) PreregisterDevices(ctx context.Context, req *gen.PreregisterDevicesRequest) (*gen.PreregisterDevicesResponse, error) {
+	devices := make([]*model.Device, 0, len(req.Devices))
+	for _, device := range req.Devices {
+		devices = append(devices, toModelDevice(device))
+	}
+
+	result, err := s.ProvisioningService.PreregisterDevices(ctx, devices)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.PreregisterDevicesResponse{ExistingSerialNumbers: result.ExistingSerials}
+	for _, device := range result.Devices {
+		response.Devices = append(response.Devices, toProtoDevice(device))
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) ClaimDevice(ctx context.Context, req *gen.ClaimDeviceRequest) (*gen.Device, error) {
+	device, err := s.ProvisioningService.ClaimDevice(ctx, req.SerialNumber, req.ClaimCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) StartDeviceTransfer(ctx context.Context, req *gen.StartDeviceTransferRequest) (*gen.Device, error) {
+	device, err := s.TransferService.StartTransfer(ctx, req.Id, req.ToUserId, req.WipeHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) AcceptDeviceTransfer(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
+	device, err := s.TransferService.AcceptTransfer(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) RejectDeviceTransfer(ctx context.Context, req *gen.DeviceRequest) (*gen.DeviceResponse, error) {
+	if err := s.TransferService.RejectTransfer(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) ListIncomingTransfers(ctx context.Context, _ *gen.ListIncomingTransfersRequest) (*gen.DeviceList, error) {
+	devices, err := s.TransferService.ListIncomingTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList []*gen.Device
+	for _, device := range devices {
+		deviceList = append(deviceList, toProtoDevice(device))
+	}
+
+	return &gen.DeviceList{Devices: deviceList}, nil
+}
+
+func (s *DeviceGrpcServer) ShareDevice(ctx context.Context, req *gen.ShareDeviceRequest) (*gen.Device, error) {
+	share := &model.DeviceShare{
+		UserID:    req.UserId,
+		Role:      toModelRole(req.Role),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	device, err := s.SharingService.ShareDevice(ctx, req.Id, share)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) UnshareDevice(ctx context.Context, req *gen.UnshareDeviceRequest) (*gen.DeviceResponse, error) {
+	if err := s.SharingService.UnshareDevice(ctx, req.Id, req.UserId); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) ListSharedDevices(ctx context.Context, _ *gen.ListSharedDevicesRequest) (*gen.DeviceList, error) {
+	devices, err := s.SharingService.ListSharedDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList []*gen.Device
+	for _, device := range devices {
+		deviceList = append(deviceList, toProtoDevice(device))
+	}
+
+	return &gen.DeviceList{Devices: deviceList}, nil
+}
+
+func (s *DeviceGrpcServer) CreateDeviceGroup(ctx context.Context, req *gen.CreateDeviceGroupRequest) (*gen.DeviceGroup, error) {
+	group := toModelDeviceGroup(req.Group)
+
+	if err := s.GroupService.CreateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceGroup(group), nil
+}
+
+func (s *DeviceGrpcServer) GetDeviceGroup(ctx context.Context, req *gen.DeviceGroupRequest) (*gen.DeviceGroup, error) {
+	group, err := s.GroupService.GetGroupById(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceGroup(group), nil
+}
+
+func (s *DeviceGrpcServer) ListDeviceGroups(ctx context.Context, req *gen.ListDeviceGroupsRequest) (*gen.ListDeviceGroupsResponse, error) {
+	groups, err := s.GroupService.GetGroupsByUserId(ctx, req.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.ListDeviceGroupsResponse{}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, toProtoDeviceGroup(group))
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) UpdateDeviceGroup(ctx context.Context, req *gen.UpdateDeviceGroupRequest) (*gen.DeviceGroup, error) {
+	group := toModelDeviceGroup(req.Group)
+
+	if err := s.GroupService.UpdateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceGroup(group), nil
+}
+
+func (s *DeviceGrpcServer) DeleteDeviceGroup(ctx context.Context, req *gen.DeviceGroupRequest) (*gen.DeviceGroupResponse, error) {
+	if err := s.GroupService.DeleteGroup(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceGroupResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) AddDevicesToGroup(ctx context.Context, req *gen.GroupMembershipRequest) (*gen.DeviceGroupResponse, error) {
+	if err := s.GroupService.AddDevicesToGroup(ctx, req.GroupId, req.DeviceIds); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceGroupResponse{Id: req.GroupId, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) RemoveDevicesFromGroup(ctx context.Context, req *gen.GroupMembershipRequest) (*gen.DeviceGroupResponse, error) {
+	if err := s.GroupService.RemoveDevicesFromGroup(ctx, req.GroupId, req.DeviceIds); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceGroupResponse{Id: req.GroupId, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) ChangeGroupStatus(ctx context.Context, req *gen.ChangeGroupStatusRequest) (*gen.ChangeGroupStatusResponse, error) {
+	result, err := s.GroupService.ChangeGroupStatus(ctx, req.GroupId, toModelStatus(req.Status), req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.ChangeGroupStatusResponse{ChangedDeviceIds: result.ChangedDeviceIDs}
+	for _, failure := range result.Failures {
+		response.Failures = append(response.Failures, &gen.ChangeGroupStatusResponse_Failure{
+			DeviceId: failure.DeviceID,
+			Reason:   failure.Reason,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) CreateDeviceType(ctx context.Context, req *gen.CreateDeviceTypeRequest) (*gen.DeviceType, error) {
+	deviceType := toModelDeviceType(req.DeviceType)
+
+	if err := s.DeviceTypeService.CreateDeviceType(ctx, deviceType); err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceType(deviceType), nil
+}
+
+func (s *DeviceGrpcServer) GetDeviceType(ctx context.Context, req *gen.DeviceTypeRequest) (*gen.DeviceType, error) {
+	deviceType, err := s.DeviceTypeService.GetDeviceType(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceType(deviceType), nil
+}
+
+func (s *DeviceGrpcServer) ListDeviceTypes(ctx context.Context, req *gen.ListDeviceTypesRequest) (*gen.ListDeviceTypesResponse, error) {
+	deviceTypes, err := s.DeviceTypeService.ListDeviceTypes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.ListDeviceTypesResponse{}
+	for _, deviceType := range deviceTypes {
+		response.DeviceTypes = append(response.DeviceTypes, toProtoDeviceType(deviceType))
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) UpdateDeviceType(ctx context.Context, req *gen.UpdateDeviceTypeRequest) (*gen.DeviceType, error) {
+	deviceType := toModelDeviceType(req.DeviceType)
+
+	if err := s.DeviceTypeService.UpdateDeviceType(ctx, deviceType); err != nil {
+		return nil, err
+	}
+
+	return toProtoDeviceType(deviceType), nil
+}
+
+func (s *DeviceGrpcServer) DeleteDeviceType(ctx context.Context, req *gen.DeviceTypeRequest) (*gen.DeviceTypeResponse, error) {
+	if err := s.DeviceTypeService.DeleteDeviceType(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceTypeResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) SetDeviceLabels(ctx context.Context, req *gen.SetDeviceLabelsRequest) (*gen.Device, error) {
+	device, err := s.DeviceService.SetDeviceLabels(ctx, req.Id, req.Tags, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) RemoveDeviceLabels(ctx context.Context, req *gen.RemoveDeviceLabelsRequest) (*gen.Device, error) {
+	device, err := s.DeviceService.RemoveDeviceLabels(ctx, req.Id, req.Tags, req.LabelKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) ChangeDeviceStatus(ctx context.Context, req *gen.ChangeDeviceStatusRequest) (*gen.Device, error) {
+	version, err := toModelVersion("etag", req.Etag)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := s.DeviceService.ChangeDeviceStatus(ctx, req.Id, toModelStatus(req.Status), req.Reason, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) DeleteDevice(ctx context.Context, req *gen.DeleteDeviceRequest) (*gen.DeviceResponse, error) {
+	version, err := toModelVersion("etag", req.Etag)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.DeviceService.DeleteDevice(ctx, req.Id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gen.DeviceResponse{
+		Id:      req.Id,
+		Success: true,
+	}, nil
+}
+
+func (s *DeviceGrpcServer) ListDeletedDevices(ctx context.Context, req *gen.ListDeletedDevicesRequest) (*gen.DeviceList, error) {
+	devices, err := s.DeviceService.ListDeletedDevices(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList []*gen.Device
+	for _, device := range devices {
+		deviceList = append(deviceList, toProtoDevice(device))
+	}
+
+	return &gen.DeviceList{Devices: deviceList}, nil
+}
+
+func (s *DeviceGrpcServer) RestoreDevice(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
+	device, err := s.DeviceService.RestoreDevice(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoDevice(device), nil
+}
+
+func (s *DeviceGrpcServer) WatchDevices(req *gen.WatchDevicesRequest, stream gen.DeviceService_WatchDevicesServer) error {
+	ctx := stream.Context()
+
+	events, err := s.DeviceService.WatchDevices(ctx, req.UserId, req.GroupId, req.ResumeToken)
+	if err != nil {
+		return err
+	}
+	defer events.Close(context.Background())
+
+	for {
+		event, err := events.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil // The client went away
+			}
+			return err
+		}
+
+		if err := stream.Send(toProtoDeviceEvent(event)); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *DeviceGrpcServer) ReportTelemetry(ctx context.Context, req *gen.ReportTelemetryRequest) (*gen.ReportTelemetryResponse, error) {
+	samples := toModelTelemetrySamples(req.Samples)
+
+	if err := s.TelemetryService.ReportTelemetry(ctx, req.DeviceId, samples); err != nil {
+		return nil, err
+	}
+
+	return &gen.ReportTelemetryResponse{Accepted: int32(len(samples))}, nil
+}
+
+// ReportTelemetryStream stores each request as a batch as soon as it arrives.
+// Batches stored before an error are kept.
+func (s *DeviceGrpcServer) ReportTelemetryStream(stream gen.DeviceService_ReportTelemetryStreamServer) error {
+	var accepted int32
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&gen.ReportTelemetryResponse{Accepted: accepted})
+		}
+		if err != nil {
+			return err
+		}
+
+		samples := toModelTelemetrySamples(req.Samples)
+		if err := s.TelemetryService.ReportTelemetry(stream.Context(), req.DeviceId, samples); err != nil {
+			return err
+		}
+		accepted += int32(len(samples))
 	}
+}
 
-	token := md["authorization"][0]
-	tokenResults, err := s.AuthService.VerifyToken(ctx, &authservice.VerifyTokenRequest{
-		Token: token,
+func (s *DeviceGrpcServer) GetTelemetryHistory(ctx context.Context, req *gen.GetTelemetryHistoryRequest) (*gen.TelemetryHistory, error) {
+	samples, err := s.TelemetryService.GetTelemetryHistory(ctx, &model.TelemetryQuery{
+		DeviceID: req.DeviceId,
+		From:     req.StartTime,
+		To:       req.EndTime,
+		Interval: req.IntervalSeconds,
+		Limit:    int(req.Limit),
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	if !tokenResults.Valid {
-		return nil, errors.New("invalid token")
+	history := &gen.TelemetryHistory{}
+	for _, sample := range samples {
+		history.Samples = append(history.Samples, toProtoTelemetrySample(sample))
+	}
+
+	return history, nil
+}
+
+func (s *DeviceGrpcServer) ReportLocation(ctx context.Context, req *gen.ReportLocationRequest) (*gen.ReportLocationResponse, error) {
+	locations := make([]*model.Location, 0, len(req.Locations))
+	for _, location := range req.Locations {
+		locations = append(locations, toModelLocation(location))
 	}
 
-	device := &model.Device{
-		ID:               req.Device.Id,
-		UserID:           req.Device.UserId,
-		SerialNumber:     req.Device.SerialNumber,
-		Name:             req.Device.Name,
-		Status:           req.Device.Status,
-		DeviceType:       req.Device.DeviceType,
-		RegistrationDate: req.Device.RegistrationDate,
-		BatteryLevel:     int(req.Device.BatteryLevel),
+	if err := s.LocationService.ReportLocation(ctx, req.DeviceId, locations); err != nil {
+		return nil, err
 	}
 
-	err = s.DeviceService.CreateDevice(ctx, device)
+	return &gen.ReportLocationResponse{Accepted: int32(len(locations))}, nil
+}
+
+func (s *DeviceGrpcServer) GetLocationHistory(ctx context.Context, req *gen.GetLocationHistoryRequest) (*gen.GetLocationHistoryResponse, error) {
+	page, err := s.LocationService.GetLocationHistory(ctx, &model.LocationHistoryQuery{
+		DeviceID:  req.DeviceId,
+		From:      req.StartTime,
+		To:        req.EndTime,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+
 	if err != nil {
 		return nil, err
 	}
 
-	return &gen.DeviceResponse{
-		Id:      device.ID,
-		Success: true,
-	}, nil
+	response := &gen.GetLocationHistoryResponse{NextPageToken: page.NextPageToken}
+	for _, location := range page.Locations {
+		response.Locations = append(response.Locations, toProtoLocation(location))
+	}
+
+	return response, nil
 }
 
-func (s *DeviceGrpcServer) GetDeviceById(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
-	device, err := s.DeviceService.GetDeviceById(ctx, req.Id)
+func (s *DeviceGrpcServer) ExportLocationHistory(req *gen.ExportLocationHistoryRequest, stream gen.DeviceService_ExportLocationHistoryServer) error {
+	query := &model.LocationHistoryQuery{
+		DeviceID: req.DeviceId,
+		From:     req.StartTime,
+		To:       req.EndTime,
+	}
+
+	format := exportFormats[req.Format]
+	writer := newExportWriter(stream, format.ContentType())
+	if err := s.LocationService.ExportLocationHistory(stream.Context(), query, format, writer); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+func (s *DeviceGrpcServer) CreateGeofence(ctx context.Context, req *gen.CreateGeofenceRequest) (*gen.Geofence, error) {
+	geofence := toModelGeofence(req.Geofence)
+
+	if err := s.GeofenceService.CreateGeofence(ctx, geofence); err != nil {
+		return nil, err
+	}
+
+	return toProtoGeofence(geofence), nil
+}
+
+func (s *DeviceGrpcServer) GetGeofence(ctx context.Context, req *gen.GeofenceRequest) (*gen.Geofence, error) {
+	geofence, err := s.GeofenceService.GetGeofenceById(ctx, req.Id)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &gen.Device{
-		Id:               device.ID,
-		UserId:           device.UserID,
-		SerialNumber:     device.SerialNumber,
-		Name:             device.Name,
-		Status:           device.Status,
-		DeviceType:       device.DeviceType,
-		RegistrationDate: device.RegistrationDate,
-		BatteryLevel:     int32(device.BatteryLevel),
-	}, nil
+	return toProtoGeofence(geofence), nil
 }
 
-func (s *DeviceGrpcServer) GetDeviceBySerialNumber(ctx context.Context, req *gen.DeviceRequest) (*gen.Device, error) {
-	device, err := s.DeviceService.GetDeviceBySerialNumber(ctx, req.Id)
+func (s *DeviceGrpcServer) ListGeofences(ctx context.Context, req *gen.ListGeofencesRequest) (*gen.ListGeofencesResponse, error) {
+	geofences, err := s.GeofenceService.GetGeofencesByUserId(ctx, req.UserId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.ListGeofencesResponse{}
+	for _, geofence := range geofences {
+		response.Geofences = append(response.Geofences, toProtoGeofence(geofence))
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) UpdateGeofence(ctx context.Context, req *gen.UpdateGeofenceRequest) (*gen.Geofence, error) {
+	geofence := toModelGeofence(req.Geofence)
+
+	if err := s.GeofenceService.UpdateGeofence(ctx, geofence); err != nil {
+		return nil, err
+	}
+
+	return toProtoGeofence(geofence), nil
+}
+
+func (s *DeviceGrpcServer) DeleteGeofence(ctx context.Context, req *gen.GeofenceRequest) (*gen.GeofenceResponse, error) {
+	if err := s.GeofenceService.DeleteGeofence(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	return &gen.GeofenceResponse{Id: req.Id, Success: true}, nil
+}
+
+func (s *DeviceGrpcServer) ListGeofenceEvents(ctx context.Context, req *gen.ListGeofenceEventsRequest) (*gen.ListGeofenceEventsResponse, error) {
+	page, err := s.GeofenceService.ListGeofenceEvents(ctx, &model.GeofenceEventQuery{
+		GeofenceID: req.GeofenceId,
+		DeviceID:   req.DeviceId,
+		From:       req.StartTime,
+		To:         req.EndTime,
+		PageSize:   int(req.PageSize),
+		PageToken:  req.PageToken,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	response := &gen.ListGeofenceEventsResponse{NextPageToken: page.NextPageToken}
+	for _, event := range page.Events {
+		response.Events = append(response.Events, toProtoGeofenceEvent(event))
+	}
+
+	return response, nil
+}
+
+func (s *DeviceGrpcServer) GetTrip(ctx context.Context, req *gen.TripRequest) (*gen.Trip, error) {
+	trip, err := s.TripService.GetTrip(ctx, req.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoTrip(trip), nil
+}
+
+func (s *DeviceGrpcServer) ListTrips(ctx context.Context, req *gen.ListTripsRequest) (*gen.ListTripsResponse, error) {
+	page, err := s.TripService.ListTrips(ctx, &model.TripQuery{
+		DeviceID:     req.DeviceId,
+		From:         req.StartTime,
+		To:           req.EndTime,
+		IncludeStops: req.IncludeStops,
+		PageSize:     int(req.PageSize),
+		PageToken:    req.PageToken,
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	response := &gen.ListTripsResponse{NextPageToken: page.NextPageToken}
+	for _, trip := range page.Trips {
+		response.Trips = append(response.Trips, toProtoTrip(trip))
+	}
+
+	return response, nil
+}
+
+func toModelDevice(device *gen.Device) *model.Device {
+	return &model.Device{
+		ID:               device.GetId(),
+		UserID:           device.GetUserId(),
+		SerialNumber:     device.GetSerialNumber(),
+		Name:             device.GetName(),
+		Status:           toModelStatus(device.GetStatus()),
+		DeviceType:       device.GetDeviceType(),
+		RegistrationDate: device.GetRegistrationDate(),
+		BatteryLevel:     int(device.GetBatteryLevel()),
+		Tags:             device.GetTags(),
+		Labels:           device.GetLabels(),
+		Attributes:       toModelAttributes(device.GetAttributes()),
+	}
+}
+
+func toProtoDevice(device *model.Device) *gen.Device {
 	return &gen.Device{
 		Id:               device.ID,
 		UserId:           device.UserID,
 		SerialNumber:     device.SerialNumber,
 		Name:             device.Name,
-		Status:           device.Status,
+		Status:           toProtoStatus(device.Status),
 		DeviceType:       device.DeviceType,
 		RegistrationDate: device.RegistrationDate,
 		BatteryLevel:     int32(device.BatteryLevel),
-	}, nil
+		LastStatusChange: toProtoStatusChange(device.LastStatusChange),
+		ChargingState:    toProtoChargingState(device.ChargingState),
+		SignalStrength:   int32(device.SignalStrength),
+		LastTelemetryAt:  device.LastTelemetryAt,
+		LastLocation:     toProtoLocation(device.LastLocation),
+		DeviceKey:        device.DeviceKey,
+		ClaimCode:        device.ClaimCode,
+		Transfer:         toProtoDeviceTransfer(device.Transfer),
+		Shares:           toProtoDeviceShares(device.Shares),
+		Role:             toProtoRole(device.Role),
+		GroupIds:         device.GroupIDs,
+		Tags:             device.Tags,
+		Labels:           device.Labels,
+		Attributes:       toProtoAttributes(device.Attributes),
+		DeletedAt:        device.DeletedAt,
+		DeletedBy:        device.DeletedBy,
+		Etag:             model.ETag(device.Version),
+	}
 }
 
-func (s *DeviceGrpcServer) GetDevicesByUserId(ctx context.Context, req *gen.DeviceRequest) (*gen.DeviceList, error) {
-	devices, err := s.DeviceService.GetDevicesByUserId(ctx, req.Id)
+// toModelVersion returns the device version an etag stands for.
+func toModelVersion(field, etag string) (int64, error) {
+	version, err := model.ParseETag(etag)
+	if err != nil {
+		return 0, errs.InvalidField(field, "must be an etag returned by the service")
+	}
+	return version, nil
+}
+
+func toModelAttributes(attributes *structpb.Struct) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+	return attributes.AsMap()
+}
 
+// toProtoAttributes drops attributes that have no JSON representation. The
+// service only stores attributes it received as JSON, so only documents edited
+// in the database by hand can have them.
+func toProtoAttributes(attributes map[string]interface{}) *structpb.Struct {
+	if attributes == nil {
+		return nil
+	}
+
+	protoAttributes, err := structpb.NewStruct(attributes)
 	if err != nil {
-		return nil, err
+		log.Printf("failed to convert device attributes: %v", err)
+		return nil
+	}
+	return protoAttributes
+}
+
+func toProtoDeviceTransfer(transfer *model.DeviceTransfer) *gen.DeviceTransfer {
+	if transfer == nil {
+		return nil
 	}
 
-	var deviceList []*gen.Device
+	return &gen.DeviceTransfer{
+		ToUserId:    transfer.ToUserID,
+		WipeHistory: transfer.WipeHistory,
+		CreatedAt:   transfer.CreatedAt,
+		ExpiresAt:   transfer.ExpiresAt,
+	}
+}
 
-	for _, device := range devices {
-		deviceList = append(deviceList, &gen.Device{
-			Id:               device.ID,
-			UserId:           device.UserID,
-			SerialNumber:     device.SerialNumber,
-			Name:             device.Name,
-			Status:           device.Status,
-			DeviceType:       device.DeviceType,
-			RegistrationDate: device.RegistrationDate,
-			BatteryLevel:     int32(device.BatteryLevel),
+func toProtoDeviceShares(shares []model.DeviceShare) []*gen.DeviceShare {
+	var protoShares []*gen.DeviceShare
+	for _, share := range shares {
+		protoShares = append(protoShares, &gen.DeviceShare{
+			UserId:    share.UserID,
+			Role:      toProtoRole(share.Role),
+			GrantedBy: share.GrantedBy,
+			GrantedAt: share.GrantedAt,
+			ExpiresAt: share.ExpiresAt,
 		})
 	}
+	return protoShares
+}
 
-	return &gen.DeviceList{
-		Devices: deviceList,
-	}, nil
+func toModelDeviceGroup(group *gen.DeviceGroup) *model.DeviceGroup {
+	return &model.DeviceGroup{
+		ID:          group.GetId(),
+		UserID:      group.GetUserId(),
+		Name:        group.GetName(),
+		Description: group.GetDescription(),
+		CreatedAt:   group.GetCreatedAt(),
+	}
+}
+
+func toProtoDeviceGroup(group *model.DeviceGroup) *gen.DeviceGroup {
+	return &gen.DeviceGroup{
+		Id:          group.ID,
+		UserId:      group.UserID,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   group.CreatedAt,
+	}
+}
+
+func toModelDeviceType(deviceType *gen.DeviceType) *model.DeviceType {
+	var capabilities []model.Capability
+	for _, capability := range deviceType.GetCapabilities() {
+		capabilities = append(capabilities, toModelCapability(capability))
+	}
+
+	return &model.DeviceType{
+		ID:                  deviceType.GetId(),
+		DisplayName:         deviceType.GetDisplayName(),
+		Capabilities:        capabilities,
+		SerialNumberPattern: deviceType.GetSerialNumberPattern(),
+		BatteryChemistry:    toModelBatteryChemistry(deviceType.GetBatteryChemistry()),
+		ReportingInterval:   deviceType.GetReportingInterval(),
+		AttributesSchema:    deviceType.GetAttributesSchema(),
+	}
+}
+
+func toProtoDeviceType(deviceType *model.DeviceType) *gen.DeviceType {
+	var capabilities []gen.DeviceCapability
+	for _, capability := range deviceType.Capabilities {
+		capabilities = append(capabilities, deviceCapabilities[capability])
+	}
+
+	return &gen.DeviceType{
+		Id:                  deviceType.ID,
+		DisplayName:         deviceType.DisplayName,
+		Capabilities:        capabilities,
+		SerialNumberPattern: deviceType.SerialNumberPattern,
+		BatteryChemistry:    batteryChemistries[deviceType.BatteryChemistry],
+		ReportingInterval:   deviceType.ReportingInterval,
+		AttributesSchema:    deviceType.AttributesSchema,
+		CreatedAt:           deviceType.CreatedAt,
+		UpdatedAt:           deviceType.UpdatedAt,
+	}
+}
+
+var deviceCapabilities = map[model.Capability]gen.DeviceCapability{
+	model.CapabilityGPS:         gen.DeviceCapability_GPS,
+	model.CapabilityTemperature: gen.DeviceCapability_TEMPERATURE,
+	model.CapabilitySOSButton:   gen.DeviceCapability_SOS_BUTTON,
+	model.CapabilityCellular:    gen.DeviceCapability_CELLULAR,
+}
+
+// toModelCapability maps an unspecified capability to an empty one, which the
+// service rejects.
+func toModelCapability(capability gen.DeviceCapability) model.Capability {
+	for modelCapability, protoCapability := range deviceCapabilities {
+		if protoCapability == capability {
+			return modelCapability
+		}
+	}
+	return ""
+}
+
+var batteryChemistries = map[model.BatteryChemistry]gen.BatteryChemistry{
+	model.ChemistryLithiumIon:           gen.BatteryChemistry_LITHIUM_ION,
+	model.ChemistryLithiumPolymer:       gen.BatteryChemistry_LITHIUM_POLYMER,
+	model.ChemistryLithiumIronPhosphate: gen.BatteryChemistry_LITHIUM_IRON_PHOSPHATE,
+	model.ChemistryAlkaline:             gen.BatteryChemistry_ALKALINE,
+	model.ChemistryNickelMetalHydride:   gen.BatteryChemistry_NICKEL_METAL_HYDRIDE,
+}
+
+func toModelBatteryChemistry(chemistry gen.BatteryChemistry) model.BatteryChemistry {
+	for modelChemistry, protoChemistry := range batteryChemistries {
+		if protoChemistry == chemistry {
+			return modelChemistry
+		}
+	}
+	return model.ChemistryUnknown
+}
+
+var deviceRoles = map[model.DeviceRole]gen.DeviceRole{
+	model.RoleOwner:  gen.DeviceRole_OWNER,
+	model.RoleEditor: gen.DeviceRole_EDITOR,
+	model.RoleViewer: gen.DeviceRole_VIEWER,
+}
+
+func toModelRole(role gen.DeviceRole) model.DeviceRole {
+	for modelRole, protoRole := range deviceRoles {
+		if protoRole == role {
+			return modelRole
+		}
+	}
+	return ""
+}
+
+func toProtoRole(role model.DeviceRole) gen.DeviceRole {
+	return deviceRoles[role]
+}
+
+func toModelDeviceFilter(filter *gen.DeviceFilter) model.DeviceFilter {
+	modelFilter := model.DeviceFilter{
+		Status:        toModelStatus(filter.GetStatus()),
+		DeviceType:    filter.GetDeviceType(),
+		GroupID:       filter.GetGroupId(),
+		Tags:          filter.GetTags(),
+		LabelSelector: filter.GetLabelSelector(),
+	}
+
+	if filter != nil && filter.BatteryBelow != nil {
+		batteryBelow := int(filter.GetBatteryBelow())
+		modelFilter.BatteryBelow = &batteryBelow
+	}
+
+	return modelFilter
+}
+
+var deviceStatuses = map[model.DeviceStatus]gen.DeviceStatus{
+	model.StatusProvisioned:    gen.DeviceStatus_PROVISIONED,
+	model.StatusActive:         gen.DeviceStatus_ACTIVE,
+	model.StatusInactive:       gen.DeviceStatus_INACTIVE,
+	model.StatusLost:           gen.DeviceStatus_LOST,
+	model.StatusMaintenance:    gen.DeviceStatus_MAINTENANCE,
+	model.StatusDecommissioned: gen.DeviceStatus_DECOMMISSIONED,
+}
+
+func toModelStatus(status gen.DeviceStatus) model.DeviceStatus {
+	for modelStatus, protoStatus := range deviceStatuses {
+		if protoStatus == status {
+			return modelStatus
+		}
+	}
+	return ""
+}
+
+func toProtoStatus(status model.DeviceStatus) gen.DeviceStatus {
+	return deviceStatuses[status]
+}
+
+func toProtoStatusChange(change *model.StatusChange) *gen.StatusChange {
+	if change == nil {
+		return nil
+	}
+
+	return &gen.StatusChange{
+		From:      toProtoStatus(change.From),
+		To:        toProtoStatus(change.To),
+		ChangedBy: change.ChangedBy,
+		ChangedAt: change.ChangedAt,
+		Reason:    change.Reason,
+	}
+}
+
+var deviceEventTypes = map[model.DeviceEventType]gen.DeviceEvent_Type{
+	model.DeviceCreated: gen.DeviceEvent_CREATED,
+	model.DeviceUpdated: gen.DeviceEvent_UPDATED,
+	model.DeviceDeleted: gen.DeviceEvent_DELETED,
+}
+
+func toProtoDeviceEvent(event *model.DeviceEvent) *gen.DeviceEvent {
+	return &gen.DeviceEvent{
+		Type:        deviceEventTypes[event.Type],
+		Device:      toProtoDevice(event.Device),
+		ResumeToken: event.ResumeToken,
+		Time:        event.Time,
+	}
+}
+
+var chargingStates = map[model.ChargingState]gen.ChargingState{
+	model.ChargingCharging:    gen.ChargingState_CHARGING,
+	model.ChargingDischarging: gen.ChargingState_DISCHARGING,
+	model.ChargingFull:        gen.ChargingState_FULL,
+}
+
+func toModelChargingState(state gen.ChargingState) model.ChargingState {
+	for modelState, protoState := range chargingStates {
+		if protoState == state {
+			return modelState
+		}
+	}
+	return model.ChargingUnknown
+}
+
+func toProtoChargingState(state model.ChargingState) gen.ChargingState {
+	return chargingStates[state]
+}
+
+func toModelTelemetrySamples(samples []*gen.TelemetrySample) []*model.TelemetrySample {
+	modelSamples := make([]*model.TelemetrySample, 0, len(samples))
+	for _, sample := range samples {
+		modelSamples = append(modelSamples, &model.TelemetrySample{
+			Timestamp:      sample.GetTimestamp(),
+			BatteryLevel:   int(sample.GetBatteryLevel()),
+			ChargingState:  toModelChargingState(sample.GetChargingState()),
+			SignalStrength: int(sample.GetSignalStrength()),
+		})
+	}
+	return modelSamples
+}
+
+func toProtoTelemetrySample(sample *model.TelemetrySample) *gen.TelemetrySample {
+	return &gen.TelemetrySample{
+		Timestamp:      sample.Timestamp,
+		BatteryLevel:   int32(sample.BatteryLevel),
+		ChargingState:  toProtoChargingState(sample.ChargingState),
+		SignalStrength: int32(sample.SignalStrength),
+	}
+}
+
+func toModelLocation(location *gen.Location) *model.Location {
+	return &model.Location{
+		Latitude:  location.GetLatitude(),
+		Longitude: location.GetLongitude(),
+		Accuracy:  location.GetAccuracy(),
+		Altitude:  location.GetAltitude(),
+		Speed:     location.GetSpeed(),
+		Heading:   location.GetHeading(),
+		Timestamp: location.GetTimestamp(),
+	}
+}
+
+func toProtoLocation(location *model.Location) *gen.Location {
+	if location == nil {
+		return nil
+	}
+
+	return &gen.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Accuracy:  location.Accuracy,
+		Altitude:  location.Altitude,
+		Speed:     location.Speed,
+		Heading:   location.Heading,
+		Timestamp: location.Timestamp,
+	}
+}
+
+func toModelGeofence(geofence *gen.Geofence) *model.Geofence {
+	modelGeofence := &model.Geofence{
+		ID:           geofence.GetId(),
+		UserID:       geofence.GetUserId(),
+		Name:         geofence.GetName(),
+		DeviceIDs:    geofence.GetDeviceIds(),
+		DwellSeconds: geofence.GetDwellSeconds(),
+	}
+
+	switch shape := geofence.GetShape().(type) {
+	case *gen.Geofence_Circle:
+		modelGeofence.Shape = model.GeofenceCircle
+		if center := shape.Circle.GetCenter(); center != nil {
+			modelGeofence.Center = &model.LatLng{Latitude: center.GetLatitude(), Longitude: center.GetLongitude()}
+		}
+		modelGeofence.Radius = shape.Circle.GetRadius()
+	case *gen.Geofence_Polygon:
+		modelGeofence.Shape = model.GeofencePolygon
+		for _, vertex := range shape.Polygon.GetVertices() {
+			modelGeofence.Vertices = append(modelGeofence.Vertices, model.LatLng{Latitude: vertex.GetLatitude(), Longitude: vertex.GetLongitude()})
+		}
+	}
+
+	return modelGeofence
+}
+
+func toProtoGeofence(geofence *model.Geofence) *gen.Geofence {
+	protoGeofence := &gen.Geofence{
+		Id:           geofence.ID,
+		UserId:       geofence.UserID,
+		Name:         geofence.Name,
+		DeviceIds:    geofence.DeviceIDs,
+		DwellSeconds: geofence.DwellSeconds,
+		CreatedAt:    geofence.CreatedAt,
+	}
+
+	switch geofence.Shape {
+	case model.GeofenceCircle:
+		circle := &gen.Circle{Radius: geofence.Radius}
+		if geofence.Center != nil {
+			circle.Center = toProtoLatLng(*geofence.Center)
+		}
+		protoGeofence.Shape = &gen.Geofence_Circle{Circle: circle}
+	case model.GeofencePolygon:
+		polygon := &gen.Polygon{}
+		for _, vertex := range geofence.Vertices {
+			polygon.Vertices = append(polygon.Vertices, toProtoLatLng(vertex))
+		}
+		protoGeofence.Shape = &gen.Geofence_Polygon{Polygon: polygon}
+	}
+
+	return protoGeofence
+}
+
+func toProtoLatLng(position model.LatLng) *gen.LatLng {
+	return &gen.LatLng{Latitude: position.Latitude, Longitude: position.Longitude}
+}
+
+var exportFormats = map[gen.ExportLocationHistoryRequest_Format]export.Format{
+	gen.ExportLocationHistoryRequest_GPX:     export.GPX,
+	gen.ExportLocationHistoryRequest_KML:     export.KML,
+	gen.ExportLocationHistoryRequest_GEOJSON: export.GeoJSON,
+	gen.ExportLocationHistoryRequest_CSV:     export.CSV,
+}
+
+var geofenceEventTypes = map[model.GeofenceEventType]gen.GeofenceEvent_Type{
+	model.GeofenceEnter: gen.GeofenceEvent_ENTER,
+	model.GeofenceExit:  gen.GeofenceEvent_EXIT,
+	model.GeofenceDwell: gen.GeofenceEvent_DWELL,
+}
+
+func toProtoGeofenceEvent(event *model.GeofenceEvent) *gen.GeofenceEvent {
+	return &gen.GeofenceEvent{
+		Id:         event.ID,
+		GeofenceId: event.GeofenceID,
+		DeviceId:   event.DeviceID,
+		Type:       geofenceEventTypes[event.Type],
+		Position:   toProtoLatLng(event.Position),
+		Time:       event.Time,
+	}
+}
+
+var tripKinds = map[model.TripKind]gen.Trip_Kind{
+	model.TripKindTrip: gen.Trip_TRIP,
+	model.TripKindStop: gen.Trip_STOP,
+}
+
+func toProtoTrip(trip *model.Trip) *gen.Trip {
+	return &gen.Trip{
+		Id:           trip.ID,
+		DeviceId:     trip.DeviceID,
+		Kind:         tripKinds[trip.Kind],
+		Start:        toProtoLatLng(trip.StartPosition),
+		StartTime:    trip.StartTime,
+		End:          toProtoLatLng(trip.EndPosition),
+		EndTime:      trip.EndTime,
+		Distance:     trip.Distance,
+		Duration:     trip.Duration,
+		MaxSpeed:     trip.MaxSpeed,
+		AverageSpeed: trip.AverageSpeed,
+	}
 }
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/BerryTracer/device-service/grpc/server"
 	"github.com/BerryTracer/device-service/repository"
 	"github.com/BerryTracer/device-service/service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -63,30 +66,234 @@ func main() {
 		}
 	}(mongoDB, ctx)
 
+	// Serial numbers used to be unique across every device. Devices in the trash
+	// now give up their serial number, so the index is replaced by a partial one.
+	// Dropping it fails with IndexNotFound once it is gone, which is expected.
+	if _, err := mongoDB.GetCollection().Indexes().DropOne(ctx, "serial_number_1"); err != nil {
+		var commandErr mongo.CommandError
+		if !errors.As(err, &commandErr) || (commandErr.Name != "IndexNotFound" && commandErr.Name != "NamespaceNotFound") {
+			log.Fatalf("failed to drop index: %v", err)
+		}
+	}
+
 	// Create MongoDB indexes
 	indexSpecs := []mongodb.IndexSpec{
 		{
-			Key:     map[string]interface{}{"serial_number": 1},
-			Options: options.Index().SetUnique(true),
+			// Only live devices have a serial_number; deleted devices keep theirs in deleted_serial_number.
+			Key: map[string]interface{}{"serial_number": 1},
+			Options: options.Index().
+				SetName("serial_number_live").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"serial_number": bson.M{"$exists": true}}),
+		},
+		{
+			// Deleted devices are purged in order of deletion.
+			Key:     map[string]interface{}{"deleted_at": 1},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"deleted_at": bson.M{"$exists": true}}),
+		},
+		{
+			Key:     map[string]interface{}{"user_id": 1},
+			Options: options.Index(),
+		},
+		{
+			// Device types can only be deleted once no device has them.
+			Key:     map[string]interface{}{"device_type": 1},
+			Options: options.Index(),
+		},
+		{
+			Key:     map[string]interface{}{"last_location": "2dsphere"},
+			Options: options.Index(),
+		},
+		{
+			Key:     map[string]interface{}{"credential.key_hash": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Key:     map[string]interface{}{"transfer.to_user_id": 1},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			// Interrupted history wipes are finished by the purger.
+			Key:     map[string]interface{}{"history_wipe_pending": 1},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Key:     map[string]interface{}{"shares.user_id": 1},
+			Options: options.Index(),
+		},
+		{
+			// Labels have arbitrary keys, so a wildcard index covers every label selector.
+			Key:     map[string]interface{}{"labels.$**": 1},
+			Options: options.Index(),
 		},
 	}
 	if err := mongoDB.CreateIndexes(ctx, indexSpecs); err != nil {
 		log.Fatalf("failed to create indexes: %v", err)
 	}
 
+	// Record pre-images so change streams can report who owned a deleted device.
+	// This needs MongoDB 6.0+ running as a replica set; without it delete events are not delivered.
+	enablePreImages := bson.D{
+		{Key: "collMod", Value: mongoDB.GetCollection().Name()},
+		{Key: "changeStreamPreAndPostImages", Value: bson.M{"enabled": true}},
+	}
+	if err := mongoDB.GetCollection().Database().RunCommand(ctx, enablePreImages).Err(); err != nil {
+		log.Printf("failed to enable change stream pre-images, delete events will not be watched: %v", err)
+	}
+
+	// Store telemetry samples in a time-series collection next to the devices.
+	// Creating it fails with NamespaceExists once it is there, which is expected.
+	database := mongoDB.GetCollection().Database()
+	telemetryOptions := options.CreateCollection().SetTimeSeriesOptions(
+		options.TimeSeries().SetTimeField("timestamp").SetMetaField("device_id").SetGranularity("minutes"),
+	)
+	if err := database.CreateCollection(ctx, "device_telemetry", telemetryOptions); err != nil {
+		var commandErr mongo.CommandError
+		if !errors.As(err, &commandErr) || commandErr.Name != "NamespaceExists" {
+			log.Fatalf("failed to create telemetry collection: %v", err)
+		}
+	}
+
+	// Location history is read per device in time order.
+	// The keys are ordered, so the index is created directly rather than through an IndexSpec map.
+	locationCollection := database.Collection("device_locations")
+	locationIndex := mongo.IndexModel{Keys: bson.D{{Key: "device_id", Value: 1}, {Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}}
+	if _, err := locationCollection.Indexes().CreateOne(ctx, locationIndex); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Geofences are looked up by owner and by the devices they watch; their
+	// events are read per geofence in time order.
+	geofenceCollection := database.Collection("geofences")
+	geofenceIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "device_ids", Value: 1}}},
+	}
+	if _, err := geofenceCollection.Indexes().CreateMany(ctx, geofenceIndexes); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Events are also deleted per device when a device changes hands.
+	geofenceEventCollection := database.Collection("geofence_events")
+	geofenceEventIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "geofence_id", Value: 1}, {Key: "time", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "device_id", Value: 1}}},
+	}
+	if _, err := geofenceEventCollection.Indexes().CreateMany(ctx, geofenceEventIndexes); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Devices are listed per owner and group or tag, so those listings stay index-backed.
+	// The keys are ordered, so the indexes are created directly rather than through an IndexSpec map.
+	deviceOwnerIndexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "group_ids", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "tags", Value: 1}}},
+	}
+	if _, err := mongoDB.GetCollection().Indexes().CreateMany(ctx, deviceOwnerIndexes); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Group names are unique per user.
+	groupCollection := database.Collection("device_groups")
+	groupIndex := mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := groupCollection.Indexes().CreateOne(ctx, groupIndex); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Trips are read per device in start order; the detection state is keyed by device ID.
+	tripCollection := database.Collection("trips")
+	tripIndex := mongo.IndexModel{Keys: bson.D{{Key: "device_id", Value: 1}, {Key: "start_time", Value: 1}, {Key: "_id", Value: 1}}}
+	if _, err := tripCollection.Indexes().CreateOne(ctx, tripIndex); err != nil {
+		log.Fatalf("failed to create indexes: %v", err)
+	}
+
+	// Trip detection thresholds per device type, as JSON, e.g. {"bike": {"moving_speed": 2}}.
+	// Device types without thresholds use the defaults.
+	var tripThresholds service.TripThresholds
+	if value := envLoader.Getenv("TRIP_THRESHOLDS"); value != "" {
+		tripThresholds, err = service.ParseTripThresholds(value)
+		if err != nil {
+			log.Fatalf("failed to load trip thresholds: %v", err)
+		}
+	}
+
+	// How long deleted devices stay in the trash before they are purged, e.g. "720h".
+	trashRetention := service.DefaultTrashRetention
+	if value := envLoader.Getenv("DEVICE_TRASH_RETENTION"); value != "" {
+		trashRetention, err = time.ParseDuration(value)
+		if err != nil || trashRetention <= 0 {
+			log.Fatalf("failed to load the trash retention: %q is not a positive duration", value)
+		}
+	}
+
 	// --- Repository and Service Initialization ---
-	// Initialize the MongoDB adapter for the device repository
-	mongoDBAdapter := mongodb.NewMongoAdapter(mongoDB.GetCollection())
+	// Initialize the MongoDB collection for the device repository
+	deviceCollection := repository.NewMongoCollection(mongoDB.GetCollection())
+
+	// Set up the device repository with the MongoDB collection
+	deviceRepository := repository.NewDeviceMongoRepository(deviceCollection)
+
+	// Watch device changes through MongoDB change streams
+	deviceChangeFeed := repository.NewDeviceMongoChangeFeed(deviceCollection)
+
+	// The catalog of device types, keyed by their ID
+	deviceTypeRepository := repository.NewDeviceTypeMongoRepository(repository.NewMongoCollection(database.Collection("device_types")))
+	deviceTypeService := service.NewDeviceTypeService(deviceTypeRepository, deviceRepository)
+
+	// Initialize the device service with the device repository, the device-type catalog and the change feed
+	deviceService := service.NewDeviceService(deviceRepository, deviceTypeRepository, deviceChangeFeed)
+
+	// Record telemetry in its own collection and keep the latest values on the device
+	telemetryRepository := repository.NewTelemetryMongoRepository(repository.NewMongoCollection(database.Collection("device_telemetry")))
+	telemetryService := service.NewTelemetryService(telemetryRepository, deviceRepository)
+
+	// Issue, rotate and verify the keys devices authenticate their own calls with
+	credentialService := service.NewCredentialService(deviceRepository)
+
+	// Preregister devices at the factory and let users claim them
+	provisioningService := service.NewProvisioningService(deviceRepository, deviceTypeRepository)
+
+	// Manage geofences and evaluate every location report against them
+	geofenceRepository := repository.NewGeofenceMongoRepository(
+		repository.NewMongoCollection(geofenceCollection),
+		repository.NewMongoCollection(geofenceEventCollection),
+	)
+	geofenceService := service.NewGeofenceService(geofenceRepository, deviceRepository)
+
+	// Group reported locations into trips and stops as they arrive
+	tripRepository := repository.NewTripMongoRepository(
+		repository.NewMongoCollection(tripCollection),
+		repository.NewMongoCollection(database.Collection("trip_states")),
+	)
+	tripService := service.NewTripService(tripRepository, deviceRepository, tripThresholds)
+
+	// Record location history and keep the last location on the device
+	locationRepository := repository.NewLocationMongoRepository(repository.NewMongoCollection(locationCollection))
+	locationService := service.NewLocationService(locationRepository, deviceRepository, geofenceService, tripService)
+
+	// Hand devices over between users, erasing their history on request
+	transferService := service.NewTransferService(deviceRepository, telemetryRepository, locationRepository, tripRepository, geofenceRepository)
+
+	// Share devices with other users as viewers or editors
+	sharingService := service.NewSharingService(deviceRepository)
 
-	// Set up the device repository with the MongoDB adapter
-	deviceRepository := repository.NewDeviceMongoRepository(mongoDBAdapter)
+	// Organize devices into groups and change the status of a whole group at once
+	groupRepository := repository.NewGroupMongoRepository(repository.NewMongoCollection(groupCollection))
+	groupService := service.NewGroupService(groupRepository, deviceRepository, deviceService)
 
-	// Initialize the device service with the device repository
-	deviceService := service.NewDeviceService(deviceRepository)
+	// Purge devices from the trash in the background, together with their history,
+	// and finish history wipes of transferred devices that were interrupted
+	devicePurger := service.NewDevicePurger(deviceRepository, trashRetention, telemetryRepository, locationRepository, tripRepository, geofenceRepository)
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go devicePurger.Run(purgeCtx)
 
 	// --- gRPC Server Initialization ---
 	// Start the Device gRPC server
-	err = server.NewDeviceGrpcServer(deviceService, authServiceClient).Run(":50053")
+	err = server.NewDeviceGrpcServer(deviceService, telemetryService, locationService, geofenceService, tripService, credentialService, provisioningService, transferService, sharingService, groupService, deviceTypeService, authServiceClient).Run(":50053")
 	if err != nil {
 		log.Fatalf("failed to create indexes: %v", err)
 	}
@@ -5,25 +5,132 @@ import (
 )
 
 type Device struct {
-	ID               string `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID           string `bson:"user_id" json:"user_id"`
-	SerialNumber     string `bson:"serial_number" json:"serial_number"`
-	DeviceType       string `bson:"device_type" json:"device_type"`
-	Name             string `bson:"name" json:"name"`
-	Status           string `bson:"status" json:"status"`
-	RegistrationDate int64  `bson:"registration_date" json:"registration_date"`
-	BatteryLevel     int    `bson:"battery_level" json:"battery_level"`
+	ID                string                 `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID            string                 `bson:"user_id" json:"user_id"`
+	SerialNumber      string                 `bson:"serial_number" json:"serial_number"`
+	DeviceType        string                 `bson:"device_type" json:"device_type"`
+	Name              string                 `bson:"name" json:"name"`
+	Status            DeviceStatus           `bson:"status" json:"status"`
+	RegistrationDate  int64                  `bson:"registration_date" json:"registration_date"`
+	BatteryLevel      int                    `bson:"battery_level" json:"battery_level"`
+	LastStatusChange  *StatusChange          `bson:"last_status_change,omitempty" json:"last_status_change,omitempty"`
+	ChargingState     ChargingState          `bson:"charging_state,omitempty" json:"charging_state,omitempty"`
+	SignalStrength    int                    `bson:"signal_strength,omitempty" json:"signal_strength,omitempty"`
+	LastTelemetryAt   int64                  `bson:"last_telemetry_at,omitempty" json:"last_telemetry_at,omitempty"`
+	LastLocation      *Location              `bson:"-" json:"last_location,omitempty"`
+	Credential        *DeviceCredential      `bson:"credential,omitempty" json:"-"`
+	DeviceKey         string                 `bson:"-" json:"device_key,omitempty"` // The plain key, only set when it is issued
+	Claim             *DeviceClaim           `bson:"claim,omitempty" json:"-"`
+	ClaimCode         string                 `bson:"-" json:"claim_code,omitempty"` // The plain claim code, only set when it is issued
+	Transfer          *DeviceTransfer        `bson:"transfer,omitempty" json:"transfer,omitempty"`
+	Shares            []DeviceShare          `bson:"shares,omitempty" json:"shares,omitempty"`
+	GroupIDs          []string               `bson:"group_ids,omitempty" json:"group_ids,omitempty"`
+	Tags              []string               `bson:"tags,omitempty" json:"tags,omitempty"`
+	Labels            map[string]string      `bson:"labels,omitempty" json:"labels,omitempty"`
+	Attributes        map[string]interface{} `bson:"attributes,omitempty" json:"attributes,omitempty"` // Decoded JSON, checked against the schema of the device type
+	Role              DeviceRole             `bson:"-" json:"role,omitempty"`                          // The caller's role, set on listings
+	DeletedAt         int64                  `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` // Unix timestamp (seconds since epoch); set while the device is in the trash
+	DeletedBy         string                 `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"` // The user who moved the device to the trash
+	Version           int64                  `bson:"version" json:"version"`                           // Expected by writes when set; see DeviceDB.Version
+	HistoryWipeBefore int64                  `bson:"-" json:"-"`                                       // Set while a history wipe is pending; see DeviceDB.HistoryWipeBefore
 }
 
 type DeviceDB struct {
-	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID           string             `bson:"user_id" json:"user_id"`
-	SerialNumber     string             `bson:"serial_number" json:"serial_number"`
-	DeviceType       string             `bson:"device_type" json:"device_type"`
-	Name             string             `bson:"name" json:"name"`
-	Status           string             `bson:"status" json:"status"`
-	RegistrationDate int64              `bson:"registration_date" json:"registration_date"`
-	BatteryLevel     int                `bson:"battery_level" json:"battery_level"`
+	ID               primitive.ObjectID     `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID           string                 `bson:"user_id" json:"user_id"`
+	SerialNumber     string                 `bson:"serial_number" json:"serial_number"`
+	DeviceType       string                 `bson:"device_type" json:"device_type"`
+	Name             string                 `bson:"name" json:"name"`
+	Status           DeviceStatus           `bson:"status" json:"status"`
+	RegistrationDate int64                  `bson:"registration_date" json:"registration_date"`
+	BatteryLevel     int                    `bson:"battery_level" json:"battery_level"`
+	LastStatusChange *StatusChange          `bson:"last_status_change,omitempty" json:"last_status_change,omitempty"`
+	ChargingState    ChargingState          `bson:"charging_state,omitempty" json:"charging_state,omitempty"`
+	SignalStrength   int                    `bson:"signal_strength,omitempty" json:"signal_strength,omitempty"`
+	LastTelemetryAt  int64                  `bson:"last_telemetry_at,omitempty" json:"last_telemetry_at,omitempty"`
+	LastLocation     *GeoPoint              `bson:"last_location,omitempty" json:"last_location,omitempty"` // Indexed with 2dsphere
+	LastFix          *LocationFix           `bson:"last_fix,omitempty" json:"last_fix,omitempty"`
+	Credential       *DeviceCredential      `bson:"credential,omitempty" json:"-"` // Indexed, unique by key hash
+	Claim            *DeviceClaim           `bson:"claim,omitempty" json:"-"`
+	Transfer         *DeviceTransfer        `bson:"transfer,omitempty" json:"transfer,omitempty"`   // Indexed by recipient
+	Shares           []DeviceShare          `bson:"shares,omitempty" json:"shares,omitempty"`       // Indexed by user
+	GroupIDs         []string               `bson:"group_ids,omitempty" json:"group_ids,omitempty"` // Indexed with user_id
+	Tags             []string               `bson:"tags,omitempty" json:"tags,omitempty"`           // Indexed with user_id
+	Labels           map[string]string      `bson:"labels,omitempty" json:"labels,omitempty"`       // Indexed by a wildcard index
+	Attributes       map[string]interface{} `bson:"attributes,omitempty" json:"attributes,omitempty"`
+	DeletedAt        int64                  `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+	DeletedBy        string                 `bson:"deleted_by,omitempty" json:"deleted_by,omitempty"`
+	// Version counts the changes made to the device by its users: edits,
+	// status changes, labels, groups, shares, keys, claims, transfers and the
+	// trash. Reported telemetry and locations, failed claim attempts and the
+	// bookkeeping of history wipes leave it alone, so a reporting device never
+	// conflicts with an edit. Devices stored before versions existed have
+	// none, which reads as 0.
+	Version int64 `bson:"version"`
+	// DeletedSerialNumber holds the serial number of a device in the trash. It
+	// is moved out of serial_number, which is only unique among live devices,
+	// so that the serial number can be registered again.
+	DeletedSerialNumber string `bson:"deleted_serial_number,omitempty" json:"deleted_serial_number,omitempty"`
+	// HistoryWipePending is set when a transfer that wipes the history is
+	// accepted, and cleared once the history is gone. It lets an interrupted
+	// wipe be finished later.
+	HistoryWipePending bool `bson:"history_wipe_pending,omitempty" json:"-"`
+	// HistoryWipeBefore is when the transfer whose wipe is pending was
+	// accepted. Only the history up to then is wiped, so a wipe finished late
+	// leaves alone what the device has reported to its new owner.
+	HistoryWipeBefore int64 `bson:"history_wipe_before,omitempty" json:"-"`
+}
+
+// Clone returns a copy of the device that shares no slices, maps or nested
+// structs with it. Attribute values below the top level are shared, as they
+// are only ever replaced, never changed in place.
+func (d *Device) Clone() *Device {
+	clone := *d
+
+	if d.LastStatusChange != nil {
+		change := *d.LastStatusChange
+		clone.LastStatusChange = &change
+	}
+	if d.LastLocation != nil {
+		location := *d.LastLocation
+		clone.LastLocation = &location
+	}
+	if d.Credential != nil {
+		credential := *d.Credential
+		clone.Credential = &credential
+	}
+	if d.Claim != nil {
+		claim := *d.Claim
+		clone.Claim = &claim
+	}
+	if d.Transfer != nil {
+		transfer := *d.Transfer
+		clone.Transfer = &transfer
+	}
+
+	if d.Shares != nil {
+		clone.Shares = append([]DeviceShare(nil), d.Shares...)
+	}
+	if d.GroupIDs != nil {
+		clone.GroupIDs = append([]string(nil), d.GroupIDs...)
+	}
+	if d.Tags != nil {
+		clone.Tags = append([]string(nil), d.Tags...)
+	}
+	if d.Labels != nil {
+		clone.Labels = make(map[string]string, len(d.Labels))
+		for key, value := range d.Labels {
+			clone.Labels[key] = value
+		}
+	}
+	if d.Attributes != nil {
+		clone.Attributes = make(map[string]interface{}, len(d.Attributes))
+		for key, value := range d.Attributes {
+			clone.Attributes[key] = value
+		}
+	}
+
+	return &clone
 }
 
 func (d *Device) ToDeviceDB() (*DeviceDB, error) {
@@ -32,7 +139,7 @@ func (d *Device) ToDeviceDB() (*DeviceDB, error) {
 		return nil, err
 	}
 
-	return &DeviceDB{
+	deviceDB := &DeviceDB{
 		ID:               objectID,
 		UserID:           d.UserID,
 		SerialNumber:     d.SerialNumber,
@@ -41,18 +148,68 @@ func (d *Device) ToDeviceDB() (*DeviceDB, error) {
 		Status:           d.Status,
 		RegistrationDate: d.RegistrationDate,
 		BatteryLevel:     d.BatteryLevel,
-	}, nil
+		LastStatusChange: d.LastStatusChange,
+		ChargingState:    d.ChargingState,
+		SignalStrength:   d.SignalStrength,
+		LastTelemetryAt:  d.LastTelemetryAt,
+		Credential:       d.Credential,
+		Claim:            d.Claim,
+		Transfer:         d.Transfer,
+		Shares:           d.Shares,
+		GroupIDs:         d.GroupIDs,
+		Tags:             d.Tags,
+		Labels:           d.Labels,
+		Attributes:       d.Attributes,
+		DeletedAt:        d.DeletedAt,
+		DeletedBy:        d.DeletedBy,
+		Version:          d.Version,
+	}
+
+	if d.LastLocation != nil {
+		point := NewGeoPoint(d.LastLocation.Latitude, d.LastLocation.Longitude)
+		fix := d.LastLocation.Fix()
+		deviceDB.LastLocation = &point
+		deviceDB.LastFix = &fix
+	}
+
+	return deviceDB, nil
 }
 
 func (d *DeviceDB) ToDevice() *Device {
-	return &Device{
-		ID:               d.ID.Hex(),
-		UserID:           d.UserID,
-		SerialNumber:     d.SerialNumber,
-		DeviceType:       d.DeviceType,
-		Name:             d.Name,
-		Status:           d.Status,
-		RegistrationDate: d.RegistrationDate,
-		BatteryLevel:     d.BatteryLevel,
+	device := &Device{
+		ID:                d.ID.Hex(),
+		UserID:            d.UserID,
+		SerialNumber:      d.SerialNumber,
+		DeviceType:        d.DeviceType,
+		Name:              d.Name,
+		Status:            d.Status,
+		RegistrationDate:  d.RegistrationDate,
+		BatteryLevel:      d.BatteryLevel,
+		LastStatusChange:  d.LastStatusChange,
+		ChargingState:     d.ChargingState,
+		SignalStrength:    d.SignalStrength,
+		LastTelemetryAt:   d.LastTelemetryAt,
+		Credential:        d.Credential,
+		Claim:             d.Claim,
+		Transfer:          d.Transfer,
+		Shares:            d.Shares,
+		GroupIDs:          d.GroupIDs,
+		Tags:              d.Tags,
+		Labels:            d.Labels,
+		Attributes:        attributesFromDB(d.Attributes),
+		DeletedAt:         d.DeletedAt,
+		DeletedBy:         d.DeletedBy,
+		Version:           d.Version,
+		HistoryWipeBefore: d.HistoryWipeBefore,
+	}
+
+	if d.DeletedSerialNumber != "" {
+		device.SerialNumber = d.DeletedSerialNumber
 	}
+
+	if d.LastLocation != nil && d.LastFix != nil {
+		device.LastLocation = newLocation(device.ID, *d.LastLocation, *d.LastFix)
+	}
+
+	return device
 }
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// attributesFromDB converts the attributes sub-document of a device back into
+// the shape of decoded JSON that Device.Attributes has: nested documents become
+// maps, arrays become slices and every number a float64.
+func attributesFromDB(attributes map[string]interface{}) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		result[key] = attributeFromDB(value)
+	}
+	return result
+}
+
+func attributeFromDB(value interface{}) interface{} {
+	switch value := value.(type) {
+	case primitive.D:
+		return attributesFromDB(value.Map())
+	case primitive.M:
+		return attributesFromDB(value)
+	case map[string]interface{}:
+		return attributesFromDB(value)
+	case primitive.A:
+		return attributesFromArray(value)
+	case []interface{}:
+		return attributesFromArray(value)
+	case int32:
+		return float64(value)
+	case int64:
+		return float64(value)
+	case int:
+		return float64(value)
+	default:
+		return value
+	}
+}
+
+func attributesFromArray(values []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, attributeFromDB(value))
+	}
+	return result
+}
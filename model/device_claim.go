@@ -0,0 +1,23 @@
+package model
+
+// DeviceClaim is the one-time code an unowned device is claimed with. Only a
+// hash of the code is stored; the code itself is shown once when the device is
+// preregistered.
+type DeviceClaim struct {
+	CodeHash       string `bson:"code_hash" json:"-"`                               // Hex-encoded SHA-256 of the normalized code
+	FailedAttempts int    `bson:"failed_attempts" json:"failed_attempts"`           // Wrong codes since the last lockout
+	LockedUntil    int64  `bson:"locked_until" json:"locked_until"`                 // Unix timestamp until which claims are refused
+	ClaimedAt      int64  `bson:"claimed_at,omitempty" json:"claimed_at"`           // Unix timestamp, set once the code is used
+	ClaimedBy      string `bson:"claimed_by,omitempty" json:"claimed_by,omitempty"` // User ID of the claiming user
+}
+
+// Claimed reports whether the claim code has been used.
+func (c *DeviceClaim) Claimed() bool {
+	return c.ClaimedAt != 0
+}
+
+// PreregistrationResult is the outcome of preregistering a batch of devices.
+type PreregistrationResult struct {
+	Devices         []*Device // The created devices, with their claim codes and device keys
+	ExistingSerials []string  // Serial numbers that were skipped because they already exist
+}
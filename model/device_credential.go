@@ -0,0 +1,8 @@
+package model
+
+// DeviceCredential is the key a device authenticates its own calls with. Only
+// a hash of the key is stored; the key itself is shown once when it is issued.
+type DeviceCredential struct {
+	KeyHash  string `bson:"key_hash" json:"-"`          // Hex-encoded SHA-256 of the key
+	IssuedAt int64  `bson:"issued_at" json:"issued_at"` // Unix timestamp (seconds since epoch)
+}
@@ -0,0 +1,19 @@
+package model
+
+// DeviceEventType is the kind of change a DeviceEvent describes.
+type DeviceEventType string
+
+const (
+	DeviceCreated DeviceEventType = "created"
+	DeviceUpdated DeviceEventType = "updated"
+	DeviceDeleted DeviceEventType = "deleted"
+)
+
+// DeviceEvent describes a change to a device. ResumeToken identifies the
+// event's position in the change feed so a watcher can continue after it.
+type DeviceEvent struct {
+	Type        DeviceEventType `json:"type"`
+	Device      *Device         `json:"device"`
+	ResumeToken string          `json:"resume_token"`
+	Time        int64           `json:"time"` // Unix timestamp (seconds since epoch)
+}
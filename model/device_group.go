@@ -0,0 +1,61 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeviceGroup is a named set of a user's devices, such as "Vans" or
+// "Warehouse A". Membership is stored on the devices, so a device can belong
+// to several groups and the devices of a group are found through an index.
+type DeviceGroup struct {
+	ID          string `json:"id,omitempty"`
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CreatedAt   int64  `json:"created_at"` // Unix timestamp (seconds since epoch)
+}
+
+type DeviceGroupDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID      string             `bson:"user_id" json:"user_id"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description,omitempty" json:"description,omitempty"`
+	CreatedAt   int64              `bson:"created_at" json:"created_at"`
+}
+
+// GroupStatusResult reports the outcome of changing the status of every device of a group.
+type GroupStatusResult struct {
+	ChangedDeviceIDs []string             `json:"changed_device_ids"`
+	Failures         []GroupStatusFailure `json:"failures,omitempty"`
+}
+
+// GroupStatusFailure is a device of a group whose status could not be changed.
+type GroupStatusFailure struct {
+	DeviceID string `json:"device_id"`
+	Reason   string `json:"reason"`
+}
+
+func (g *DeviceGroup) ToDeviceGroupDB() (*DeviceGroupDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeviceGroupDB{
+		ID:          objectID,
+		UserID:      g.UserID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+	}, nil
+}
+
+func (g *DeviceGroupDB) ToDeviceGroup() *DeviceGroup {
+	return &DeviceGroup{
+		ID:          g.ID.Hex(),
+		UserID:      g.UserID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+// DeviceFilter restricts which devices a listing returns. Zero values match everything.
+type DeviceFilter struct {
+	Status        DeviceStatus `json:"status,omitempty"`
+	DeviceType    string       `json:"device_type,omitempty"`
+	BatteryBelow  *int         `json:"battery_below,omitempty"`
+	GroupID       string       `json:"group_id,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`           // Devices with every one of the tags
+	LabelSelector string       `json:"label_selector,omitempty"` // See package selector
+}
+
+// ListDevicesQuery describes one page of a device listing.
+type ListDevicesQuery struct {
+	UserID    string       `json:"user_id"`
+	PageSize  int          `json:"page_size"`
+	PageToken string       `json:"page_token,omitempty"`
+	OrderBy   string       `json:"order_by,omitempty"`
+	Filter    DeviceFilter `json:"filter"`
+	// SharedAt also includes the devices shared with UserID by a share that is
+	// active at this Unix time. Zero lists the devices UserID owns only.
+	SharedAt int64 `json:"-"`
+}
+
+// DevicePage is one page of a device listing.
+type DevicePage struct {
+	Devices       []*Device `json:"devices"`
+	NextPageToken string    `json:"next_page_token,omitempty"`
+	TotalSize     int64     `json:"total_size"`
+}
+
+// NearbyDevicesQuery selects a user's devices whose last location is within
+// Radius meters of a point.
+type NearbyDevicesQuery struct {
+	UserID    string       `json:"user_id"`
+	Latitude  float64      `json:"latitude"`
+	Longitude float64      `json:"longitude"`
+	Radius    float64      `json:"radius"` // Meters
+	Filter    DeviceFilter `json:"filter"`
+	Limit     int          `json:"limit"`
+	// SharedAt also includes the devices shared with UserID, as in ListDevicesQuery.
+	SharedAt int64 `json:"-"`
+}
+
+// NearbyDevice is a device with the distance of its last location from the queried point.
+type NearbyDevice struct {
+	Device   *Device `json:"device"`
+	Distance float64 `json:"distance"` // Meters
+}
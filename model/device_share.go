@@ -0,0 +1,62 @@
+package model
+
+// DeviceRole is the access a user has to a device. Each role includes the
+// permissions of the roles below it: owner, then editor, then viewer.
+type DeviceRole string
+
+const (
+	RoleOwner  DeviceRole = "owner"
+	RoleEditor DeviceRole = "editor"
+	RoleViewer DeviceRole = "viewer"
+)
+
+// roleRanks orders the roles; a missing role ranks below every other.
+var roleRanks = map[DeviceRole]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Includes reports whether r grants at least the permissions of required.
+func (r DeviceRole) Includes(required DeviceRole) bool {
+	return roleRanks[r] > 0 && roleRanks[r] >= roleRanks[required]
+}
+
+// Shareable reports whether r can be granted to another user.
+func (r DeviceRole) Shareable() bool {
+	return r == RoleViewer || r == RoleEditor
+}
+
+// DeviceShare grants a user other than the owner access to a device.
+type DeviceShare struct {
+	UserID    string     `bson:"user_id" json:"user_id"`
+	Role      DeviceRole `bson:"role" json:"role"`
+	GrantedBy string     `bson:"granted_by" json:"granted_by"`
+	GrantedAt int64      `bson:"granted_at" json:"granted_at"` // Unix timestamp (seconds since epoch)
+	ExpiresAt int64      `bson:"expires_at" json:"expires_at"` // Unix timestamp (seconds since epoch), 0 never expires
+}
+
+// Active reports whether the share still grants access at now.
+func (s DeviceShare) Active(now int64) bool {
+	return s.ExpiresAt == 0 || now < s.ExpiresAt
+}
+
+// RoleOf returns the role of userID on the device at now, or an empty role if
+// the user has no access.
+func (d *Device) RoleOf(userID string, now int64) DeviceRole {
+	if userID == "" {
+		return ""
+	}
+
+	if d.UserID == userID {
+		return RoleOwner
+	}
+
+	for _, share := range d.Shares {
+		if share.UserID == userID && share.Active(now) {
+			return share.Role
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,42 @@
+package model
+
+// DeviceStatus is the lifecycle state of a device.
+type DeviceStatus string
+
+const (
+	StatusProvisioned    DeviceStatus = "provisioned"
+	StatusActive         DeviceStatus = "active"
+	StatusInactive       DeviceStatus = "inactive"
+	StatusLost           DeviceStatus = "lost"
+	StatusMaintenance    DeviceStatus = "maintenance"
+	StatusDecommissioned DeviceStatus = "decommissioned"
+)
+
+// DeviceStatuses lists every valid DeviceStatus.
+var DeviceStatuses = []DeviceStatus{
+	StatusProvisioned,
+	StatusActive,
+	StatusInactive,
+	StatusLost,
+	StatusMaintenance,
+	StatusDecommissioned,
+}
+
+// Valid reports whether s is one of the defined statuses.
+func (s DeviceStatus) Valid() bool {
+	for _, status := range DeviceStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange records a transition of a device's status.
+type StatusChange struct {
+	From      DeviceStatus `bson:"from" json:"from"`
+	To        DeviceStatus `bson:"to" json:"to"`
+	ChangedBy string       `bson:"changed_by" json:"changed_by"`
+	ChangedAt int64        `bson:"changed_at" json:"changed_at"` // Unix timestamp (seconds since epoch)
+	Reason    string       `bson:"reason,omitempty" json:"reason,omitempty"`
+}
@@ -0,0 +1,33 @@
+package model
+
+// DeviceTransfer is an offer by the owner of a device to hand it over to
+// another user, who can accept or reject it until it expires.
+type DeviceTransfer struct {
+	ToUserID    string `bson:"to_user_id" json:"to_user_id"`
+	WipeHistory bool   `bson:"wipe_history" json:"wipe_history"` // Erase the device's history before it changes hands
+	CreatedAt   int64  `bson:"created_at" json:"created_at"`     // Unix timestamp (seconds since epoch)
+	ExpiresAt   int64  `bson:"expires_at" json:"expires_at"`     // Unix timestamp (seconds since epoch)
+}
+
+// Expired reports whether the transfer can no longer be answered at now.
+func (t *DeviceTransfer) Expired(now int64) bool {
+	return now >= t.ExpiresAt
+}
+
+// TransferOutcome is how a transfer was answered.
+type TransferOutcome string
+
+const (
+	TransferAccepted TransferOutcome = "accepted"
+	TransferRejected TransferOutcome = "rejected"
+)
+
+// OwnershipChange records the answer to a transfer in the audit history of a device.
+type OwnershipChange struct {
+	FromUserID   string          `bson:"from_user_id" json:"from_user_id"`
+	ToUserID     string          `bson:"to_user_id" json:"to_user_id"`
+	Outcome      TransferOutcome `bson:"outcome" json:"outcome"`
+	HistoryWiped bool            `bson:"history_wiped" json:"history_wiped"`
+	OfferedAt    int64           `bson:"offered_at" json:"offered_at"`   // Unix timestamp (seconds since epoch)
+	AnsweredAt   int64           `bson:"answered_at" json:"answered_at"` // Unix timestamp (seconds since epoch)
+}
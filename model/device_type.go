@@ -0,0 +1,118 @@
+package model
+
+// Capability is a feature a device type has.
+type Capability string
+
+const (
+	CapabilityGPS         Capability = "gps"
+	CapabilityTemperature Capability = "temperature"
+	CapabilitySOSButton   Capability = "sos_button"
+	CapabilityCellular    Capability = "cellular"
+)
+
+// Capabilities lists every valid Capability.
+var Capabilities = []Capability{
+	CapabilityGPS,
+	CapabilityTemperature,
+	CapabilitySOSButton,
+	CapabilityCellular,
+}
+
+// Valid reports whether c is one of the defined capabilities.
+func (c Capability) Valid() bool {
+	for _, capability := range Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// BatteryChemistry is the kind of battery a device type runs on.
+type BatteryChemistry string
+
+const (
+	ChemistryUnknown              BatteryChemistry = ""
+	ChemistryLithiumIon           BatteryChemistry = "lithium_ion"
+	ChemistryLithiumPolymer       BatteryChemistry = "lithium_polymer"
+	ChemistryLithiumIronPhosphate BatteryChemistry = "lithium_iron_phosphate"
+	ChemistryAlkaline             BatteryChemistry = "alkaline"
+	ChemistryNickelMetalHydride   BatteryChemistry = "nickel_metal_hydride"
+)
+
+// BatteryChemistries lists every valid BatteryChemistry other than ChemistryUnknown.
+var BatteryChemistries = []BatteryChemistry{
+	ChemistryLithiumIon,
+	ChemistryLithiumPolymer,
+	ChemistryLithiumIronPhosphate,
+	ChemistryAlkaline,
+	ChemistryNickelMetalHydride,
+}
+
+// Valid reports whether c is unknown or one of the defined chemistries.
+func (c BatteryChemistry) Valid() bool {
+	if c == ChemistryUnknown {
+		return true
+	}
+	for _, chemistry := range BatteryChemistries {
+		if c == chemistry {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceType is an entry of the device-type catalog. Its ID, such as
+// "gps-tracker", is what devices store in their DeviceType.
+type DeviceType struct {
+	ID                  string           `json:"id"`
+	DisplayName         string           `json:"display_name"`
+	Capabilities        []Capability     `json:"capabilities,omitempty"`
+	SerialNumberPattern string           `json:"serial_number_pattern,omitempty"` // Regular expression the whole serial number must match
+	BatteryChemistry    BatteryChemistry `json:"battery_chemistry,omitempty"`
+	ReportingInterval   int64            `json:"reporting_interval,omitempty"` // Default seconds between reports
+	AttributesSchema    string           `json:"attributes_schema,omitempty"`  // JSON Schema the attributes of devices must satisfy, see package schema
+	CreatedAt           int64            `json:"created_at"`                   // Unix timestamp (seconds since epoch)
+	UpdatedAt           int64            `json:"updated_at"`                   // Unix timestamp (seconds since epoch)
+}
+
+// DeviceTypeDB is a DeviceType as stored in the catalog, keyed by its ID.
+type DeviceTypeDB struct {
+	ID                  string           `bson:"_id" json:"id"`
+	DisplayName         string           `bson:"display_name" json:"display_name"`
+	Capabilities        []Capability     `bson:"capabilities,omitempty" json:"capabilities,omitempty"`
+	SerialNumberPattern string           `bson:"serial_number_pattern,omitempty" json:"serial_number_pattern,omitempty"`
+	BatteryChemistry    BatteryChemistry `bson:"battery_chemistry,omitempty" json:"battery_chemistry,omitempty"`
+	ReportingInterval   int64            `bson:"reporting_interval,omitempty" json:"reporting_interval,omitempty"`
+	AttributesSchema    string           `bson:"attributes_schema,omitempty" json:"attributes_schema,omitempty"` // Stored as text, since schema keywords start with '$'
+	CreatedAt           int64            `bson:"created_at" json:"created_at"`
+	UpdatedAt           int64            `bson:"updated_at" json:"updated_at"`
+}
+
+func (t *DeviceType) ToDeviceTypeDB() *DeviceTypeDB {
+	return &DeviceTypeDB{
+		ID:                  t.ID,
+		DisplayName:         t.DisplayName,
+		Capabilities:        t.Capabilities,
+		SerialNumberPattern: t.SerialNumberPattern,
+		BatteryChemistry:    t.BatteryChemistry,
+		ReportingInterval:   t.ReportingInterval,
+		AttributesSchema:    t.AttributesSchema,
+		CreatedAt:           t.CreatedAt,
+		UpdatedAt:           t.UpdatedAt,
+	}
+}
+
+func (t *DeviceTypeDB) ToDeviceType() *DeviceType {
+	return &DeviceType{
+		ID:                  t.ID,
+		DisplayName:         t.DisplayName,
+		Capabilities:        t.Capabilities,
+		SerialNumberPattern: t.SerialNumberPattern,
+		BatteryChemistry:    t.BatteryChemistry,
+		ReportingInterval:   t.ReportingInterval,
+		AttributesSchema:    t.AttributesSchema,
+		CreatedAt:           t.CreatedAt,
+		UpdatedAt:           t.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidETag is returned by ParseETag for a tag that ETag did not produce.
+var ErrInvalidETag = errors.New("not an etag of a device")
+
+// ETag formats a device version as an entity tag, the version in double
+// quotes, e.g. "7".
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseETag returns the version of an entity tag formatted by ETag. An empty
+// tag stands for version 0, which writes treat as "any version".
+func ParseETag(etag string) (int64, error) {
+	if etag == "" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(etag)
+	if err != nil {
+		return 0, ErrInvalidETag
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 0 {
+		return 0, ErrInvalidETag
+	}
+
+	return version, nil
+}
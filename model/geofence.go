@@ -0,0 +1,175 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GeofenceShape is the kind of area a geofence covers.
+type GeofenceShape string
+
+const (
+	GeofenceCircle  GeofenceShape = "circle"
+	GeofencePolygon GeofenceShape = "polygon"
+)
+
+// LatLng is a position in degrees.
+type LatLng struct {
+	Latitude  float64 `bson:"latitude" json:"latitude"`
+	Longitude float64 `bson:"longitude" json:"longitude"`
+}
+
+// Geofence is an area owned by a user that devices are watched against.
+// A circle uses Center and Radius; a polygon uses Vertices.
+type Geofence struct {
+	ID           string        `json:"id,omitempty"`
+	UserID       string        `json:"user_id"`
+	Name         string        `json:"name"`
+	Shape        GeofenceShape `json:"shape"`
+	Center       *LatLng       `json:"center,omitempty"`
+	Radius       float64       `json:"radius,omitempty"` // Meters
+	Vertices     []LatLng      `json:"vertices,omitempty"`
+	DeviceIDs    []string      `json:"device_ids"`
+	DwellSeconds int64         `json:"dwell_seconds"` // Time inside before a dwell event, 0 disables dwell events
+	CreatedAt    int64         `json:"created_at"`    // Unix timestamp (seconds since epoch)
+
+	// States tracks each attached device relative to the fence, by device ID.
+	States map[string]GeofenceState `json:"-"`
+}
+
+// GeofenceState is what is known about a device relative to a geofence.
+type GeofenceState struct {
+	Inside    bool  `bson:"inside" json:"inside"`
+	Since     int64 `bson:"since" json:"since"`
+	Dwelled   bool  `bson:"dwelled" json:"dwelled"`
+	UpdatedAt int64 `bson:"updated_at" json:"updated_at"`
+}
+
+type GeofenceDB struct {
+	ID           primitive.ObjectID       `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID       string                   `bson:"user_id" json:"user_id"`
+	Name         string                   `bson:"name" json:"name"`
+	Shape        GeofenceShape            `bson:"shape" json:"shape"`
+	Center       *LatLng                  `bson:"center,omitempty" json:"center,omitempty"`
+	Radius       float64                  `bson:"radius,omitempty" json:"radius,omitempty"`
+	Vertices     []LatLng                 `bson:"vertices,omitempty" json:"vertices,omitempty"`
+	DeviceIDs    []string                 `bson:"device_ids" json:"device_ids"`
+	DwellSeconds int64                    `bson:"dwell_seconds" json:"dwell_seconds"`
+	CreatedAt    int64                    `bson:"created_at" json:"created_at"`
+	States       map[string]GeofenceState `bson:"states,omitempty" json:"-"`
+}
+
+// GeofenceEventType is the kind of transition of a device relative to a geofence.
+type GeofenceEventType string
+
+const (
+	GeofenceEnter GeofenceEventType = "enter"
+	GeofenceExit  GeofenceEventType = "exit"
+	GeofenceDwell GeofenceEventType = "dwell"
+)
+
+// GeofenceEvent records a device entering, leaving or dwelling in a geofence.
+type GeofenceEvent struct {
+	ID         string            `json:"id,omitempty"`
+	GeofenceID string            `json:"geofence_id"`
+	DeviceID   string            `json:"device_id"`
+	UserID     string            `json:"user_id"`
+	Type       GeofenceEventType `json:"type"`
+	Position   LatLng            `json:"position"`
+	Time       int64             `json:"time"` // Unix timestamp (seconds since epoch) of the position
+}
+
+type GeofenceEventDB struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	GeofenceID primitive.ObjectID `bson:"geofence_id" json:"geofence_id"`
+	DeviceID   primitive.ObjectID `bson:"device_id" json:"device_id"`
+	UserID     string             `bson:"user_id" json:"user_id"`
+	Type       GeofenceEventType  `bson:"type" json:"type"`
+	Position   LatLng             `bson:"position" json:"position"`
+	Time       int64              `bson:"time" json:"time"`
+}
+
+// GeofenceEventQuery selects a page of the events of a geofence, optionally for one device.
+type GeofenceEventQuery struct {
+	GeofenceID string `json:"geofence_id"`
+	DeviceID   string `json:"device_id"`
+	From       int64  `json:"from"` // Unix timestamp (seconds since epoch), inclusive
+	To         int64  `json:"to"`   // Unix timestamp (seconds since epoch), exclusive
+	PageSize   int    `json:"page_size"`
+	PageToken  string `json:"page_token"`
+}
+
+// GeofenceEventPage is a page of geofence events, oldest first.
+type GeofenceEventPage struct {
+	Events        []*GeofenceEvent `json:"events"`
+	NextPageToken string           `json:"next_page_token"`
+}
+
+func (g *Geofence) ToGeofenceDB() (*GeofenceDB, error) {
+	objectID, err := primitive.ObjectIDFromHex(g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeofenceDB{
+		ID:           objectID,
+		UserID:       g.UserID,
+		Name:         g.Name,
+		Shape:        g.Shape,
+		Center:       g.Center,
+		Radius:       g.Radius,
+		Vertices:     g.Vertices,
+		DeviceIDs:    g.DeviceIDs,
+		DwellSeconds: g.DwellSeconds,
+		CreatedAt:    g.CreatedAt,
+		States:       g.States,
+	}, nil
+}
+
+func (g *GeofenceDB) ToGeofence() *Geofence {
+	return &Geofence{
+		ID:           g.ID.Hex(),
+		UserID:       g.UserID,
+		Name:         g.Name,
+		Shape:        g.Shape,
+		Center:       g.Center,
+		Radius:       g.Radius,
+		Vertices:     g.Vertices,
+		DeviceIDs:    g.DeviceIDs,
+		DwellSeconds: g.DwellSeconds,
+		CreatedAt:    g.CreatedAt,
+		States:       g.States,
+	}
+}
+
+func (e *GeofenceEvent) ToGeofenceEventDB() (*GeofenceEventDB, error) {
+	geofenceID, err := primitive.ObjectIDFromHex(e.GeofenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceID, err := primitive.ObjectIDFromHex(e.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeofenceEventDB{
+		GeofenceID: geofenceID,
+		DeviceID:   deviceID,
+		UserID:     e.UserID,
+		Type:       e.Type,
+		Position:   e.Position,
+		Time:       e.Time,
+	}, nil
+}
+
+func (e *GeofenceEventDB) ToGeofenceEvent() *GeofenceEvent {
+	return &GeofenceEvent{
+		ID:         e.ID.Hex(),
+		GeofenceID: e.GeofenceID.Hex(),
+		DeviceID:   e.DeviceID.Hex(),
+		UserID:     e.UserID,
+		Type:       e.Type,
+		Position:   e.Position,
+		Time:       e.Time,
+	}
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Location is a position fix reported by a device.
+type Location struct {
+	DeviceID  string  `json:"device_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float64 `json:"accuracy"`  // Meters
+	Altitude  float64 `json:"altitude"`  // Meters above sea level
+	Speed     float64 `json:"speed"`     // Meters per second
+	Heading   float64 `json:"heading"`   // Degrees clockwise from true north
+	Timestamp int64   `json:"timestamp"` // Unix timestamp (seconds since epoch)
+}
+
+// GeoPoint is a GeoJSON point. Coordinates are stored as [longitude, latitude].
+type GeoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// LocationFix holds the details of a location that are not part of its GeoJSON point.
+type LocationFix struct {
+	Accuracy  float64 `bson:"accuracy" json:"accuracy"`
+	Altitude  float64 `bson:"altitude" json:"altitude"`
+	Speed     float64 `bson:"speed" json:"speed"`
+	Heading   float64 `bson:"heading" json:"heading"`
+	Timestamp int64   `bson:"timestamp" json:"timestamp"`
+}
+
+// LocationDB is a Location as stored in the location history collection.
+type LocationDB struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	DeviceID    primitive.ObjectID `bson:"device_id" json:"device_id"`
+	Point       GeoPoint           `bson:"point" json:"point"`
+	LocationFix `bson:",inline"`
+}
+
+// LocationHistoryQuery selects a page of the locations of a device within a time range.
+type LocationHistoryQuery struct {
+	DeviceID  string `json:"device_id"`
+	From      int64  `json:"from"` // Unix timestamp (seconds since epoch), inclusive
+	To        int64  `json:"to"`   // Unix timestamp (seconds since epoch), exclusive
+	PageSize  int    `json:"page_size"`
+	PageToken string `json:"page_token"`
+}
+
+// LocationPage is a page of a device's location history, oldest first.
+type LocationPage struct {
+	Locations     []*Location `json:"locations"`
+	NextPageToken string      `json:"next_page_token"`
+}
+
+// NewGeoPoint returns the GeoJSON point at the given coordinates.
+func NewGeoPoint(latitude, longitude float64) GeoPoint {
+	return GeoPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// Latitude returns the latitude of the point.
+func (p GeoPoint) Latitude() float64 {
+	if len(p.Coordinates) < 2 {
+		return 0
+	}
+	return p.Coordinates[1]
+}
+
+// Longitude returns the longitude of the point.
+func (p GeoPoint) Longitude() float64 {
+	if len(p.Coordinates) < 1 {
+		return 0
+	}
+	return p.Coordinates[0]
+}
+
+// Fix returns the details of the location that are stored next to its point.
+func (l *Location) Fix() LocationFix {
+	return LocationFix{
+		Accuracy:  l.Accuracy,
+		Altitude:  l.Altitude,
+		Speed:     l.Speed,
+		Heading:   l.Heading,
+		Timestamp: l.Timestamp,
+	}
+}
+
+func (l *Location) ToLocationDB() (*LocationDB, error) {
+	deviceID, err := primitive.ObjectIDFromHex(l.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocationDB{
+		DeviceID:    deviceID,
+		Point:       NewGeoPoint(l.Latitude, l.Longitude),
+		LocationFix: l.Fix(),
+	}, nil
+}
+
+func (l *LocationDB) ToLocation() *Location {
+	return newLocation(l.DeviceID.Hex(), l.Point, l.LocationFix)
+}
+
+// newLocation combines a point and its fix into a Location.
+func newLocation(deviceID string, point GeoPoint, fix LocationFix) *Location {
+	return &Location{
+		DeviceID:  deviceID,
+		Latitude:  point.Latitude(),
+		Longitude: point.Longitude(),
+		Accuracy:  fix.Accuracy,
+		Altitude:  fix.Altitude,
+		Speed:     fix.Speed,
+		Heading:   fix.Heading,
+		Timestamp: fix.Timestamp,
+	}
+}
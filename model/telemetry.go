@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ChargingState describes whether a device's battery is charging.
+type ChargingState string
+
+const (
+	ChargingUnknown     ChargingState = ""
+	ChargingCharging    ChargingState = "charging"
+	ChargingDischarging ChargingState = "discharging"
+	ChargingFull        ChargingState = "full"
+)
+
+// TelemetrySample is a single reading reported by a device.
+type TelemetrySample struct {
+	DeviceID       string        `json:"device_id"`
+	Timestamp      int64         `json:"timestamp"` // Unix timestamp (seconds since epoch)
+	BatteryLevel   int           `json:"battery_level"`
+	ChargingState  ChargingState `json:"charging_state"`
+	SignalStrength int           `json:"signal_strength"` // dBm
+}
+
+// TelemetrySampleDB is a TelemetrySample as stored in the time-series collection.
+type TelemetrySampleDB struct {
+	DeviceID       primitive.ObjectID `bson:"device_id" json:"device_id"`
+	Timestamp      time.Time          `bson:"timestamp" json:"timestamp"`
+	BatteryLevel   int                `bson:"battery_level" json:"battery_level"`
+	ChargingState  ChargingState      `bson:"charging_state" json:"charging_state"`
+	SignalStrength int                `bson:"signal_strength" json:"signal_strength"`
+}
+
+// TelemetryQuery selects the samples of a device within a time range.
+// With a non-zero Interval samples are averaged into buckets of that many seconds.
+type TelemetryQuery struct {
+	DeviceID string `json:"device_id"`
+	From     int64  `json:"from"` // Unix timestamp (seconds since epoch), inclusive
+	To       int64  `json:"to"`   // Unix timestamp (seconds since epoch), exclusive
+	Interval int64  `json:"interval"`
+	Limit    int    `json:"limit"`
+}
+
+func (s *TelemetrySample) ToTelemetrySampleDB() (*TelemetrySampleDB, error) {
+	deviceID, err := primitive.ObjectIDFromHex(s.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TelemetrySampleDB{
+		DeviceID:       deviceID,
+		Timestamp:      time.Unix(s.Timestamp, 0).UTC(),
+		BatteryLevel:   s.BatteryLevel,
+		ChargingState:  s.ChargingState,
+		SignalStrength: s.SignalStrength,
+	}, nil
+}
+
+func (s *TelemetrySampleDB) ToTelemetrySample() *TelemetrySample {
+	return &TelemetrySample{
+		DeviceID:       s.DeviceID.Hex(),
+		Timestamp:      s.Timestamp.Unix(),
+		BatteryLevel:   s.BatteryLevel,
+		ChargingState:  s.ChargingState,
+		SignalStrength: s.SignalStrength,
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TripKind tells trips, where a device was moving, and stops, where it stayed in place, apart.
+type TripKind string
+
+const (
+	TripKindTrip TripKind = "trip"
+	TripKindStop TripKind = "stop"
+)
+
+// Trip is a completed trip or stop of a device. A stop is located at its start
+// position and has no distance.
+type Trip struct {
+	ID            string   `json:"id,omitempty"`
+	DeviceID      string   `json:"device_id"`
+	UserID        string   `json:"user_id"`
+	Kind          TripKind `json:"kind"`
+	StartPosition LatLng   `json:"start_position"`
+	StartTime     int64    `json:"start_time"` // Unix timestamp (seconds since epoch)
+	EndPosition   LatLng   `json:"end_position"`
+	EndTime       int64    `json:"end_time"`      // Unix timestamp (seconds since epoch)
+	Distance      float64  `json:"distance"`      // Meters
+	Duration      int64    `json:"duration"`      // Seconds
+	MaxSpeed      float64  `json:"max_speed"`     // Meters per second
+	AverageSpeed  float64  `json:"average_speed"` // Meters per second
+}
+
+type TripDB struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	DeviceID      primitive.ObjectID `bson:"device_id" json:"device_id"`
+	UserID        string             `bson:"user_id" json:"user_id"`
+	Kind          TripKind           `bson:"kind" json:"kind"`
+	StartPosition LatLng             `bson:"start_position" json:"start_position"`
+	StartTime     int64              `bson:"start_time" json:"start_time"`
+	EndPosition   LatLng             `bson:"end_position" json:"end_position"`
+	EndTime       int64              `bson:"end_time" json:"end_time"`
+	Distance      float64            `bson:"distance" json:"distance"`
+	Duration      int64              `bson:"duration" json:"duration"`
+	MaxSpeed      float64            `bson:"max_speed" json:"max_speed"`
+	AverageSpeed  float64            `bson:"average_speed" json:"average_speed"`
+}
+
+// TripQuery selects a page of the trips of a device, optionally with its stops.
+type TripQuery struct {
+	DeviceID     string `json:"device_id"`
+	From         int64  `json:"from"` // Unix timestamp (seconds since epoch) of the start, inclusive
+	To           int64  `json:"to"`   // Unix timestamp (seconds since epoch) of the start, exclusive
+	IncludeStops bool   `json:"include_stops"`
+	PageSize     int    `json:"page_size"`
+	PageToken    string `json:"page_token"`
+}
+
+// TripPage is a page of trips, oldest first.
+type TripPage struct {
+	Trips         []*Trip `json:"trips"`
+	NextPageToken string  `json:"next_page_token"`
+}
+
+// TripPoint is a position used by trip detection.
+type TripPoint struct {
+	Latitude  float64 `bson:"latitude" json:"latitude"`
+	Longitude float64 `bson:"longitude" json:"longitude"`
+	Speed     float64 `bson:"speed" json:"speed"`
+	Time      int64   `bson:"time" json:"time"`
+}
+
+// TripSegment is a trip or stop that is still being detected.
+type TripSegment struct {
+	Kind     TripKind  `bson:"kind" json:"kind"`
+	Start    TripPoint `bson:"start" json:"start"`
+	End      TripPoint `bson:"end" json:"end"`
+	Distance float64   `bson:"distance" json:"distance"`
+	MaxSpeed float64   `bson:"max_speed" json:"max_speed"`
+}
+
+// TripState is the trip detection in progress for a device, so that it can
+// continue with the next location instead of rescanning the history.
+type TripState struct {
+	Current            *TripSegment `bson:"current,omitempty" json:"current,omitempty"`
+	Pending            *TripSegment `bson:"pending,omitempty" json:"pending,omitempty"`
+	Stationary         *TripPoint   `bson:"stationary,omitempty" json:"stationary,omitempty"`
+	StationaryDistance float64      `bson:"stationary_distance" json:"stationary_distance"`
+	Last               *TripPoint   `bson:"last,omitempty" json:"last,omitempty"`
+	// Version counts the saves of the state. A state is only saved over the
+	// version it was read at, so concurrent detections cannot overwrite each other.
+	Version int64 `bson:"version" json:"-"`
+}
+
+func (t *Trip) ToTripDB() (*TripDB, error) {
+	deviceID, err := primitive.ObjectIDFromHex(t.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TripDB{
+		DeviceID:      deviceID,
+		UserID:        t.UserID,
+		Kind:          t.Kind,
+		StartPosition: t.StartPosition,
+		StartTime:     t.StartTime,
+		EndPosition:   t.EndPosition,
+		EndTime:       t.EndTime,
+		Distance:      t.Distance,
+		Duration:      t.Duration,
+		MaxSpeed:      t.MaxSpeed,
+		AverageSpeed:  t.AverageSpeed,
+	}, nil
+}
+
+func (t *TripDB) ToTrip() *Trip {
+	return &Trip{
+		ID:            t.ID.Hex(),
+		DeviceID:      t.DeviceID.Hex(),
+		UserID:        t.UserID,
+		Kind:          t.Kind,
+		StartPosition: t.StartPosition,
+		StartTime:     t.StartTime,
+		EndPosition:   t.EndPosition,
+		EndTime:       t.EndTime,
+		Distance:      t.Distance,
+		Duration:      t.Duration,
+		MaxSpeed:      t.MaxSpeed,
+		AverageSpeed:  t.AverageSpeed,
+	}
+}
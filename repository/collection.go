@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BerryTracer/common-service/adapter/database/mongodb"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is the set of collection operations used by the repositories.
+// It covers the common mongodb.MongoAdapter plus the queries it does not expose.
+type Collection interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) mongodb.SingleResult
+	FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) mongodb.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (mongodb.Cursor, error)
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (mongodb.Cursor, error)
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStream, error)
+}
+
+// MongoCollection implements Collection on top of a *mongo.Collection.
+type MongoCollection struct {
+	*mongodb.MongoAdapterImpl
+	collection *mongo.Collection
+}
+
+// NewMongoCollection returns a new MongoCollection.
+func NewMongoCollection(collection *mongo.Collection) *MongoCollection {
+	return &MongoCollection{
+		MongoAdapterImpl: mongodb.NewMongoAdapter(collection),
+		collection:       collection,
+	}
+}
+
+// CountDocuments implements Collection.
+func (c *MongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	return c.collection.CountDocuments(ctx, filter, opts...)
+}
+
+// InsertMany implements Collection.
+func (c *MongoCollection) InsertMany(ctx context.Context, documents []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	return c.collection.InsertMany(ctx, documents, opts...)
+}
+
+// UpdateMany implements Collection.
+func (c *MongoCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return c.collection.UpdateMany(ctx, filter, update, opts...)
+}
+
+// DeleteMany implements Collection.
+func (c *MongoCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return c.collection.DeleteMany(ctx, filter, opts...)
+}
+
+// FindOneAndUpdate implements Collection.
+func (c *MongoCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{}, opts ...*options.FindOneAndUpdateOptions) mongodb.SingleResult {
+	return c.collection.FindOneAndUpdate(ctx, filter, update, opts...)
+}
+
+// Aggregate implements Collection.
+func (c *MongoCollection) Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (mongodb.Cursor, error) {
+	cursor, err := c.collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// Watch implements Collection.
+func (c *MongoCollection) Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (ChangeStream, error) {
+	stream, err := c.collection.Watch(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// Ensure MongoCollection implements Collection and the common MongoAdapter interfaces
+var (
+	_ Collection           = &MongoCollection{}
+	_ mongodb.MongoAdapter = &MongoCollection{}
+)
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeviceChangeFeed delivers the changes made to a user's devices.
+type DeviceChangeFeed interface {
+	// WatchDevices streams the changes to userID's devices and to the devices
+	// shared with userID, or only to those in groupID when it is set. With a
+	// resume token the stream continues right after the event that carried it.
+	WatchDevices(ctx context.Context, userID string, groupID string, resumeToken string) (DeviceEventStream, error)
+}
+
+// DeviceEventStream is an open stream of device events.
+type DeviceEventStream interface {
+	// Next blocks until the next event is available or ctx is done.
+	Next(ctx context.Context) (*model.DeviceEvent, error)
+	Close(ctx context.Context) error
+}
+
+// ChangeStream is the subset of *mongo.ChangeStream used by DeviceMongoChangeFeed.
+type ChangeStream interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	ResumeToken() bson.Raw
+	Err() error
+	Close(ctx context.Context) error
+}
+
+var (
+	// ErrInvalidResumeToken is returned when a resume token cannot be parsed.
+	ErrInvalidResumeToken = errs.InvalidField("resume_token", "is malformed")
+	// ErrEventsLost is returned when events after a resume token are no longer retained.
+	ErrEventsLost = errs.New(errs.FailedPrecondition, "DEVICE_EVENTS_LOST", "device events were lost; restart watching without a resume token")
+)
+
+type DeviceMongoChangeFeed struct {
+	Collection Collection
+}
+
+// NewDeviceMongoChangeFeed returns a new DeviceMongoChangeFeed.
+// Delete events are only delivered when the collection has change stream
+// pre-images enabled, since the owner of a deleted device is otherwise unknown.
+func NewDeviceMongoChangeFeed(collection Collection) *DeviceMongoChangeFeed {
+	return &DeviceMongoChangeFeed{Collection: collection}
+}
+
+// changeEvent is the subset of a MongoDB change event used by the feed.
+type changeEvent struct {
+	OperationType            string              `bson:"operationType"`
+	ClusterTime              primitive.Timestamp `bson:"clusterTime"`
+	FullDocument             *model.DeviceDB     `bson:"fullDocument"`
+	FullDocumentBeforeChange *model.DeviceDB     `bson:"fullDocumentBeforeChange"`
+}
+
+// WatchDevices implements DeviceChangeFeed.
+// A device that leaves the group is still reported once, from its pre-image.
+// Moving a device to the trash is reported as its deletion and restoring it
+// as its creation; purging it from the trash is not reported again.
+func (f *DeviceMongoChangeFeed) WatchDevices(ctx context.Context, userID string, groupID string, resumeToken string) (DeviceEventStream, error) {
+	match := primitive.M{
+		"operationType": primitive.M{"$in": primitive.A{"insert", "update", "replace", "delete"}},
+		"$or": primitive.A{
+			primitive.M{"fullDocument.user_id": userID},
+			primitive.M{"fullDocumentBeforeChange.user_id": userID},
+			primitive.M{"fullDocument.shares.user_id": userID},
+			primitive.M{"fullDocumentBeforeChange.shares.user_id": userID},
+		},
+		"$nor": primitive.A{primitive.M{
+			"operationType":                       "delete",
+			"fullDocumentBeforeChange.deleted_at": primitive.M{"$exists": true},
+		}},
+	}
+	if groupID != "" {
+		match["$and"] = primitive.A{primitive.M{"$or": primitive.A{
+			primitive.M{"fullDocument.group_ids": groupID},
+			primitive.M{"fullDocumentBeforeChange.group_ids": groupID},
+		}}}
+	}
+	pipeline := primitive.A{primitive.M{"$match": match}}
+
+	watchOptions := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+
+	if resumeToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil {
+			return nil, ErrInvalidResumeToken
+		}
+		watchOptions.SetResumeAfter(bson.Raw(token))
+	}
+
+	stream, err := f.Collection.Watch(ctx, pipeline, watchOptions)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &mongoDeviceEventStream{stream: stream}, nil
+}
+
+type mongoDeviceEventStream struct {
+	stream ChangeStream
+}
+
+func (s *mongoDeviceEventStream) Next(ctx context.Context) (*model.DeviceEvent, error) {
+	if !s.stream.Next(ctx) {
+		if err := s.stream.Err(); err != nil {
+			return nil, translateError(err)
+		}
+		return nil, ctx.Err()
+	}
+
+	var change changeEvent
+	if err := s.stream.Decode(&change); err != nil {
+		return nil, err
+	}
+
+	event := &model.DeviceEvent{
+		ResumeToken: base64.RawURLEncoding.EncodeToString(s.stream.ResumeToken()),
+		Time:        int64(change.ClusterTime.T),
+	}
+
+	switch change.OperationType {
+	case "insert":
+		event.Type = model.DeviceCreated
+	case "delete":
+		event.Type = model.DeviceDeleted
+	default:
+		event.Type = updateType(change)
+	}
+
+	// The current document is missing for deletes and for updates whose
+	// document was removed before the lookup; fall back to the pre-image.
+	switch {
+	case change.FullDocument != nil:
+		event.Device = change.FullDocument.ToDevice()
+	case change.FullDocumentBeforeChange != nil:
+		event.Device = change.FullDocumentBeforeChange.ToDevice()
+	default:
+		event.Device = &model.Device{}
+	}
+
+	return event, nil
+}
+
+// updateType tells apart the updates that move a device to or from the trash.
+func updateType(change changeEvent) model.DeviceEventType {
+	switch {
+	case change.FullDocument != nil && change.FullDocument.DeletedAt != 0:
+		return model.DeviceDeleted
+	case change.FullDocumentBeforeChange != nil && change.FullDocumentBeforeChange.DeletedAt != 0:
+		return model.DeviceCreated
+	default:
+		return model.DeviceUpdated
+	}
+}
+
+func (s *mongoDeviceEventStream) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
+
+// Ensure DeviceMongoChangeFeed implements DeviceChangeFeed interface
+var _ DeviceChangeFeed = &DeviceMongoChangeFeed{}
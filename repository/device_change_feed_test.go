@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDeviceMongoChangeFeed_WatchDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	mockStream := mock_repository.NewMockChangeStream(ctrl)
+	feed := repository.NewDeviceMongoChangeFeed(mockCollection)
+
+	ctx := context.Background()
+	deviceDB := &model.DeviceDB{ID: primitive.NewObjectID(), UserID: "user123", Name: "Bike"}
+	resumeToken := bson.Raw{0x05, 0x00, 0x00, 0x00, 0x00}
+
+	mockCollection.EXPECT().
+		Watch(ctx, gomock.Any(), gomock.Any()).
+		Return(mockStream, nil).
+		Times(1)
+
+	mockStream.EXPECT().Next(ctx).Return(true).Times(1)
+	mockStream.EXPECT().
+		Decode(gomock.Any()).
+		DoAndReturn(func(v interface{}) error {
+			data, err := bson.Marshal(bson.M{"operationType": "insert", "fullDocument": deviceDB})
+			if err != nil {
+				return err
+			}
+			return bson.Unmarshal(data, v)
+		}).
+		Times(1)
+	mockStream.EXPECT().ResumeToken().Return(resumeToken).Times(1)
+
+	// Call the WatchDevices method and read the first event.
+	stream, err := feed.WatchDevices(ctx, "user123", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	event, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.Type != model.DeviceCreated || event.Device.ID != deviceDB.ID.Hex() || event.ResumeToken == "" {
+		t.Errorf("expected created event for %s with a resume token, got %+v", deviceDB.ID.Hex(), event)
+	}
+}
+
+func TestDeviceMongoChangeFeed_WatchDevices_Trash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	mockStream := mock_repository.NewMockChangeStream(ctrl)
+	feed := repository.NewDeviceMongoChangeFeed(mockCollection)
+
+	ctx := context.Background()
+	live := &model.DeviceDB{ID: primitive.NewObjectID(), UserID: "user123", SerialNumber: "SN-1"}
+	deleted := &model.DeviceDB{ID: live.ID, UserID: "user123", DeletedAt: 1700000000, DeletedSerialNumber: "SN-1"}
+
+	mockCollection.EXPECT().Watch(ctx, gomock.Any(), gomock.Any()).Return(mockStream, nil).Times(1)
+
+	// The device is moved to the trash and restored.
+	changes := []bson.M{
+		{"operationType": "update", "fullDocument": deleted, "fullDocumentBeforeChange": live},
+		{"operationType": "update", "fullDocument": live, "fullDocumentBeforeChange": deleted},
+	}
+	for _, change := range changes {
+		change := change
+		mockStream.EXPECT().Next(ctx).Return(true).Times(1)
+		mockStream.EXPECT().
+			Decode(gomock.Any()).
+			DoAndReturn(func(v interface{}) error {
+				data, err := bson.Marshal(change)
+				if err != nil {
+					return err
+				}
+				return bson.Unmarshal(data, v)
+			}).
+			Times(1)
+		mockStream.EXPECT().ResumeToken().Return(bson.Raw{0x05, 0x00, 0x00, 0x00, 0x00}).Times(1)
+	}
+
+	stream, err := feed.WatchDevices(ctx, "user123", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, want := range []model.DeviceEventType{model.DeviceDeleted, model.DeviceCreated} {
+		event, err := stream.Next(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if event.Type != want || event.Device.SerialNumber != "SN-1" {
+			t.Errorf("expected %s event for SN-1, got %+v", want, event)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeviceHistory is implemented by the repositories that keep data reported by
+// devices, so that the history of a device can be erased when it changes hands.
+type DeviceHistory interface {
+	// DeleteDeviceHistory deletes what the device reported at or before before,
+	// a Unix timestamp, or all of it when before is 0.
+	DeleteDeviceHistory(ctx context.Context, deviceId string, before int64) error
+}
+
+// deleteByDevice deletes the documents of a device from a collection whose
+// timeField is at or before cutoff, or all of them when cutoff is nil.
+func deleteByDevice(ctx context.Context, collection Collection, deviceId string, timeField string, cutoff interface{}) error {
+	objectID, err := parseObjectID("device_id", deviceId)
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{"device_id": objectID}
+	if cutoff != nil {
+		filter[timeField] = primitive.M{"$lte": cutoff}
+	}
+
+	_, err = collection.DeleteMany(ctx, filter)
+	return err
+}
+
+// historyCutoff returns the cutoff of deleteByDevice for fields that hold Unix timestamps.
+func historyCutoff(before int64) interface{} {
+	if before == 0 {
+		return nil
+	}
+	return before
+}
+
+// Ensure the history repositories implement DeviceHistory interface
+var (
+	_ DeviceHistory = &TelemetryMongoRepository{}
+	_ DeviceHistory = &LocationMongoRepository{}
+	_ DeviceHistory = &TripMongoRepository{}
+	_ DeviceHistory = &GeofenceMongoRepository{}
+)
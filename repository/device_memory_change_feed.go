@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/BerryTracer/device-service/model"
+)
+
+// DefaultMemoryChangeFeedCapacity is the number of events a
+// DeviceMemoryChangeFeed keeps for resuming watchers.
+const DefaultMemoryChangeFeedCapacity = 1024
+
+// DeviceMemoryChangeFeed is an in-memory DeviceChangeFeed. Events are added
+// with Publish and numbered sequentially; the sequence number is the resume token.
+// It lets the watch flow run without a MongoDB replica set, e.g. in tests.
+type DeviceMemoryChangeFeed struct {
+	mu       sync.Mutex
+	capacity int
+	events   []*model.DeviceEvent
+	lastSeq  uint64
+	notify   chan struct{}
+}
+
+// NewDeviceMemoryChangeFeed returns a new DeviceMemoryChangeFeed that retains
+// the last capacity events.
+func NewDeviceMemoryChangeFeed(capacity int) *DeviceMemoryChangeFeed {
+	if capacity <= 0 {
+		capacity = DefaultMemoryChangeFeedCapacity
+	}
+
+	return &DeviceMemoryChangeFeed{
+		capacity: capacity,
+		notify:   make(chan struct{}),
+	}
+}
+
+// Publish appends a copy of event to the feed, assigns its resume token and
+// wakes up every waiting watcher.
+func (f *DeviceMemoryChangeFeed) Publish(event model.DeviceEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastSeq++
+	event.ResumeToken = strconv.FormatUint(f.lastSeq, 10)
+
+	f.events = append(f.events, &event)
+	if len(f.events) > f.capacity {
+		f.events = f.events[len(f.events)-f.capacity:]
+	}
+
+	close(f.notify)
+	f.notify = make(chan struct{})
+}
+
+// WatchDevices implements DeviceChangeFeed.
+func (f *DeviceMemoryChangeFeed) WatchDevices(_ context.Context, userID string, groupID string, resumeToken string) (DeviceEventStream, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	after := f.lastSeq
+	if resumeToken != "" {
+		seq, err := strconv.ParseUint(resumeToken, 10, 64)
+		if err != nil || seq > f.lastSeq {
+			return nil, ErrInvalidResumeToken
+		}
+		if seq < f.firstSeq()-1 {
+			return nil, ErrEventsLost
+		}
+		after = seq
+	}
+
+	return &memoryDeviceEventStream{feed: f, userID: userID, groupID: groupID, after: after}, nil
+}
+
+// firstSeq returns the sequence number of the oldest retained event. The
+// caller must hold f.mu.
+func (f *DeviceMemoryChangeFeed) firstSeq() uint64 {
+	return f.lastSeq - uint64(len(f.events)) + 1
+}
+
+type memoryDeviceEventStream struct {
+	feed    *DeviceMemoryChangeFeed
+	userID  string
+	groupID string
+	after   uint64
+}
+
+func (s *memoryDeviceEventStream) Next(ctx context.Context) (*model.DeviceEvent, error) {
+	for {
+		event, notify, err := s.next()
+		if event != nil || err != nil {
+			return event, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// next returns the next matching event, or the channel that is closed when
+// another event is published.
+func (s *memoryDeviceEventStream) next() (*model.DeviceEvent, <-chan struct{}, error) {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	first := s.feed.firstSeq()
+	if s.after+1 < first {
+		// Events were evicted before the watcher read them.
+		return nil, nil, ErrEventsLost
+	}
+
+	for seq := s.after + 1; seq <= s.feed.lastSeq; seq++ {
+		event := s.feed.events[seq-first]
+		s.after = seq
+		if s.matches(event.Device) {
+			// Every watcher gets its own device, which it may change.
+			copied := *event
+			copied.Device = event.Device.Clone()
+			return &copied, nil, nil
+		}
+	}
+
+	return nil, s.feed.notify, nil
+}
+
+// matches reports whether events of device are delivered to the watcher.
+func (s *memoryDeviceEventStream) matches(device *model.Device) bool {
+	if device == nil {
+		return false
+	}
+
+	if device.UserID != s.userID && !sharedWith(device, s.userID) {
+		return false
+	}
+
+	if s.groupID == "" {
+		return true
+	}
+
+	for _, groupID := range device.GroupIDs {
+		if groupID == s.groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedWith reports whether device is shared with userID, whether or not the
+// share has expired.
+func sharedWith(device *model.Device, userID string) bool {
+	for _, share := range device.Shares {
+		if share.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *memoryDeviceEventStream) Close(_ context.Context) error {
+	return nil
+}
+
+// Ensure DeviceMemoryChangeFeed implements DeviceChangeFeed interface
+var _ DeviceChangeFeed = &DeviceMemoryChangeFeed{}
@@ -0,0 +1,167 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+func publishDevice(feed *repository.DeviceMemoryChangeFeed, eventType model.DeviceEventType, id, userID string) {
+	feed.Publish(model.DeviceEvent{
+		Type:   eventType,
+		Device: &model.Device{ID: id, UserID: userID},
+	})
+}
+
+func TestDeviceMemoryChangeFeed_WatchDevices(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := feed.WatchDevices(ctx, "user123", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Events for other users are skipped.
+	publishDevice(feed, model.DeviceCreated, "device1", "someone-else")
+	publishDevice(feed, model.DeviceUpdated, "device2", "user123")
+
+	event, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.Type != model.DeviceUpdated || event.Device.ID != "device2" {
+		t.Errorf("expected update of device2, got %+v", event)
+	}
+}
+
+func TestDeviceMemoryChangeFeed_WatchDevices_Shared(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := feed.WatchDevices(ctx, "friend", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Devices shared with the watcher are delivered with the owner's events.
+	publishDevice(feed, model.DeviceUpdated, "device1", "owner")
+	feed.Publish(model.DeviceEvent{
+		Type:   model.DeviceUpdated,
+		Device: &model.Device{ID: "device2", UserID: "owner", Shares: []model.DeviceShare{{UserID: "friend", Role: model.RoleViewer}}},
+	})
+
+	event, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.Device.ID != "device2" {
+		t.Errorf("expected update of the shared device2, got %+v", event)
+	}
+}
+
+func TestDeviceMemoryChangeFeed_Resume(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := feed.WatchDevices(ctx, "user123", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	publishDevice(feed, model.DeviceCreated, "device1", "user123")
+	first, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Events published while the client is disconnected are replayed after resuming.
+	publishDevice(feed, model.DeviceUpdated, "device1", "user123")
+	publishDevice(feed, model.DeviceDeleted, "device1", "user123")
+
+	resumed, err := feed.WatchDevices(ctx, "user123", "", first.ResumeToken)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, want := range []model.DeviceEventType{model.DeviceUpdated, model.DeviceDeleted} {
+		event, err := resumed.Next(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if event.Type != want {
+			t.Errorf("expected %s event, got %s", want, event.Type)
+		}
+	}
+}
+
+func TestDeviceMemoryChangeFeed_EventsLost(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(2)
+	ctx := context.Background()
+
+	publishDevice(feed, model.DeviceCreated, "device1", "user123")
+	publishDevice(feed, model.DeviceUpdated, "device1", "user123")
+	publishDevice(feed, model.DeviceUpdated, "device1", "user123")
+
+	// The event after token "0" has been evicted.
+	_, err := feed.WatchDevices(ctx, "user123", "", "0")
+	if !errs.Is(err, errs.FailedPrecondition) {
+		t.Errorf("expected FailedPrecondition error, got %v", err)
+	}
+
+	// A malformed token is rejected.
+	_, err = feed.WatchDevices(ctx, "user123", "", "latest")
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestDeviceMemoryChangeFeed_NextCanceled(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	stream, err := feed.WatchDevices(ctx, "user123", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = stream.Next(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestDeviceMemoryChangeFeed_WatchDevices_Group(t *testing.T) {
+	feed := repository.NewDeviceMemoryChangeFeed(10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	stream, err := feed.WatchDevices(ctx, "user123", "vans", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Only devices in the watched group are delivered.
+	feed.Publish(model.DeviceEvent{Type: model.DeviceUpdated, Device: &model.Device{ID: "bike", UserID: "user123", GroupIDs: []string{"bikes"}}})
+	feed.Publish(model.DeviceEvent{Type: model.DeviceUpdated, Device: &model.Device{ID: "van", UserID: "user123", GroupIDs: []string{"bikes", "vans"}}})
+
+	event, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.Device.ID != "van" {
+		t.Errorf("expected the event of the van, got %+v", event.Device)
+	}
+}
@@ -2,31 +2,114 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 
-	"github.com/BerryTracer/common-service/adapter/database/mongodb"
 	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type DeviceRepository interface {
 	CreateDevice(ctx context.Context, device *model.Device) error
+	CreateDevices(ctx context.Context, devices []*model.Device) ([]*model.Device, error)
 	GetDeviceById(ctx context.Context, id string) (*model.Device, error)
 	GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (*model.Device, error)
 	GetDevicesByUserId(ctx context.Context, userId string) ([]*model.Device, error)
+	CountDevicesByType(ctx context.Context, deviceType string) (int64, error)
+	ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error)
+	FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error)
+	UpdateDevice(ctx context.Context, device *model.Device, change *model.StatusChange) error
+	PatchDevice(ctx context.Context, device *model.Device, paths []string, change *model.StatusChange) error
+	ChangeDeviceStatus(ctx context.Context, id string, change *model.StatusChange, version int64) (*model.Device, error)
+	UpdateTelemetry(ctx context.Context, id string, sample *model.TelemetrySample) error
+	UpdateLocation(ctx context.Context, id string, location *model.Location) error
+	GetDeviceByKeyHash(ctx context.Context, keyHash string) (*model.Device, error)
+	SetDeviceCredential(ctx context.Context, id string, credential *model.DeviceCredential) error
+	ClaimDevice(ctx context.Context, id string, codeHash string, userId string, claimedAt int64) error
+	RecordClaimFailure(ctx context.Context, id string, maxAttempts int, now int64, lockedUntil int64) error
+	StartTransfer(ctx context.Context, id string, ownerId string, transfer *model.DeviceTransfer) error
+	AnswerTransfer(ctx context.Context, id string, change *model.OwnershipChange) error
+	GetIncomingTransfers(ctx context.Context, userId string, now int64) ([]*model.Device, error)
+	GetSharedDevices(ctx context.Context, userId string, now int64) ([]*model.Device, error)
+	ShareDevice(ctx context.Context, id string, ownerId string, share *model.DeviceShare, maxShares int, now int64) error
+	UnshareDevice(ctx context.Context, id string, userId string) error
+	GetDevicesByGroupId(ctx context.Context, userId string, groupId string) ([]*model.Device, error)
+	AddDevicesToGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error
+	RemoveDevicesFromGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error
+	SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string, maxTags int, maxLabels int) error
+	RemoveDeviceLabels(ctx context.Context, id string, tags []string, keys []string) error
+	DeleteDevice(ctx context.Context, id string, deletedBy string, deletedAt int64, version int64) error
+	GetDeletedDeviceById(ctx context.Context, id string) (*model.Device, error)
+	GetDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error)
+	GetDevicesDeletedBefore(ctx context.Context, deletedBefore int64, limit int64) ([]*model.Device, error)
+	RestoreDevice(ctx context.Context, id string) error
+	PurgeDevice(ctx context.Context, id string, deletedBefore int64) error
+	GetDevicesPendingHistoryWipe(ctx context.Context, limit int64) ([]*model.Device, error)
+	CompleteHistoryWipe(ctx context.Context, id string, before int64) error
 }
 
+// ErrEmptyUpdateMask is returned by PatchDevice when no field paths are given.
+var ErrEmptyUpdateMask = errs.InvalidField("update_mask", "must contain at least one path")
+
+// immutableDeviceFields lists the paths that can never be changed by a patch.
+var immutableDeviceFields = map[string]bool{
+	"id":                true,
+	"serial_number":     true,
+	"user_id":           true,
+	"registration_date": true,
+}
+
+// ErrVersionMismatch is returned by writes that expect a version of the device
+// when it has been changed since. Its metadata carries the current version.
+var ErrVersionMismatch = errs.New(errs.Aborted, "DEVICE_VERSION_MISMATCH", "device was changed since it was read; read it again and retry")
+
+// bumpVersion is the update operator that counts a change of a device; see model.DeviceDB.Version.
+var bumpVersion = primitive.M{"version": int64(1)}
+
+// bumpVersionExpr is the expression that counts a change of a device in a pipeline update.
+var bumpVersionExpr = primitive.M{"$add": primitive.A{primitive.M{"$ifNull": primitive.A{"$version", int64(0)}}, int64(1)}}
+
+// wipedDeviceFields lists the fields of a device that hold its history, which
+// are removed when a transfer is accepted with the history wiped.
+var wipedDeviceFields = []string{
+	"battery_level",
+	"charging_state",
+	"signal_strength",
+	"last_telemetry_at",
+	"last_location",
+	"last_fix",
+	"last_status_change",
+	"status_history",
+}
+
+// notDeleted is the deleted_at condition that matches devices outside the trash.
+// Reads and writes of devices use it, so deleted devices are only reachable
+// through the methods for the trash.
+var notDeleted = primitive.M{"$exists": false}
+
 type DeviceMongoRepository struct {
-	Collection mongodb.MongoAdapter
+	Collection Collection
 }
 
 // NewDeviceMongoRepository returns a new DeviceMongoRepository.
-func NewDeviceMongoRepository(collection mongodb.MongoAdapter) *DeviceMongoRepository {
+func NewDeviceMongoRepository(collection Collection) *DeviceMongoRepository {
 	return &DeviceMongoRepository{Collection: collection}
 }
 
 // CreateDevice implements DeviceRepository.
+// A device without an ID is assigned a new ObjectID, which is written back to
+// device, and starts at version 1.
 func (r *DeviceMongoRepository) CreateDevice(ctx context.Context, device *model.Device) error {
-	deviceDB, err := device.ToDeviceDB()
+	if device.ID == "" {
+		device.ID = primitive.NewObjectID().Hex()
+	}
+	device.Version = 1
+
+	deviceDB, err := toDeviceDB(device)
 
 	if err != nil {
 		return err
@@ -35,27 +118,82 @@ func (r *DeviceMongoRepository) CreateDevice(ctx context.Context, device *model.
 	_, err = r.Collection.InsertOne(ctx, deviceDB)
 
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	return nil
 }
 
+// CreateDevices implements DeviceRepository.
+// The devices are inserted independently: devices whose serial number already
+// exists are skipped, and the devices that were created are returned.
+func (r *DeviceMongoRepository) CreateDevices(ctx context.Context, devices []*model.Device) ([]*model.Device, error) {
+	if len(devices) == 0 {
+		return nil, nil
+	}
+
+	documents := make([]interface{}, 0, len(devices))
+	for _, device := range devices {
+		if device.ID == "" {
+			device.ID = primitive.NewObjectID().Hex()
+		}
+		device.Version = 1
+
+		deviceDB, err := toDeviceDB(device)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, deviceDB)
+	}
+
+	_, err := r.Collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return devices, nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return nil, translateError(err)
+	}
+
+	skipped := make(map[int]bool, len(bulkErr.WriteErrors))
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKey {
+			return nil, translateError(err)
+		}
+		skipped[writeErr.Index] = true
+	}
+
+	created := make([]*model.Device, 0, len(devices)-len(skipped))
+	for i, device := range devices {
+		if !skipped[i] {
+			created = append(created, device)
+		}
+	}
+
+	return created, nil
+}
+
 // GetDeviceById implements DeviceRepository.
 func (r *DeviceMongoRepository) GetDeviceById(ctx context.Context, id string) (*model.Device, error) {
-	var deviceDB model.DeviceDB
-	err := r.Collection.FindOne(ctx, primitive.M{"_id": id}).Decode(&deviceDB)
+	objectID, err := parseObjectID("id", id)
 	if err != nil {
 		return nil, err
 	}
 
+	var deviceDB model.DeviceDB
+	err = r.Collection.FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": notDeleted}).Decode(&deviceDB)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
 	return deviceDB.ToDevice(), nil
 }
 
 // GetDevicesByUserId implements DeviceRepository.
 func (r *DeviceMongoRepository) GetDevicesByUserId(ctx context.Context, userId string) ([]*model.Device, error) {
 	var devicesDB []*model.DeviceDB
-	cursor, err := r.Collection.Find(ctx, primitive.M{"user_id": userId})
+	cursor, err := r.Collection.Find(ctx, primitive.M{"user_id": userId, "deleted_at": notDeleted})
 	if err != nil {
 		return nil, err
 	}
@@ -71,16 +209,1182 @@ func (r *DeviceMongoRepository) GetDevicesByUserId(ctx context.Context, userId s
 	return devices, nil
 }
 
+// CountDevicesByType implements DeviceRepository.
+// Devices in the trash are counted, since they keep their type when restored.
+func (r *DeviceMongoRepository) CountDevicesByType(ctx context.Context, deviceType string) (int64, error) {
+	return r.Collection.CountDocuments(ctx, primitive.M{"device_type": deviceType})
+}
+
+// ListDevices implements DeviceRepository.
+// Pages are read with keyset pagination on the requested order, so the cost of
+// a page does not grow with its position in the listing.
+func (r *DeviceMongoRepository) ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error) {
+	order, err := parseOrderBy(query.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := deviceFilter(query.UserID, query.SharedAt, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := filter
+	if query.PageToken != "" {
+		cursor, err := decodePageToken(query.PageToken, order)
+		if err != nil {
+			return nil, err
+		}
+		pageFilter = primitive.M{"$and": primitive.A{filter, order.after(cursor)}}
+	}
+
+	// Fetch one extra device to find out whether another page follows.
+	findOptions := options.Find().SetSort(order.sort()).SetLimit(int64(size + 1))
+	cursor, err := r.Collection.Find(ctx, pageFilter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*model.DeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	total, err := r.Collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.DevicePage{TotalSize: total}
+	if len(devicesDB) > size {
+		devicesDB = devicesDB[:size]
+		last := devicesDB[size-1]
+		page.NextPageToken, err = encodePageToken(&pageCursor{Order: order, Value: sortValue(last, order.Field), ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, deviceDB := range devicesDB {
+		page.Devices = append(page.Devices, deviceDB.ToDevice())
+	}
+
+	return page, nil
+}
+
+// nearbyDeviceDB is a device as returned by the $geoNear stage.
+type nearbyDeviceDB struct {
+	model.DeviceDB `bson:",inline"`
+	Distance       float64 `bson:"distance"`
+}
+
+// FindDevicesNear implements DeviceRepository.
+// Devices are returned nearest first; devices that never reported a location
+// are not included.
+func (r *DeviceMongoRepository) FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error) {
+	limit, err := nearbyLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := deviceFilter(query.UserID, query.SharedAt, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := primitive.A{
+		primitive.M{"$geoNear": primitive.M{
+			"near":          model.NewGeoPoint(query.Latitude, query.Longitude),
+			"key":           "last_location",
+			"distanceField": "distance",
+			"maxDistance":   query.Radius,
+			"spherical":     true,
+			"query":         filter,
+		}},
+		primitive.M{"$limit": limit},
+	}
+
+	cursor, err := r.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*nearbyDeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*model.NearbyDevice, 0, len(devicesDB))
+	for _, deviceDB := range devicesDB {
+		devices = append(devices, &model.NearbyDevice{Device: deviceDB.ToDevice(), Distance: deviceDB.Distance})
+	}
+
+	return devices, nil
+}
+
+// deviceFilter builds the Mongo filter for a user's devices matching filter.
+// A non-zero sharedAt also matches the devices shared with the user by a
+// share that is active at that time. A malformed label selector is reported
+// as an InvalidArgument error.
+func deviceFilter(userID string, sharedAt int64, filter model.DeviceFilter) (primitive.M, error) {
+	query := primitive.M{"deleted_at": notDeleted}
+	if sharedAt == 0 {
+		query["user_id"] = userID
+	} else {
+		query["$or"] = primitive.A{
+			primitive.M{"user_id": userID},
+			primitive.M{"shares": activeShare(userID, sharedAt)},
+		}
+	}
+
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	if filter.DeviceType != "" {
+		query["device_type"] = filter.DeviceType
+	}
+
+	if filter.BatteryBelow != nil {
+		query["battery_level"] = primitive.M{"$lt": *filter.BatteryBelow}
+	}
+
+	if filter.GroupID != "" {
+		query["group_ids"] = filter.GroupID
+	}
+
+	if len(filter.Tags) > 0 {
+		query["tags"] = primitive.M{"$all": filter.Tags}
+	}
+
+	if filter.LabelSelector != "" {
+		requirements, err := labelFilter(filter.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		if len(requirements) > 0 {
+			query["$and"] = requirements
+		}
+	}
+
+	return query, nil
+}
+
+// sortValue returns the value of the sortable field of deviceDB.
+func sortValue(deviceDB *model.DeviceDB, field string) interface{} {
+	switch field {
+	case "name":
+		return deviceDB.Name
+	case "registration_date":
+		return deviceDB.RegistrationDate
+	case "battery_level":
+		return deviceDB.BatteryLevel
+	default:
+		return nil
+	}
+}
+
 // GetDeviceBySerialNumber implements DeviceRepository.
+// Devices in the trash have no serial_number, so they are never found.
 func (r *DeviceMongoRepository) GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (*model.Device, error) {
 	var deviceDB model.DeviceDB
 	err := r.Collection.FindOne(ctx, primitive.M{"serial_number": serialNumber}).Decode(&deviceDB)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return deviceDB.ToDevice(), nil
+}
+
+// UpdateDevice implements DeviceRepository.
+// A non-zero device.Version must still be the version of the stored device.
+// A non-nil change moves the device to a new status in the same update, like
+// ChangeDeviceStatus. On success, device holds the updated device.
+func (r *DeviceMongoRepository) UpdateDevice(ctx context.Context, device *model.Device, change *model.StatusChange) error {
+	deviceDB, err := toDeviceDB(device)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"user_id":           deviceDB.UserID,
+		"serial_number":     deviceDB.SerialNumber,
+		"device_type":       deviceDB.DeviceType,
+		"name":              deviceDB.Name,
+		"status":            deviceDB.Status,
+		"registration_date": deviceDB.RegistrationDate,
+		"battery_level":     deviceDB.BatteryLevel,
+		"attributes":        deviceDB.Attributes,
+	}, "$inc": bumpVersion}
+
+	filter := withVersion(primitive.M{"_id": deviceDB.ID, "deleted_at": notDeleted}, deviceDB.Version)
+	notMatched := ErrDeviceNotFound
+	if change != nil {
+		applyStatusChange(filter, update, change)
+		notMatched = ErrStatusConflict
+	}
+
+	return r.updateAndRead(ctx, filter, update, device, deviceDB.Version, notMatched)
+}
+
+// PatchDevice implements DeviceRepository.
+// Only the fields named in paths are written, so values the client did not
+// intend to change are left untouched. A non-zero device.Version must still be
+// the version of the stored device. A non-nil change moves the device to a new
+// status in the same update, in which case paths may be empty. On success,
+// device holds the updated device.
+func (r *DeviceMongoRepository) PatchDevice(ctx context.Context, device *model.Device, paths []string, change *model.StatusChange) error {
+	deviceDB, err := toDeviceDB(device)
+
+	if err != nil {
+		return err
+	}
+
+	set := primitive.M{}
+	if len(paths) > 0 || change == nil {
+		if set, err = patchSet(deviceDB, paths); err != nil {
+			return err
+		}
+	}
+
+	update := primitive.M{"$set": set, "$inc": bumpVersion}
+	filter := withVersion(primitive.M{"_id": deviceDB.ID, "deleted_at": notDeleted}, deviceDB.Version)
+	notMatched := ErrDeviceNotFound
+	if change != nil {
+		applyStatusChange(filter, update, change)
+		notMatched = ErrStatusConflict
+	}
+
+	return r.updateAndRead(ctx, filter, update, device, deviceDB.Version, notMatched)
+}
+
+// updateAndRead applies update to the device matching filter and replaces
+// device with the updated device, so its new version is known without reading
+// it again. When nothing matches, the error reports a version conflict or notMatched.
+func (r *DeviceMongoRepository) updateAndRead(ctx context.Context, filter primitive.M, update primitive.M, device *model.Device, version int64, notMatched error) error {
+	var updated model.DeviceDB
+	err := r.Collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return r.versionConflict(ctx, filter["_id"].(primitive.ObjectID), version, notMatched)
+	}
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	*device = *updated.ToDevice()
+	return nil
+}
+
+// patchSet builds the $set document for the given field mask paths.
+func patchSet(deviceDB *model.DeviceDB, paths []string) (primitive.M, error) {
+	if len(paths) == 0 {
+		return nil, ErrEmptyUpdateMask
+	}
+
+	set := primitive.M{}
+	for _, path := range paths {
+		if immutableDeviceFields[path] {
+			return nil, errs.InvalidField("update_mask", fmt.Sprintf("field %q is immutable", path))
+		}
+
+		switch path {
+		case "device_type":
+			set[path] = deviceDB.DeviceType
+		case "name":
+			set[path] = deviceDB.Name
+		case "status":
+			set[path] = deviceDB.Status
+		case "battery_level":
+			set[path] = deviceDB.BatteryLevel
+		case "attributes":
+			set[path] = deviceDB.Attributes
+		default:
+			return nil, errs.InvalidField("update_mask", fmt.Sprintf("unknown field %q", path))
+		}
+	}
+
+	return set, nil
+}
+
+// statusHistoryLimit is the number of status changes kept on a device.
+const statusHistoryLimit = 100
+
+// ErrStatusConflict is returned by ChangeDeviceStatus when the device is no
+// longer in the status the change starts from.
+var ErrStatusConflict = errs.New(errs.FailedPrecondition, "DEVICE_STATUS_CONFLICT", "device status was changed concurrently")
+
+// ChangeDeviceStatus implements DeviceRepository.
+// The change is only applied while the device is still in change.From and, if
+// version is not 0, still at version. It is appended to the device's status
+// history. The updated device is returned.
+func (r *DeviceMongoRepository) ChangeDeviceStatus(ctx context.Context, id string, change *model.StatusChange, version int64) (*model.Device, error) {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	update := primitive.M{"$set": primitive.M{}, "$inc": bumpVersion}
+	filter := withVersion(primitive.M{"_id": objectID, "deleted_at": notDeleted}, version)
+	applyStatusChange(filter, update, change)
+
+	device := &model.Device{}
+	if err := r.updateAndRead(ctx, filter, update, device, version, ErrStatusConflict); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// applyStatusChange adds change to an update of a device: the update only
+// matches while the device is still in change.From, sets the new status and
+// appends the change to the status history. update must have a $set document.
+func applyStatusChange(filter primitive.M, update primitive.M, change *model.StatusChange) {
+	filter["status"] = change.From
+
+	set := update["$set"].(primitive.M)
+	set["status"] = change.To
+	set["last_status_change"] = change
+
+	update["$push"] = primitive.M{
+		"status_history": primitive.M{"$each": primitive.A{change}, "$slice": -statusHistoryLimit},
+	}
+}
+
+// UpdateTelemetry implements DeviceRepository.
+// The latest telemetry values on the device are only replaced by a newer sample,
+// so batches that arrive out of order never move them back in time.
+func (r *DeviceMongoRepository) UpdateTelemetry(ctx context.Context, id string, sample *model.TelemetrySample) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{
+		"_id":        objectID,
+		"deleted_at": notDeleted,
+		"$or": primitive.A{
+			primitive.M{"last_telemetry_at": primitive.M{"$exists": false}},
+			primitive.M{"last_telemetry_at": primitive.M{"$lt": sample.Timestamp}},
+		},
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"battery_level":     sample.BatteryLevel,
+		"charging_state":    sample.ChargingState,
+		"signal_strength":   sample.SignalStrength,
+		"last_telemetry_at": sample.Timestamp,
+	}}
+
+	if _, err := r.Collection.UpdateOne(ctx, filter, update); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// UpdateLocation implements DeviceRepository.
+// Like UpdateTelemetry, the last location is only replaced by a newer one.
+func (r *DeviceMongoRepository) UpdateLocation(ctx context.Context, id string, location *model.Location) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{
+		"_id":        objectID,
+		"deleted_at": notDeleted,
+		"$or": primitive.A{
+			primitive.M{"last_fix.timestamp": primitive.M{"$exists": false}},
+			primitive.M{"last_fix.timestamp": primitive.M{"$lt": location.Timestamp}},
+		},
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"last_location": model.NewGeoPoint(location.Latitude, location.Longitude),
+		"last_fix":      location.Fix(),
+	}}
+
+	if _, err := r.Collection.UpdateOne(ctx, filter, update); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// GetDeviceByKeyHash implements DeviceRepository.
+func (r *DeviceMongoRepository) GetDeviceByKeyHash(ctx context.Context, keyHash string) (*model.Device, error) {
+	var deviceDB model.DeviceDB
+	err := r.Collection.FindOne(ctx, primitive.M{"credential.key_hash": keyHash, "deleted_at": notDeleted}).Decode(&deviceDB)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return deviceDB.ToDevice(), nil
+}
+
+// SetDeviceCredential implements DeviceRepository.
+// A nil credential removes the device's key.
+func (r *DeviceMongoRepository) SetDeviceCredential(ctx context.Context, id string, credential *model.DeviceCredential) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{"$unset": primitive.M{"credential": ""}, "$inc": bumpVersion}
+	if credential != nil {
+		update = primitive.M{"$set": primitive.M{"credential": credential}, "$inc": bumpVersion}
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": objectID, "deleted_at": notDeleted}, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// DeleteDevice implements DeviceRepository.
+// The device is moved to the trash rather than removed: it is marked as
+// deleted by deletedBy at deletedAt, and its serial number is set aside so it
+// can be registered again. A non-zero version must still be the version of the
+// stored device.
+func (r *DeviceMongoRepository) DeleteDevice(ctx context.Context, id string, deletedBy string, deletedAt int64, version int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{
+		"$set":    primitive.M{"deleted_at": deletedAt, "deleted_by": deletedBy},
+		"$rename": primitive.M{"serial_number": "deleted_serial_number"},
+		"$inc":    bumpVersion,
+	}
+
+	filter := withVersion(primitive.M{"_id": objectID, "deleted_at": notDeleted}, version)
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return r.versionConflict(ctx, objectID, version, ErrDeviceNotFound)
+	}
+
+	return nil
+}
+
+// GetDeletedDeviceById implements DeviceRepository.
+// Only a device in the trash is returned.
+func (r *DeviceMongoRepository) GetDeletedDeviceById(ctx context.Context, id string) (*model.Device, error) {
+	objectID, err := parseObjectID("id", id)
 	if err != nil {
 		return nil, err
 	}
 
+	var deviceDB model.DeviceDB
+	err = r.Collection.FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": true}}).Decode(&deviceDB)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
 	return deviceDB.ToDevice(), nil
 }
 
+// GetDeletedDevices implements DeviceRepository.
+// The devices of userId in the trash are returned, the most recently deleted first.
+func (r *DeviceMongoRepository) GetDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error) {
+	filter := primitive.M{"user_id": userId, "deleted_at": primitive.M{"$exists": true}}
+
+	return r.findDevices(ctx, filter, options.Find().SetSort(primitive.D{{Key: "deleted_at", Value: -1}}))
+}
+
+// GetDevicesDeletedBefore implements DeviceRepository.
+// It returns at most limit devices that were moved to the trash before
+// deletedBefore, the longest deleted first.
+func (r *DeviceMongoRepository) GetDevicesDeletedBefore(ctx context.Context, deletedBefore int64, limit int64) ([]*model.Device, error) {
+	filter := primitive.M{"deleted_at": primitive.M{"$lt": deletedBefore}}
+
+	return r.findDevices(ctx, filter, options.Find().SetSort(primitive.D{{Key: "deleted_at", Value: 1}}).SetLimit(limit))
+}
+
+// RestoreDevice implements DeviceRepository.
+// The device leaves the trash with its serial number, which fails with
+// AlreadyExists if another device was registered with it in the meantime.
+func (r *DeviceMongoRepository) RestoreDevice(ctx context.Context, id string) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{
+		"$unset":  primitive.M{"deleted_at": "", "deleted_by": ""},
+		"$rename": primitive.M{"deleted_serial_number": "serial_number"},
+		"$inc":    bumpVersion,
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": true}}, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// PurgeDevice implements DeviceRepository.
+// The device is removed for good, but only while it is still in the trash
+// since before deletedBefore, so a device restored and deleted again in the
+// meantime is kept.
+func (r *DeviceMongoRepository) PurgeDevice(ctx context.Context, id string, deletedBefore int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Collection.DeleteOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$lt": deletedBefore}})
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// GetDevicesPendingHistoryWipe implements DeviceRepository.
+// It returns devices whose history was to be wiped on a transfer but has not
+// been wiped yet, in or out of the trash.
+func (r *DeviceMongoRepository) GetDevicesPendingHistoryWipe(ctx context.Context, limit int64) ([]*model.Device, error) {
+	return r.findDevices(ctx, primitive.M{"history_wipe_pending": true}, options.Find().SetLimit(limit))
+}
+
+// CompleteHistoryWipe implements DeviceRepository.
+// It records that the history of the device has been wiped up to before, unless
+// a later transfer has asked for another wipe in the meantime, which is reported
+// as ErrDeviceNotFound like a device that is gone.
+func (r *DeviceMongoRepository) CompleteHistoryWipe(ctx context.Context, id string, before int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{"_id": objectID, "history_wipe_before": before}
+	update := primitive.M{"$unset": primitive.M{"history_wipe_pending": "", "history_wipe_before": ""}}
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// findDevices returns the devices matching filter.
+func (r *DeviceMongoRepository) findDevices(ctx context.Context, filter primitive.M, opts ...*options.FindOptions) ([]*model.Device, error) {
+	cursor, err := r.Collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*model.DeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*model.Device, 0, len(devicesDB))
+	for _, deviceDB := range devicesDB {
+		devices = append(devices, deviceDB.ToDevice())
+	}
+
+	return devices, nil
+}
+
+// ErrDeviceAlreadyClaimed is returned by ClaimDevice when the device was
+// claimed or its claim code changed in the meantime.
+var ErrDeviceAlreadyClaimed = errs.New(errs.FailedPrecondition, "DEVICE_ALREADY_CLAIMED", "device has already been claimed")
+
+// ErrClaimLocked is returned by ClaimDevice and RecordClaimFailure while the
+// device refuses claims after too many wrong codes.
+var ErrClaimLocked = errs.New(errs.ResourceExhausted, "CLAIM_LOCKED", "too many wrong claim codes; try again later")
+
+// claimUnlocked matches devices that accept claims at now.
+func claimUnlocked(now int64) primitive.M {
+	return primitive.M{"$lte": now}
+}
+
+// ClaimDevice implements DeviceRepository.
+// The device is only bound to userId while it is unowned, accepts claims at
+// claimedAt, and its claim code is unused and still has the given hash, so a
+// code can never be used twice nor during a lockout.
+func (r *DeviceMongoRepository) ClaimDevice(ctx context.Context, id string, codeHash string, userId string, claimedAt int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{
+		"_id":                objectID,
+		"user_id":            "",
+		"claim.code_hash":    codeHash,
+		"claim.claimed_at":   primitive.M{"$exists": false},
+		"claim.locked_until": claimUnlocked(claimedAt),
+		"deleted_at":         notDeleted,
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"user_id":               userId,
+		"registration_date":     claimedAt,
+		"claim.claimed_at":      claimedAt,
+		"claim.claimed_by":      userId,
+		"claim.failed_attempts": 0,
+	}, "$inc": bumpVersion}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		// Wrong codes may have locked claims since the device was read.
+		locked, err := r.Collection.CountDocuments(ctx, primitive.M{"_id": objectID, "claim.locked_until": primitive.M{"$gt": claimedAt}})
+		if err != nil {
+			return translateError(err)
+		}
+		if locked > 0 {
+			return ErrClaimLocked
+		}
+		return ErrDeviceAlreadyClaimed
+	}
+
+	return nil
+}
+
+// RecordClaimFailure implements DeviceRepository.
+// The failed attempts of the device are counted atomically; the attempt that
+// reaches maxAttempts locks claims until lockedUntil and starts a new count.
+// Attempts made while claims are locked at now are refused with ErrClaimLocked
+// rather than counted, so concurrent attempts cannot exceed maxAttempts.
+func (r *DeviceMongoRepository) RecordClaimFailure(ctx context.Context, id string, maxAttempts int, now int64, lockedUntil int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	attempts := primitive.M{"$add": primitive.A{primitive.M{"$ifNull": primitive.A{"$claim.failed_attempts", 0}}, 1}}
+	exhausted := primitive.M{"$gte": primitive.A{attempts, maxAttempts}}
+
+	update := primitive.A{primitive.M{"$set": primitive.M{
+		"claim.failed_attempts": primitive.M{"$cond": primitive.A{exhausted, 0, attempts}},
+		"claim.locked_until":    primitive.M{"$cond": primitive.A{exhausted, lockedUntil, primitive.M{"$ifNull": primitive.A{"$claim.locked_until", 0}}}},
+	}}}
+
+	claimed := primitive.M{"_id": objectID, "claim": primitive.M{"$exists": true}, "deleted_at": notDeleted}
+	filter := primitive.M{"_id": objectID, "claim.locked_until": claimUnlocked(now), "deleted_at": notDeleted}
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.Collection.CountDocuments(ctx, claimed)
+		if err != nil {
+			return translateError(err)
+		}
+		if count > 0 {
+			return ErrClaimLocked
+		}
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// ownershipHistoryLimit is the number of answered transfers kept on a device.
+const ownershipHistoryLimit = 100
+
+// ErrTransferConflict is returned when a transfer was replaced, answered or the
+// device changed owner in the meantime.
+var ErrTransferConflict = errs.New(errs.FailedPrecondition, "DEVICE_TRANSFER_CONFLICT", "device transfer was changed concurrently")
+
+// StartTransfer implements DeviceRepository.
+// The transfer is only stored while ownerId still owns the device, and replaces
+// any transfer offered before.
+func (r *DeviceMongoRepository) StartTransfer(ctx context.Context, id string, ownerId string, transfer *model.DeviceTransfer) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{"$set": primitive.M{"transfer": transfer}, "$inc": bumpVersion}
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": objectID, "user_id": ownerId, "deleted_at": notDeleted}, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrTransferConflict
+	}
+
+	return nil
+}
+
+// AnswerTransfer implements DeviceRepository.
+// The transfer offered at change.OfferedAt is removed and the change appended
+// to the device's ownership history. An accepted transfer moves the device to
+// change.ToUserID in the same update, so the owner changes atomically.
+func (r *DeviceMongoRepository) AnswerTransfer(ctx context.Context, id string, change *model.OwnershipChange) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{
+		"_id":                 objectID,
+		"user_id":             change.FromUserID,
+		"transfer.to_user_id": change.ToUserID,
+		"transfer.created_at": change.OfferedAt,
+		"deleted_at":          notDeleted,
+	}
+
+	unset := primitive.M{"transfer": ""}
+	update := primitive.M{
+		"$unset": unset,
+		"$push": primitive.M{
+			"ownership_history": primitive.M{"$each": primitive.A{change}, "$slice": -ownershipHistoryLimit},
+		},
+		"$inc": bumpVersion,
+	}
+	if change.Outcome == model.TransferAccepted {
+		// The previous owner's shares and groups do not carry over to the new owner.
+		set := primitive.M{"user_id": change.ToUserID}
+		update["$set"] = set
+		unset["shares"] = ""
+		unset["group_ids"] = ""
+		if change.HistoryWiped {
+			// The history is erased after the owner has changed; see CompleteHistoryWipe.
+			set["history_wipe_pending"] = true
+			set["history_wipe_before"] = change.AnsweredAt
+			// The latest readings and status changes belong to the history as well.
+			for _, field := range wipedDeviceFields {
+				unset[field] = ""
+			}
+		}
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrTransferConflict
+	}
+
+	return nil
+}
+
+// GetIncomingTransfers implements DeviceRepository.
+// It returns the devices offered to userId whose transfer has not expired at now.
+func (r *DeviceMongoRepository) GetIncomingTransfers(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	filter := primitive.M{
+		"transfer.to_user_id": userId,
+		"transfer.expires_at": primitive.M{"$gt": now},
+		"deleted_at":          notDeleted,
+	}
+
+	cursor, err := r.Collection.Find(ctx, filter, options.Find().SetSort(primitive.D{{Key: "transfer.created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*model.DeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*model.Device, 0, len(devicesDB))
+	for _, deviceDB := range devicesDB {
+		devices = append(devices, deviceDB.ToDevice())
+	}
+
+	return devices, nil
+}
+
+// activeShare matches a shares array holding a share with userId that is
+// active at now.
+func activeShare(userId string, now int64) primitive.M {
+	return primitive.M{"$elemMatch": primitive.M{
+		"user_id": userId,
+		"$or": primitive.A{
+			primitive.M{"expires_at": 0},
+			primitive.M{"expires_at": primitive.M{"$gt": now}},
+		},
+	}}
+}
+
+// ErrShareNotFound is returned by UnshareDevice when the device is not shared with the user.
+var ErrShareNotFound = errs.New(errs.NotFound, "DEVICE_SHARE_NOT_FOUND", "device is not shared with this user")
+
+// GetSharedDevices implements DeviceRepository.
+// It returns the devices shared with userId by a share that is active at now.
+func (r *DeviceMongoRepository) GetSharedDevices(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	filter := primitive.M{"shares": activeShare(userId, now), "deleted_at": notDeleted}
+
+	cursor, err := r.Collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*model.DeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*model.Device, 0, len(devicesDB))
+	for _, deviceDB := range devicesDB {
+		devices = append(devices, deviceDB.ToDevice())
+	}
+
+	return devices, nil
+}
+
+// ErrShareLimitReached is returned by ShareDevice when the device is already
+// shared with the most users allowed.
+var ErrShareLimitReached = errs.New(errs.FailedPrecondition, "DEVICE_SHARE_LIMIT_REACHED", "device is shared with too many users")
+
+// ShareDevice implements DeviceRepository.
+// The share replaces any earlier share with the same user, and the shares that
+// have expired at now are dropped. It is only stored while ownerId still owns
+// the device and the device keeps no more than maxShares active shares.
+func (r *DeviceMongoRepository) ShareDevice(ctx context.Context, id string, ownerId string, share *model.DeviceShare, maxShares int, now int64) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	// The active shares with other users, which the new share joins.
+	others := primitive.M{"$filter": primitive.M{
+		"input": primitive.M{"$ifNull": primitive.A{"$shares", primitive.A{}}},
+		"cond": primitive.M{"$and": primitive.A{
+			primitive.M{"$ne": primitive.A{"$$this.user_id", share.UserID}},
+			primitive.M{"$or": primitive.A{
+				primitive.M{"$eq": primitive.A{"$$this.expires_at", 0}},
+				primitive.M{"$gt": primitive.A{"$$this.expires_at", now}},
+			}},
+		}},
+	}}
+	// A pipeline update replaces the shares in a single write.
+	update := primitive.A{primitive.M{"$set": primitive.M{
+		"shares":  primitive.M{"$concatArrays": primitive.A{others, primitive.A{share}}},
+		"version": bumpVersionExpr,
+	}}}
+
+	// The limit is part of the filter, so concurrent shares cannot exceed it.
+	owned := primitive.M{"_id": objectID, "user_id": ownerId, "deleted_at": notDeleted}
+	filter := primitive.M{"_id": objectID, "user_id": ownerId, "deleted_at": notDeleted, "$expr": primitive.M{
+		"$lt": primitive.A{primitive.M{"$size": others}, maxShares},
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.Collection.CountDocuments(ctx, owned)
+		if err != nil {
+			return translateError(err)
+		}
+		if count > 0 {
+			return ErrShareLimitReached
+		}
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// UnshareDevice implements DeviceRepository.
+func (r *DeviceMongoRepository) UnshareDevice(ctx context.Context, id string, userId string) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{"_id": objectID, "shares.user_id": userId, "deleted_at": notDeleted}
+	update := primitive.M{"$pull": primitive.M{"shares": primitive.M{"user_id": userId}}, "$inc": bumpVersion}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return translateError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrShareNotFound
+	}
+
+	return nil
+}
+
+// GetDevicesByGroupId implements DeviceRepository.
+// Only devices of userId are returned, so the query uses the user_id and group_ids index.
+func (r *DeviceMongoRepository) GetDevicesByGroupId(ctx context.Context, userId string, groupId string) ([]*model.Device, error) {
+	cursor, err := r.Collection.Find(ctx, primitive.M{"user_id": userId, "group_ids": groupId, "deleted_at": notDeleted})
+	if err != nil {
+		return nil, err
+	}
+
+	var devicesDB []*model.DeviceDB
+	if err = cursor.All(ctx, &devicesDB); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*model.Device, 0, len(devicesDB))
+	for _, deviceDB := range devicesDB {
+		devices = append(devices, deviceDB.ToDevice())
+	}
+
+	return devices, nil
+}
+
+// AddDevicesToGroup implements DeviceRepository.
+// Devices of other users than userId are left out. Adding a device that is
+// already in the group has no effect.
+func (r *DeviceMongoRepository) AddDevicesToGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error {
+	objectIDs, err := parseDeviceIDs(deviceIds)
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{"_id": primitive.M{"$in": objectIDs}, "user_id": userId, "deleted_at": notDeleted}
+	update := primitive.M{"$addToSet": primitive.M{"group_ids": groupId}, "$inc": bumpVersion}
+
+	if _, err := r.Collection.UpdateMany(ctx, filter, update); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// RemoveDevicesFromGroup implements DeviceRepository.
+// Without deviceIds, every device of userId leaves the group. Devices in the
+// trash leave it as well, so a deleted group is never restored with them.
+func (r *DeviceMongoRepository) RemoveDevicesFromGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error {
+	filter := primitive.M{"user_id": userId, "group_ids": groupId}
+	if deviceIds != nil {
+		objectIDs, err := parseDeviceIDs(deviceIds)
+		if err != nil {
+			return err
+		}
+		filter["_id"] = primitive.M{"$in": objectIDs}
+	}
+
+	update := primitive.M{"$pull": primitive.M{"group_ids": groupId}, "$inc": bumpVersion}
+
+	if _, err := r.Collection.UpdateMany(ctx, filter, update); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// ErrLabelLimitReached is returned by SetDeviceLabels when the device would
+// have more tags or labels than allowed.
+var ErrLabelLimitReached = errs.New(errs.FailedPrecondition, "DEVICE_LABEL_LIMIT_REACHED", "device has too many tags or labels")
+
+// SetDeviceLabels implements DeviceRepository.
+// The tags are added to those of the device and the labels replace the values
+// of the same keys; other tags and labels are kept. Nothing is changed if the
+// device would end up with more than maxTags tags or maxLabels labels.
+func (r *DeviceMongoRepository) SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string, maxTags int, maxLabels int) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	// The limits are part of the filter, so concurrent changes cannot exceed them.
+	update := primitive.M{}
+	var limits primitive.A
+	if len(tags) > 0 {
+		update["$addToSet"] = primitive.M{"tags": primitive.M{"$each": tags}}
+		merged := primitive.M{"$setUnion": primitive.A{primitive.M{"$ifNull": primitive.A{"$tags", primitive.A{}}}, primitive.M{"$literal": tags}}}
+		limits = append(limits, primitive.M{"$lte": primitive.A{primitive.M{"$size": merged}, maxTags}})
+	}
+	if len(labels) > 0 {
+		set := primitive.M{}
+		for key, value := range labels {
+			set["labels."+key] = value
+		}
+		update["$set"] = set
+		merged := primitive.M{"$mergeObjects": primitive.A{primitive.M{"$ifNull": primitive.A{"$labels", primitive.M{}}}, primitive.M{"$literal": labels}}}
+		limits = append(limits, primitive.M{"$lte": primitive.A{primitive.M{"$size": primitive.M{"$objectToArray": merged}}, maxLabels}})
+	}
+
+	filter := primitive.M{"_id": objectID, "deleted_at": notDeleted, "$expr": primitive.M{"$and": limits}}
+	result, err := r.updateLabels(ctx, filter, update)
+
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.Collection.CountDocuments(ctx, primitive.M{"_id": objectID, "deleted_at": notDeleted})
+		if err != nil {
+			return translateError(err)
+		}
+		if count > 0 {
+			return ErrLabelLimitReached
+		}
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// RemoveDeviceLabels implements DeviceRepository.
+// Tags and label keys the device does not have are ignored.
+func (r *DeviceMongoRepository) RemoveDeviceLabels(ctx context.Context, id string, tags []string, keys []string) error {
+	objectID, err := parseObjectID("id", id)
+
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{}
+	if len(tags) > 0 {
+		update["$pullAll"] = primitive.M{"tags": tags}
+	}
+	if len(keys) > 0 {
+		unset := primitive.M{}
+		for _, key := range keys {
+			unset["labels."+key] = ""
+		}
+		update["$unset"] = unset
+	}
+
+	result, err := r.updateLabels(ctx, primitive.M{"_id": objectID, "deleted_at": notDeleted}, update)
+
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+// updateLabels applies an update of the tags and labels to the device matching filter.
+func (r *DeviceMongoRepository) updateLabels(ctx context.Context, filter primitive.M, update primitive.M) (*mongo.UpdateResult, error) {
+	if len(update) == 0 {
+		return nil, errs.InvalidField("tags", "no tags or labels to change")
+	}
+
+	update["$inc"] = bumpVersion
+	result, err := r.Collection.UpdateOne(ctx, filter, update)
+
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return result, nil
+}
+
+// withVersion adds the expected version of a device to filter. Version 0
+// expects no particular version.
+func withVersion(filter primitive.M, version int64) primitive.M {
+	if version != 0 {
+		filter["version"] = version
+	}
+	return filter
+}
+
+// versionConflict explains why a write that expected version of a device
+// matched nothing: the device is gone, or it has another version. Otherwise
+// the write failed for its own reason, reported as fallback.
+func (r *DeviceMongoRepository) versionConflict(ctx context.Context, objectID primitive.ObjectID, version int64, fallback error) error {
+	if version == 0 {
+		return fallback
+	}
+
+	var current struct {
+		Version int64 `bson:"version"`
+	}
+	err := r.Collection.FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": notDeleted}, options.FindOne().SetProjection(primitive.M{"version": 1})).Decode(&current)
+	if err != nil {
+		return translateError(err)
+	}
+
+	if current.Version == version {
+		return fallback
+	}
+
+	mismatch := *ErrVersionMismatch
+	return mismatch.WithMetadata("current_version", strconv.FormatInt(current.Version, 10)).
+		WithMetadata("current_etag", model.ETag(current.Version))
+}
+
+// parseDeviceIDs converts hex device IDs into ObjectIDs, reporting the first
+// invalid one on its device_ids field.
+func parseDeviceIDs(deviceIds []string) (primitive.A, error) {
+	objectIDs := make(primitive.A, 0, len(deviceIds))
+	for i, id := range deviceIds {
+		objectID, err := parseObjectID(fmt.Sprintf("device_ids[%d]", i), id)
+		if err != nil {
+			return nil, err
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	return objectIDs, nil
+}
+
 // Ensure DeviceMongoRepository implements DeviceRepository interface
 var _ DeviceRepository = &DeviceMongoRepository{}
@@ -3,12 +3,15 @@ package repository_test
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
 	"github.com/BerryTracer/device-service/model"
 	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
 	"github.com/golang/mock/gomock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -20,7 +23,7 @@ func TestDeviceMongoRepository_CreateDevice(t *testing.T) {
 	defer ctrl.Finish()
 
 	// Create a mock adapter instance.
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
 	// Create a new context.
@@ -41,6 +44,8 @@ func TestDeviceMongoRepository_CreateDevice(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// New devices start at version 1.
+	deviceDB.Version = 1
 
 	// Create a new mock adapter instance.
 	mockAdapter.EXPECT().
@@ -63,7 +68,7 @@ func TestDeviceMongoRepository_CreateDevice_Error_ToDeviceDB(t *testing.T) {
 	defer ctrl.Finish()
 
 	// Create a mock adapter instance.
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
 	// Create a new context.
@@ -95,7 +100,7 @@ func TestDeviceMongoRepository_CreateDevice_Error_InsertOne(t *testing.T) {
 	defer ctrl.Finish()
 
 	// Create a mock adapter instance.
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
 	// Create a new context.
@@ -116,6 +121,8 @@ func TestDeviceMongoRepository_CreateDevice_Error_InsertOne(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// New devices start at version 1.
+	deviceDB.Version = 1
 
 	// Create a new mock adapter instance.
 	mockAdapter.EXPECT().
@@ -142,7 +149,7 @@ func TestDeviceMongoRepository_GetDeviceById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockSingleResult := mock.NewMockSingleResult(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
@@ -157,7 +164,7 @@ func TestDeviceMongoRepository_GetDeviceById(t *testing.T) {
 
 	// Set up the expectation for FindOne, using the correct type for the ID.
 	mockAdapter.EXPECT().
-		FindOne(ctx, primitive.M{"_id": testID}). // Use objectID directly
+		FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}). // Use objectID directly
 		Return(mockSingleResult).
 		Times(1)
 
@@ -185,16 +192,17 @@ func TestDeviceMongoRepository_GetDeviceById_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockSingleResult := mock.NewMockSingleResult(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
 	ctx := context.Background()
-	testID := primitive.NewObjectID().Hex()
+	objectID := primitive.NewObjectID()
+	testID := objectID.Hex()
 
 	// Set up the expectation for FindOne to return an error.
 	mockAdapter.EXPECT().
-		FindOne(ctx, primitive.M{"_id": testID}).
+		FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}).
 		Return(mockSingleResult).
 		Times(1)
 
@@ -213,11 +221,27 @@ func TestDeviceMongoRepository_GetDeviceById_Error(t *testing.T) {
 	}
 }
 
+func TestDeviceMongoRepository_GetDeviceById_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	// Call the GetDeviceById method with an ID that is not an ObjectID.
+	_, err := repo.GetDeviceById(context.Background(), "Error")
+
+	// Check if an error is returned without querying the collection.
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
 func TestDeviceMongoRepository_GetDevicesByUserId(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockCursor := mock.NewMockCursor(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
@@ -226,7 +250,7 @@ func TestDeviceMongoRepository_GetDevicesByUserId(t *testing.T) {
 
 	// Set up the expectation for Find to return a mock cursor.
 	mockAdapter.EXPECT().
-		Find(ctx, primitive.M{"user_id": userId}).
+		Find(ctx, primitive.M{"user_id": userId, "deleted_at": primitive.M{"$exists": false}}).
 		Return(mockCursor, nil).
 		Times(1)
 
@@ -264,7 +288,7 @@ func TestDeviceMongoRepository_GetDevicesByUserId_FindError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
 	ctx := context.Background()
@@ -272,7 +296,7 @@ func TestDeviceMongoRepository_GetDevicesByUserId_FindError(t *testing.T) {
 
 	// Mock the Find method to return an error.
 	mockAdapter.EXPECT().
-		Find(ctx, primitive.M{"user_id": userId}).
+		Find(ctx, primitive.M{"user_id": userId, "deleted_at": primitive.M{"$exists": false}}).
 		Return(nil, errors.New("find error")).
 		Times(1)
 
@@ -289,7 +313,7 @@ func TestDeviceMongoRepository_GetDevicesByUserId_AllError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockCursor := mock.NewMockCursor(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
@@ -298,7 +322,7 @@ func TestDeviceMongoRepository_GetDevicesByUserId_AllError(t *testing.T) {
 
 	// Mock the Find method to return a mock cursor.
 	mockAdapter.EXPECT().
-		Find(ctx, primitive.M{"user_id": userId}).
+		Find(ctx, primitive.M{"user_id": userId, "deleted_at": primitive.M{"$exists": false}}).
 		Return(mockCursor, nil).
 		Times(1)
 
@@ -321,7 +345,7 @@ func TestDeviceMongoRepository_GetDeviceBySerialNumber_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockSingleResult := mock.NewMockSingleResult(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
@@ -359,7 +383,7 @@ func TestDeviceMongoRepository_GetDeviceBySerialNumber_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockAdapter := mock.NewMockMongoAdapter(ctrl)
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
 	mockSingleResult := mock.NewMockSingleResult(ctrl)
 	repo := repository.NewDeviceMongoRepository(mockAdapter)
 
@@ -386,3 +410,1252 @@ func TestDeviceMongoRepository_GetDeviceBySerialNumber_Error(t *testing.T) {
 		t.Errorf("expected mongo.ErrNoDocuments, got %v", err)
 	}
 }
+
+func TestDeviceMongoRepository_UpdateDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{
+		ID:               primitive.NewObjectID().Hex(),
+		UserID:           primitive.NewObjectID().Hex(),
+		SerialNumber:     "1234567890",
+		Name:             "Renamed Device",
+		DeviceType:       "Test Type",
+		Status:           "Test Status",
+		RegistrationDate: time.Now().Unix(),
+		BatteryLevel:     80,
+	}
+
+	deviceDB, err := device.ToDeviceDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Mock the FindOneAndUpdate method to match a single document.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, primitive.M{"_id": deviceDB.ID, "deleted_at": primitive.M{"$exists": false}}, gomock.Any(), gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	// The updated device is read back in the same operation.
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		SetArg(0, model.DeviceDB{ID: deviceDB.ID, Name: "Renamed Device", Version: 2}).
+		Return(nil).
+		Times(1)
+
+	// Call the UpdateDevice method.
+	err = repo.UpdateDevice(ctx, device, nil)
+
+	// Check if the error is nil and the device was replaced by the updated one.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if device.Version != 2 {
+		t.Errorf("expected the updated version 2, got %d", device.Version)
+	}
+}
+
+func TestDeviceMongoRepository_UpdateDevice_Error_ToDeviceDB(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{ID: "Error"}
+
+	// Call the UpdateDevice method.
+	err := repo.UpdateDevice(ctx, device, nil)
+
+	// Check if an error is returned.
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestDeviceMongoRepository_UpdateDevice_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{ID: primitive.NewObjectID().Hex()}
+
+	// Mock the FindOneAndUpdate method to match no documents.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the UpdateDevice method.
+	err := repo.UpdateDevice(ctx, device, nil)
+
+	// Check for a 'not found' error.
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected mongo.ErrNoDocuments, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_PatchDevice_StatusChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	device := &model.Device{ID: objectID.Hex(), Name: "Renamed Device", Version: 3}
+	change := &model.StatusChange{From: model.StatusActive, To: model.StatusLost, ChangedBy: "user123", ChangedAt: 1700000000}
+
+	// The status change and the patched fields are written in a single update.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}, "version": int64(3), "status": model.StatusActive}, primitive.M{
+			"$set": primitive.M{"name": "Renamed Device", "status": model.StatusLost, "last_status_change": change},
+			"$push": primitive.M{
+				"status_history": primitive.M{"$each": primitive.A{change}, "$slice": -100},
+			},
+			"$inc": primitive.M{"version": int64(1)},
+		}, gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	// The updated device is read back in the same operation.
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		SetArg(0, model.DeviceDB{ID: objectID, Name: "Renamed Device", Version: 4}).
+		Return(nil).
+		Times(1)
+
+	// Call the PatchDevice method.
+	if err := repo.PatchDevice(ctx, device, []string{"name"}, change); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_UpdateDevice_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	mockUpdated := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	device := &model.Device{ID: objectID.Hex(), Version: 5}
+
+	// The update only matches while the device is still at the expected version.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}, "version": int64(5)}, gomock.Any(), gomock.Any()).
+		Return(mockUpdated).
+		Times(1)
+
+	mockUpdated.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// The device was changed by someone else in the meantime.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}, gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		SetArg(0, struct {
+			Version int64 `bson:"version"`
+		}{Version: 6}).
+		Return(nil).
+		Times(1)
+
+	// Call the UpdateDevice method.
+	err := repo.UpdateDevice(ctx, device, nil)
+
+	// Check for a version mismatch carrying the current version.
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Kind != errs.Aborted || typed.Metadata["current_etag"] != `"6"` {
+		t.Errorf("expected a version mismatch at version 6, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_DeleteDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the UpdateOne method to move a single document to the trash.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}, primitive.M{
+			"$set":    primitive.M{"deleted_at": int64(1700000000), "deleted_by": "user123"},
+			"$rename": primitive.M{"serial_number": "deleted_serial_number"},
+			"$inc":    primitive.M{"version": int64(1)},
+		}).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).
+		Times(1)
+
+	// Call the DeleteDevice method.
+	err := repo.DeleteDevice(ctx, objectID.Hex(), "user123", 1700000000, 0)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_DeleteDevice_InvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	// Call the DeleteDevice method with an invalid ID.
+	err := repo.DeleteDevice(context.Background(), "Error", "user123", 1700000000, 0)
+
+	// Check if an error is returned.
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestDeviceMongoRepository_DeleteDevice_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the UpdateOne method to match no documents, as for a device already in the trash.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	// Call the DeleteDevice method.
+	err := repo.DeleteDevice(ctx, objectID.Hex(), "user123", 1700000000, 0)
+
+	// Check for a 'not found' error.
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected mongo.ErrNoDocuments, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_RestoreDevice_SerialNumberTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// The serial number was registered again while the device was in the trash.
+	duplicate := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": true}}, primitive.M{
+			"$unset":  primitive.M{"deleted_at": "", "deleted_by": ""},
+			"$rename": primitive.M{"deleted_serial_number": "serial_number"},
+			"$inc":    primitive.M{"version": int64(1)},
+		}).
+		Return(nil, duplicate).
+		Times(1)
+
+	// Call the RestoreDevice method.
+	err := repo.RestoreDevice(ctx, objectID.Hex())
+
+	// Check for an 'already exists' error.
+	if !errs.Is(err, errs.AlreadyExists) {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_GetDeletedDeviceById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	deviceDB := model.DeviceDB{ID: objectID, DeletedAt: 1700000000, DeletedBy: "user123", DeletedSerialNumber: "SN-1"}
+
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": true}}).
+		Return(mockSingleResult).
+		Times(1)
+	mockSingleResult.EXPECT().Decode(gomock.Any()).SetArg(0, deviceDB).Return(nil).Times(1)
+
+	// Call the GetDeletedDeviceById method.
+	device, err := repo.GetDeletedDeviceById(ctx, objectID.Hex())
+
+	// The serial number set aside on deletion is reported as the device's.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if device.SerialNumber != "SN-1" || device.DeletedAt != 1700000000 || device.DeletedBy != "user123" {
+		t.Errorf("expected the deleted device with its serial number, got %+v", device)
+	}
+}
+
+func TestDeviceMongoRepository_PurgeDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the DeleteOne method to find the device restored, or deleted again, in the meantime.
+	mockAdapter.EXPECT().
+		DeleteOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$lt": int64(1700000000)}}).
+		Return(&mongo.DeleteResult{DeletedCount: 0}, nil).
+		Times(1)
+
+	// Call the PurgeDevice method.
+	err := repo.PurgeDevice(ctx, objectID.Hex(), 1700000000)
+
+	// Check for a 'not found' error.
+	if !errors.Is(err, repository.ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_PatchDevice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	device := &model.Device{
+		ID:           objectID.Hex(),
+		Name:         "Renamed Device",
+		Status:       model.StatusInactive,
+		BatteryLevel: 5,
+	}
+
+	// Only the masked fields must end up in the $set document.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}, primitive.M{"$set": primitive.M{
+			"name":   "Renamed Device",
+			"status": model.StatusInactive,
+		}, "$inc": primitive.M{"version": int64(1)}}, gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	// The updated device is read back in the same operation.
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		SetArg(0, model.DeviceDB{ID: objectID, Name: "Renamed Device", Version: 1}).
+		Return(nil).
+		Times(1)
+
+	// Call the PatchDevice method.
+	err := repo.PatchDevice(ctx, device, []string{"name", "status"}, nil)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_PatchDevice_InvalidPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+	}{
+		{name: "empty mask", paths: nil},
+		{name: "id", paths: []string{"id"}},
+		{name: "serial number", paths: []string{"name", "serial_number"}},
+		{name: "user id", paths: []string{"user_id"}},
+		{name: "unknown field", paths: []string{"color"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No UpdateOne call is expected for a rejected mask.
+			mockAdapter := mock_repository.NewMockCollection(ctrl)
+			repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+			device := &model.Device{ID: primitive.NewObjectID().Hex()}
+
+			err := repo.PatchDevice(context.Background(), device, tt.paths, nil)
+
+			if !errs.Is(err, errs.InvalidArgument) {
+				t.Errorf("expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDeviceMongoRepository_PatchDevice_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{ID: primitive.NewObjectID().Hex(), Name: "Renamed Device"}
+
+	// Mock the FindOneAndUpdate method to match no documents.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the PatchDevice method.
+	err := repo.PatchDevice(ctx, device, []string{"name"}, nil)
+
+	// Check for a 'not found' error.
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Errorf("expected mongo.ErrNoDocuments, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_CreateDevice_DuplicateSerialNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{
+		ID:           primitive.NewObjectID().Hex(),
+		SerialNumber: "1234567890",
+	}
+
+	// Mock the InsertOne method to fail on the unique serial_number index.
+	mockAdapter.EXPECT().
+		InsertOne(ctx, gomock.Any()).
+		Return(nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "E11000 duplicate key error"}}}).
+		Times(1)
+
+	// Call the CreateDevice method.
+	err := repo.CreateDevice(ctx, device)
+
+	// Check for an 'already exists' error.
+	if !errs.Is(err, errs.AlreadyExists) {
+		t.Errorf("expected AlreadyExists error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_CreateDevice_DuplicateField(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		wantField string
+	}{
+		{"serial number", "E11000 duplicate key error collection: devices.devices index: serial_number_live dup key: { serial_number: \"SN-1\" }", "serial_number"},
+		{"device key", "E11000 duplicate key error collection: devices.devices index: credential.key_hash_1 dup key: { credential.key_hash: \"abc\" }", "credential.key_hash"},
+		{"unknown index", "E11000 duplicate key error", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAdapter := mock_repository.NewMockCollection(ctrl)
+			repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+			ctx := context.Background()
+			device := &model.Device{ID: primitive.NewObjectID().Hex(), SerialNumber: "SN-1"}
+
+			// The violated index is only named in the message of the write error.
+			mockAdapter.EXPECT().
+				InsertOne(ctx, gomock.Any()).
+				Return(nil, mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: tt.message}}}).
+				Times(1)
+
+			// Call the CreateDevice method.
+			err := repo.CreateDevice(ctx, device)
+
+			// Check that the field of the index is reported, and only that one.
+			var typed *errs.Error
+			if !errors.As(err, &typed) || typed.Kind != errs.AlreadyExists || typed.Metadata["field"] != tt.wantField {
+				t.Errorf("expected AlreadyExists on field %q, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestDeviceMongoRepository_GetDeviceById_TypedErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+
+	mockAdapter.EXPECT().
+		FindOne(ctx, gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// A missing document is reported as NotFound.
+	_, err := repo.GetDeviceById(ctx, primitive.NewObjectID().Hex())
+	if !errs.Is(err, errs.NotFound) {
+		t.Errorf("expected NotFound error, got %v", err)
+	}
+
+	// A malformed ID is reported as InvalidArgument.
+	_, err = repo.GetDeviceById(ctx, "not-an-object-id")
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_CreateDevice_AssignsID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	device := &model.Device{SerialNumber: "1234567890"}
+
+	// Capture the inserted document to compare its ID with the returned one.
+	var inserted *model.DeviceDB
+	mockAdapter.EXPECT().
+		InsertOne(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, document interface{}, _ ...interface{}) (*mongo.InsertOneResult, error) {
+			inserted = document.(*model.DeviceDB)
+			return &mongo.InsertOneResult{InsertedID: inserted.ID}, nil
+		}).
+		Times(1)
+
+	// Call the CreateDevice method.
+	err := repo.CreateDevice(ctx, device)
+
+	// Check that a new ObjectID was generated and written back to the device.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if inserted.ID.IsZero() || device.ID != inserted.ID.Hex() {
+		t.Errorf("expected generated ID %v to be written back, got %q", inserted.ID, device.ID)
+	}
+}
+
+func TestDeviceMongoRepository_ChangeDeviceStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	change := &model.StatusChange{From: model.StatusActive, To: model.StatusLost, ChangedBy: "user123", ChangedAt: time.Now().Unix()}
+
+	// The update only matches while the device is still in the previous status and version.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, primitive.M{"_id": objectID, "status": model.StatusActive, "deleted_at": primitive.M{"$exists": false}, "version": int64(2)}, gomock.Any(), gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		SetArg(0, model.DeviceDB{ID: objectID, Status: model.StatusLost, LastStatusChange: change, Version: 3}).
+		Return(nil).
+		Times(1)
+
+	// Call the ChangeDeviceStatus method.
+	device, err := repo.ChangeDeviceStatus(ctx, objectID.Hex(), change, 2)
+
+	// Check if the error is nil and the updated device is returned.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if device.Status != model.StatusLost || device.Version != 3 {
+		t.Errorf("expected the updated device, got %+v", device)
+	}
+}
+
+func TestDeviceMongoRepository_ChangeDeviceStatus_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	change := &model.StatusChange{From: model.StatusActive, To: model.StatusLost}
+
+	// Mock the FindOneAndUpdate method to match no documents.
+	mockAdapter.EXPECT().
+		FindOneAndUpdate(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the ChangeDeviceStatus method.
+	_, err := repo.ChangeDeviceStatus(ctx, primitive.NewObjectID().Hex(), change, 0)
+
+	// Check for a failed precondition error.
+	if !errs.Is(err, errs.FailedPrecondition) {
+		t.Errorf("expected FailedPrecondition error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_UpdateTelemetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	sample := &model.TelemetrySample{Timestamp: 1700000000, BatteryLevel: 42, ChargingState: model.ChargingCharging, SignalStrength: -80}
+
+	// The update only matches a live device while the stored telemetry is older than the sample.
+	var filter interface{}
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, f interface{}, _ interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			filter = f
+			return &mongo.UpdateResult{MatchedCount: 0}, nil
+		}).
+		Times(1)
+
+	// Call the UpdateTelemetry method.
+	err := repo.UpdateTelemetry(ctx, objectID.Hex(), sample)
+
+	// A stale sample is not an error.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	m := filter.(primitive.M)
+	if m["_id"] != objectID || m["$or"] == nil || m["deleted_at"] == nil {
+		t.Errorf("expected a filter on the device and its last telemetry time, got %v", filter)
+	}
+}
+
+func TestDeviceMongoRepository_UpdateLocation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	location := &model.Location{Latitude: 52.52, Longitude: 13.40, Accuracy: 5, Timestamp: 1700000000}
+
+	// The last location is stored as a GeoJSON point next to its fix.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, update interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			set := update.(primitive.M)["$set"].(primitive.M)
+			point := set["last_location"].(model.GeoPoint)
+			if point.Type != "Point" || point.Longitude() != 13.40 || point.Latitude() != 52.52 {
+				t.Errorf("unexpected point %+v", point)
+			}
+			if set["last_fix"].(model.LocationFix).Timestamp != location.Timestamp {
+				t.Errorf("unexpected fix %+v", set["last_fix"])
+			}
+			return &mongo.UpdateResult{MatchedCount: 1}, nil
+		}).
+		Times(1)
+
+	// Call the UpdateLocation method.
+	err := repo.UpdateLocation(ctx, objectID.Hex(), location)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_SetDeviceCredential_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// A nil credential removes the stored key.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}, primitive.M{"$unset": primitive.M{"credential": ""}, "$inc": primitive.M{"version": int64(1)}}).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).
+		Times(1)
+
+	// Call the SetDeviceCredential method.
+	err := repo.SetDeviceCredential(ctx, objectID.Hex(), nil)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_GetDeviceByKeyHash_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+
+	// Mock the FindOne method to find no device with the key.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"credential.key_hash": "hash", "deleted_at": primitive.M{"$exists": false}}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetDeviceByKeyHash method.
+	_, err := repo.GetDeviceByKeyHash(ctx, "hash")
+
+	// Check for the device not found error.
+	if !errors.Is(err, repository.ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_CreateDevices_SkipsDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	devices := []*model.Device{{SerialNumber: "SN-1"}, {SerialNumber: "SN-2"}, {SerialNumber: "SN-3"}}
+
+	// The second serial number already exists; the others are inserted.
+	mockAdapter.EXPECT().
+		InsertMany(ctx, gomock.Len(3), gomock.Any()).
+		Return(nil, mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: mongo.WriteError{Index: 1, Code: 11000}}}}).
+		Times(1)
+
+	// Call the CreateDevices method.
+	created, err := repo.CreateDevices(ctx, devices)
+
+	// Check that only the duplicate was skipped.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(created) != 2 || created[0].SerialNumber != "SN-1" || created[1].SerialNumber != "SN-3" || created[0].ID == "" {
+		t.Errorf("unexpected created devices %+v", created)
+	}
+}
+
+func TestDeviceMongoRepository_ClaimDevice_AlreadyClaimed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// The claim only matches an unowned device with an unused code that is not locked.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter interface{}, _ interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			m := filter.(primitive.M)
+			if m["_id"] != objectID || m["user_id"] != "" || m["claim.code_hash"] != "hash" || m["claim.claimed_at"] == nil {
+				t.Errorf("unexpected filter %v", filter)
+			}
+			if !reflect.DeepEqual(m["claim.locked_until"], primitive.M{"$lte": int64(1700000000)}) {
+				t.Errorf("expected the claim to require no lockout, got %v", filter)
+			}
+			return &mongo.UpdateResult{MatchedCount: 0}, nil
+		}).
+		Times(1)
+
+	// The device is not locked, so it was claimed in the meantime.
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, primitive.M{"_id": objectID, "claim.locked_until": primitive.M{"$gt": int64(1700000000)}}).
+		Return(int64(0), nil).
+		Times(1)
+
+	// Call the ClaimDevice method.
+	err := repo.ClaimDevice(ctx, objectID.Hex(), "hash", "user123", 1700000000)
+
+	// Check for the already claimed error.
+	if !errors.Is(err, repository.ErrDeviceAlreadyClaimed) {
+		t.Errorf("expected ErrDeviceAlreadyClaimed, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_RecordClaimFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// The count is updated with a pipeline, so that reading and locking are
+	// atomic, and only while claims are not locked.
+	filter := primitive.M{"_id": objectID, "claim.locked_until": primitive.M{"$lte": int64(1700000000)}, "deleted_at": primitive.M{"$exists": false}}
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, filter, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, update interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			pipeline, ok := update.(primitive.A)
+			if !ok || len(pipeline) != 1 {
+				t.Fatalf("expected a pipeline update, got %v", update)
+			}
+			set := pipeline[0].(primitive.M)["$set"].(primitive.M)
+			if set["claim.failed_attempts"] == nil || set["claim.locked_until"] == nil {
+				t.Errorf("unexpected update %v", update)
+			}
+			return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+		}).
+		Times(1)
+
+	// Call the RecordClaimFailure method.
+	if err := repo.RecordClaimFailure(ctx, objectID.Hex(), 5, 1700000000, 1700000900); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_RecordClaimFailure_Locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Another attempt locked claims since the device was read.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, primitive.M{"_id": objectID, "claim": primitive.M{"$exists": true}, "deleted_at": primitive.M{"$exists": false}}).
+		Return(int64(1), nil).
+		Times(1)
+
+	// Call the RecordClaimFailure method.
+	err := repo.RecordClaimFailure(ctx, objectID.Hex(), 5, 1700000000, 1700000900)
+
+	// Check that the attempt was refused rather than counted.
+	if !errors.Is(err, repository.ErrClaimLocked) {
+		t.Errorf("expected ErrClaimLocked, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_AnswerTransfer_Accepted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	change := &model.OwnershipChange{FromUserID: "seller", ToUserID: "buyer", Outcome: model.TransferAccepted, OfferedAt: 100, AnsweredAt: 200}
+
+	// The owner changes in the same update that removes the transfer and records it.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "user_id": "seller", "transfer.to_user_id": "buyer", "transfer.created_at": int64(100), "deleted_at": primitive.M{"$exists": false}}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, update interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			m := update.(primitive.M)
+			unset, _ := m["$unset"].(primitive.M)
+			if m["$set"].(primitive.M)["user_id"] != "buyer" || unset["shares"] == nil || m["$push"] == nil {
+				t.Errorf("unexpected update %v", update)
+			}
+			return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+		}).
+		Times(1)
+
+	// Call the AnswerTransfer method.
+	if err := repo.AnswerTransfer(ctx, objectID.Hex(), change); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_AnswerTransfer_HistoryWiped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	change := &model.OwnershipChange{FromUserID: "seller", ToUserID: "buyer", Outcome: model.TransferAccepted, HistoryWiped: true, OfferedAt: 100, AnsweredAt: 200}
+
+	// The previous owner's last readings go with the owner change.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, update interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			unset := update.(primitive.M)["$unset"].(primitive.M)
+			set := update.(primitive.M)["$set"].(primitive.M)
+			if set["history_wipe_pending"] != true || set["history_wipe_before"] != int64(200) {
+				t.Errorf("expected the history wipe up to the answer to be pending, got %v", update)
+			}
+			for _, field := range []string{"last_location", "last_fix", "battery_level", "last_telemetry_at", "status_history"} {
+				if _, ok := unset[field]; !ok {
+					t.Errorf("expected %s to be unset, got %v", field, update)
+				}
+			}
+			return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+		}).
+		Times(1)
+
+	// Call the AnswerTransfer method.
+	if err := repo.AnswerTransfer(ctx, objectID.Hex(), change); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_AnswerTransfer_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	change := &model.OwnershipChange{FromUserID: "seller", ToUserID: "buyer", Outcome: model.TransferRejected}
+
+	// Mock the UpdateOne method to match no documents.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	// Call the AnswerTransfer method.
+	err := repo.AnswerTransfer(ctx, primitive.NewObjectID().Hex(), change)
+
+	// Check for the transfer conflict error.
+	if !errors.Is(err, repository.ErrTransferConflict) {
+		t.Errorf("expected ErrTransferConflict, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_ShareDevice_NotOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	share := &model.DeviceShare{UserID: "friend", Role: model.RoleViewer}
+
+	// The share is only written while the device still belongs to the owner.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, primitive.M{"_id": objectID, "user_id": "owner", "deleted_at": primitive.M{"$exists": false}}).
+		Return(int64(0), nil).
+		Times(1)
+
+	// Call the ShareDevice method.
+	err := repo.ShareDevice(ctx, objectID.Hex(), "owner", share, 20, 1700000000)
+
+	// Check for the not found error.
+	if !errors.Is(err, repository.ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_ShareDevice_LimitReached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	share := &model.DeviceShare{UserID: "friend", Role: model.RoleViewer}
+
+	// The shares kept are the active shares with other users, and the filter
+	// limits their number, so a replaced or expired share takes no slot.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter interface{}, update interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			set := update.(primitive.A)[0].(primitive.M)["$set"].(primitive.M)
+			if _, ok := set["version"]; !ok {
+				t.Errorf("expected the share to bump the version, got %v", set)
+			}
+			others := set["shares"].(primitive.M)["$concatArrays"].(primitive.A)[0]
+			limit := primitive.M{"$lt": primitive.A{primitive.M{"$size": others}, 20}}
+			if !reflect.DeepEqual(filter.(primitive.M)["$expr"], limit) {
+				t.Errorf("expected the filter to limit the shares kept, got %v", filter)
+			}
+			return &mongo.UpdateResult{MatchedCount: 0}, nil
+		}).
+		Times(1)
+
+	// The device is still the owner's, so the limit stopped the write.
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, gomock.Any()).
+		Return(int64(1), nil).
+		Times(1)
+
+	// Call the ShareDevice method.
+	err := repo.ShareDevice(ctx, objectID.Hex(), "owner", share, 20, 1700000000)
+
+	// Check for the limit error.
+	if !errors.Is(err, repository.ErrShareLimitReached) {
+		t.Errorf("expected ErrShareLimitReached, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_UnshareDevice_NotShared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the UpdateOne method to match no device shared with the user.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "shares.user_id": "friend", "deleted_at": primitive.M{"$exists": false}}, primitive.M{"$pull": primitive.M{"shares": primitive.M{"user_id": "friend"}}, "$inc": primitive.M{"version": int64(1)}}).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	// Call the UnshareDevice method.
+	err := repo.UnshareDevice(ctx, objectID.Hex(), "friend")
+
+	// Check for the share not found error.
+	if !errors.Is(err, repository.ErrShareNotFound) {
+		t.Errorf("expected ErrShareNotFound, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_AddDevicesToGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	groupID := primitive.NewObjectID().Hex()
+
+	// Only devices of the group owner join the group.
+	mockAdapter.EXPECT().
+		UpdateMany(ctx,
+			primitive.M{"_id": primitive.M{"$in": primitive.A{objectID}}, "user_id": "user123", "deleted_at": primitive.M{"$exists": false}},
+			primitive.M{"$addToSet": primitive.M{"group_ids": groupID}, "$inc": primitive.M{"version": int64(1)}}).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).
+		Times(1)
+
+	// Call the AddDevicesToGroup method.
+	if err := repo.AddDevicesToGroup(ctx, "user123", groupID, []string{objectID.Hex()}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// An invalid device ID is reported on its field without a write.
+	err := repo.AddDevicesToGroup(ctx, "user123", groupID, []string{"invalid"})
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_RemoveDevicesFromGroup_All(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	groupID := primitive.NewObjectID().Hex()
+
+	// Without device IDs every device of the owner leaves the group.
+	mockAdapter.EXPECT().
+		UpdateMany(ctx,
+			primitive.M{"user_id": "user123", "group_ids": groupID},
+			primitive.M{"$pull": primitive.M{"group_ids": groupID}, "$inc": primitive.M{"version": int64(1)}}).
+		Return(&mongo.UpdateResult{MatchedCount: 3, ModifiedCount: 3}, nil).
+		Times(1)
+
+	// Call the RemoveDevicesFromGroup method.
+	if err := repo.RemoveDevicesFromGroup(ctx, "user123", groupID, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_SetDeviceLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Tags are added and labels set one by one, keeping the others.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx,
+			gomock.Any(),
+			primitive.M{
+				"$addToSet": primitive.M{"tags": primitive.M{"$each": []string{"fleet"}}},
+				"$set":      primitive.M{"labels.env": "prod"},
+				"$inc":      primitive.M{"version": int64(1)},
+			}).
+		DoAndReturn(func(_ context.Context, filter interface{}, _ interface{}, _ ...interface{}) (*mongo.UpdateResult, error) {
+			// Both limits are checked by the write itself.
+			if limits := filter.(primitive.M)["$expr"].(primitive.M)["$and"].(primitive.A); len(limits) != 2 {
+				t.Errorf("expected the tag and label limits in the filter, got %v", filter)
+			}
+			return &mongo.UpdateResult{MatchedCount: 0}, nil
+		}).
+		Times(1)
+
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}}).
+		Return(int64(0), nil).
+		Times(1)
+
+	// Call the SetDeviceLabels method.
+	err := repo.SetDeviceLabels(ctx, objectID.Hex(), []string{"fleet"}, map[string]string{"env": "prod"}, 50, 50)
+	if !errors.Is(err, repository.ErrDeviceNotFound) {
+		t.Errorf("expected ErrDeviceNotFound, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_SetDeviceLabels_LimitReached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, gomock.Any(), gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	// The device exists, so the limit stopped the write.
+	mockAdapter.EXPECT().
+		CountDocuments(ctx, gomock.Any()).
+		Return(int64(1), nil).
+		Times(1)
+
+	// Call the SetDeviceLabels method.
+	err := repo.SetDeviceLabels(ctx, objectID.Hex(), []string{"fleet"}, nil, 50, 50)
+	if !errors.Is(err, repository.ErrLabelLimitReached) {
+		t.Errorf("expected ErrLabelLimitReached, got %v", err)
+	}
+}
+
+func TestDeviceMongoRepository_RemoveDeviceLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	mockAdapter.EXPECT().
+		UpdateOne(ctx,
+			primitive.M{"_id": objectID, "deleted_at": primitive.M{"$exists": false}},
+			primitive.M{
+				"$pullAll": primitive.M{"tags": []string{"fleet"}},
+				"$unset":   primitive.M{"labels.env": ""},
+				"$inc":     primitive.M{"version": int64(1)},
+			}).
+		Return(&mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil).
+		Times(1)
+
+	// Call the RemoveDeviceLabels method.
+	if err := repo.RemoveDeviceLabels(ctx, objectID.Hex(), []string{"fleet"}, []string{"env"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrDeviceTypeNotFound is returned when the catalog has no device type with the given ID.
+var ErrDeviceTypeNotFound = &errs.Error{
+	Kind:    errs.NotFound,
+	Reason:  "DEVICE_TYPE_NOT_FOUND",
+	Message: "device type not found",
+	Err:     mongo.ErrNoDocuments,
+}
+
+type DeviceTypeRepository interface {
+	CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error
+	GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error)
+	ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error)
+	UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error
+	DeleteDeviceType(ctx context.Context, id string) error
+}
+
+// DeviceTypeMongoRepository stores the device-type catalog. Device types are
+// keyed by their ID, so the catalog cannot hold the same type twice.
+type DeviceTypeMongoRepository struct {
+	Collection Collection
+}
+
+// NewDeviceTypeMongoRepository returns a new DeviceTypeMongoRepository.
+func NewDeviceTypeMongoRepository(collection Collection) *DeviceTypeMongoRepository {
+	return &DeviceTypeMongoRepository{Collection: collection}
+}
+
+// CreateDeviceType implements DeviceTypeRepository.
+func (r *DeviceTypeMongoRepository) CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	if _, err := r.Collection.InsertOne(ctx, deviceType.ToDeviceTypeDB()); err != nil {
+		return translateDeviceTypeError(err)
+	}
+
+	return nil
+}
+
+// GetDeviceType implements DeviceTypeRepository.
+func (r *DeviceTypeMongoRepository) GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error) {
+	var deviceTypeDB model.DeviceTypeDB
+	if err := r.Collection.FindOne(ctx, primitive.M{"_id": id}).Decode(&deviceTypeDB); err != nil {
+		return nil, translateDeviceTypeError(err)
+	}
+
+	return deviceTypeDB.ToDeviceType(), nil
+}
+
+// ListDeviceTypes implements DeviceTypeRepository.
+// The catalog is small, so it is returned in full, ordered by ID.
+func (r *DeviceTypeMongoRepository) ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error) {
+	findOptions := options.Find().SetSort(primitive.D{{Key: "_id", Value: 1}})
+	cursor, err := r.Collection.Find(ctx, primitive.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceTypesDB []*model.DeviceTypeDB
+	if err = cursor.All(ctx, &deviceTypesDB); err != nil {
+		return nil, err
+	}
+
+	deviceTypes := make([]*model.DeviceType, 0, len(deviceTypesDB))
+	for _, deviceTypeDB := range deviceTypesDB {
+		deviceTypes = append(deviceTypes, deviceTypeDB.ToDeviceType())
+	}
+
+	return deviceTypes, nil
+}
+
+// UpdateDeviceType implements DeviceTypeRepository.
+// The creation time is left untouched.
+func (r *DeviceTypeMongoRepository) UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	deviceTypeDB := deviceType.ToDeviceTypeDB()
+
+	update := primitive.M{"$set": primitive.M{
+		"display_name":          deviceTypeDB.DisplayName,
+		"capabilities":          deviceTypeDB.Capabilities,
+		"serial_number_pattern": deviceTypeDB.SerialNumberPattern,
+		"battery_chemistry":     deviceTypeDB.BatteryChemistry,
+		"reporting_interval":    deviceTypeDB.ReportingInterval,
+		"attributes_schema":     deviceTypeDB.AttributesSchema,
+		"updated_at":            deviceTypeDB.UpdatedAt,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": deviceTypeDB.ID}, update)
+	if err != nil {
+		return translateDeviceTypeError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrDeviceTypeNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeviceType implements DeviceTypeRepository.
+func (r *DeviceTypeMongoRepository) DeleteDeviceType(ctx context.Context, id string) error {
+	result, err := r.Collection.DeleteOne(ctx, primitive.M{"_id": id})
+	if err != nil {
+		return translateDeviceTypeError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrDeviceTypeNotFound
+	}
+
+	return nil
+}
+
+// translateDeviceTypeError converts MongoDB driver errors of catalog queries into typed errors.
+func translateDeviceTypeError(err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrDeviceTypeNotFound
+	case mongo.IsDuplicateKeyError(err):
+		duplicate := errs.Wrap(errs.AlreadyExists, "DEVICE_TYPE_ALREADY_EXISTS", err)
+		duplicate.Message = "a device type with this id already exists"
+		return duplicate.WithMetadata("field", "device_type.id")
+	default:
+		return translateError(err)
+	}
+}
+
+// Ensure DeviceTypeMongoRepository implements DeviceTypeRepository interface
+var _ DeviceTypeRepository = &DeviceTypeMongoRepository{}
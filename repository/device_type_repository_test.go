@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestDeviceTypeMongoRepository_CreateDeviceType_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceTypeMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceType := &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker"}
+
+	// The device type is stored under its ID, which is already taken.
+	mockAdapter.EXPECT().
+		InsertOne(ctx, &model.DeviceTypeDB{ID: "gps-tracker", DisplayName: "GPS Tracker"}).
+		Return(nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}).
+		Times(1)
+
+	// Call the CreateDeviceType method.
+	err := repo.CreateDeviceType(ctx, deviceType)
+
+	// Check for the already exists error.
+	if !errs.Is(err, errs.AlreadyExists) {
+		t.Errorf("expected AlreadyExists error, got %v", err)
+	}
+}
+
+func TestDeviceTypeMongoRepository_GetDeviceType_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewDeviceTypeMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+
+	// Mock the FindOne method to find nothing.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": "gps-tracker"}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetDeviceType method.
+	_, err := repo.GetDeviceType(ctx, "gps-tracker")
+
+	// Check for the device type not found error.
+	if !errors.Is(err, repository.ErrDeviceTypeNotFound) {
+		t.Errorf("expected ErrDeviceTypeNotFound, got %v", err)
+	}
+}
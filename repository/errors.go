@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrDeviceNotFound is returned when no device matches the query.
+var ErrDeviceNotFound = &errs.Error{
+	Kind:    errs.NotFound,
+	Reason:  "DEVICE_NOT_FOUND",
+	Message: "device not found",
+	Err:     mongo.ErrNoDocuments,
+}
+
+// ErrGeofenceNotFound is returned when no geofence matches the query.
+var ErrGeofenceNotFound = &errs.Error{
+	Kind:    errs.NotFound,
+	Reason:  "GEOFENCE_NOT_FOUND",
+	Message: "geofence not found",
+	Err:     mongo.ErrNoDocuments,
+}
+
+// ErrTripNotFound is returned when no trip matches the query.
+var ErrTripNotFound = &errs.Error{
+	Kind:    errs.NotFound,
+	Reason:  "TRIP_NOT_FOUND",
+	Message: "trip not found",
+	Err:     mongo.ErrNoDocuments,
+}
+
+// ErrTripStateConflict is returned by SaveTripState when the detection state
+// of the device was saved by another detection since it was read.
+var ErrTripStateConflict = errs.New(errs.Aborted, "TRIP_STATE_CONFLICT", "trip detection state was changed since it was read")
+
+// ErrGeofenceStateConflict is returned by UpdateGeofenceState when the state of
+// the device was changed since it was read, or the geofence was deleted.
+var ErrGeofenceStateConflict = errs.New(errs.Aborted, "GEOFENCE_STATE_CONFLICT", "geofence state was changed since it was read")
+
+// changeStreamHistoryLost is the server error code for a resume token whose
+// position is no longer in the oplog.
+const changeStreamHistoryLost = 286
+
+// duplicateKey is the server error code for a write that violates a unique index.
+const duplicateKey = 11000
+
+// uniqueDeviceField is a field of devices kept unique by an index.
+type uniqueDeviceField struct {
+	field   string
+	message string
+}
+
+// uniqueDeviceFields maps the names of the unique indexes on devices, created
+// in main.go, to the field each of them keeps unique.
+var uniqueDeviceFields = map[string]uniqueDeviceField{
+	"serial_number_live":    {field: "serial_number", message: "a device with this serial number already exists"},
+	"credential.key_hash_1": {field: "credential.key_hash", message: "a device with this key already exists"},
+}
+
+// parseObjectID converts a hex device ID into an ObjectID, reporting invalid IDs
+// as an InvalidArgument error on the given field.
+func parseObjectID(field, id string) (primitive.ObjectID, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		invalid := errs.InvalidField(field, "must be a 24 character hex ObjectID")
+		invalid.Err = err
+		return primitive.NilObjectID, invalid
+	}
+
+	return objectID, nil
+}
+
+// translateError converts MongoDB driver errors into typed errors.
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrDeviceNotFound
+	case isCommandError(err, changeStreamHistoryLost):
+		return ErrEventsLost
+	case mongo.IsDuplicateKeyError(err):
+		duplicate := errs.Wrap(errs.AlreadyExists, "DEVICE_ALREADY_EXISTS", err)
+		unique, ok := uniqueDeviceFields[duplicateKeyIndex(err)]
+		if !ok {
+			duplicate.Message = "a device with the same unique values already exists"
+			return duplicate
+		}
+		duplicate.Message = unique.message
+		return duplicate.WithMetadata("field", unique.field)
+	default:
+		return err
+	}
+}
+
+// duplicateKeyIndex returns the name of the unique index a duplicate key error
+// violated, which the server only reports in the message, e.g.
+// "E11000 duplicate key error collection: db.devices index: serial_number_live
+// dup key: ...". It returns "" when the message names no index.
+func duplicateKeyIndex(err error) string {
+	const marker = " index: "
+
+	message := err.Error()
+	start := strings.Index(message, marker)
+	if start < 0 {
+		return ""
+	}
+
+	name := message[start+len(marker):]
+	if end := strings.IndexByte(name, ' '); end >= 0 {
+		name = name[:end]
+	}
+	return name
+}
+
+// toDeviceDB converts a device into its database form, reporting an invalid ID
+// as an InvalidArgument error.
+func toDeviceDB(device *model.Device) (*model.DeviceDB, error) {
+	deviceDB, err := device.ToDeviceDB()
+	if err != nil {
+		invalid := errs.InvalidField("device.id", "must be a 24 character hex ObjectID")
+		invalid.Err = err
+		return nil, invalid
+	}
+
+	return deviceDB, nil
+}
+
+// isCommandError reports whether err is a server command error with the given code.
+func isCommandError(err error, code int32) bool {
+	var commandErr mongo.CommandError
+	return errors.As(err, &commandErr) && commandErr.Code == code
+}
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// geofenceEventOrder is the order of geofence events: oldest first.
+var geofenceEventOrder = sortOrder{Field: "time"}
+
+type GeofenceRepository interface {
+	CreateGeofence(ctx context.Context, geofence *model.Geofence) error
+	GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error)
+	GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error)
+	GetGeofencesByDeviceId(ctx context.Context, deviceId string) ([]*model.Geofence, error)
+	UpdateGeofence(ctx context.Context, geofence *model.Geofence) error
+	UpdateGeofenceState(ctx context.Context, id string, deviceId string, previous model.GeofenceState, state model.GeofenceState) error
+	DeleteGeofence(ctx context.Context, id string) error
+	InsertGeofenceEvents(ctx context.Context, events []*model.GeofenceEvent) error
+	ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error)
+}
+
+// GeofenceMongoRepository stores geofences, with the state of each attached
+// device kept on the geofence, and their events in a separate collection.
+type GeofenceMongoRepository struct {
+	Collection      Collection
+	EventCollection Collection
+}
+
+// NewGeofenceMongoRepository returns a new GeofenceMongoRepository.
+func NewGeofenceMongoRepository(collection Collection, eventCollection Collection) *GeofenceMongoRepository {
+	return &GeofenceMongoRepository{Collection: collection, EventCollection: eventCollection}
+}
+
+// CreateGeofence implements GeofenceRepository.
+// A geofence without an ID is assigned a new ObjectID, which is written back to geofence.
+func (r *GeofenceMongoRepository) CreateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	if geofence.ID == "" {
+		geofence.ID = primitive.NewObjectID().Hex()
+	}
+
+	geofenceDB, err := toGeofenceDB(geofence)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Collection.InsertOne(ctx, geofenceDB); err != nil {
+		return translateGeofenceError(err)
+	}
+
+	return nil
+}
+
+// GetGeofenceById implements GeofenceRepository.
+func (r *GeofenceMongoRepository) GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error) {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var geofenceDB model.GeofenceDB
+	if err := r.Collection.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&geofenceDB); err != nil {
+		return nil, translateGeofenceError(err)
+	}
+
+	return geofenceDB.ToGeofence(), nil
+}
+
+// GetGeofencesByUserId implements GeofenceRepository.
+func (r *GeofenceMongoRepository) GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error) {
+	return r.findGeofences(ctx, primitive.M{"user_id": userId})
+}
+
+// GetGeofencesByDeviceId implements GeofenceRepository.
+func (r *GeofenceMongoRepository) GetGeofencesByDeviceId(ctx context.Context, deviceId string) ([]*model.Geofence, error) {
+	return r.findGeofences(ctx, primitive.M{"device_ids": deviceId})
+}
+
+func (r *GeofenceMongoRepository) findGeofences(ctx context.Context, filter primitive.M) ([]*model.Geofence, error) {
+	cursor, err := r.Collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var geofencesDB []*model.GeofenceDB
+	if err = cursor.All(ctx, &geofencesDB); err != nil {
+		return nil, err
+	}
+
+	var geofences []*model.Geofence
+	for _, geofenceDB := range geofencesDB {
+		geofences = append(geofences, geofenceDB.ToGeofence())
+	}
+
+	return geofences, nil
+}
+
+// UpdateGeofence implements GeofenceRepository.
+// The owner, creation time and device states are left untouched.
+func (r *GeofenceMongoRepository) UpdateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	geofenceDB, err := toGeofenceDB(geofence)
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"name":          geofenceDB.Name,
+		"shape":         geofenceDB.Shape,
+		"center":        geofenceDB.Center,
+		"radius":        geofenceDB.Radius,
+		"vertices":      geofenceDB.Vertices,
+		"device_ids":    geofenceDB.DeviceIDs,
+		"dwell_seconds": geofenceDB.DwellSeconds,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": geofenceDB.ID}, update)
+	if err != nil {
+		return translateGeofenceError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+// UpdateGeofenceState implements GeofenceRepository.
+// The state of the device is only replaced while it is still previous; the
+// zero previous state matches a device without a state.
+func (r *GeofenceMongoRepository) UpdateGeofenceState(ctx context.Context, id string, deviceId string, previous model.GeofenceState, state model.GeofenceState) error {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := primitive.ObjectIDFromHex(deviceId); err != nil {
+		return errs.InvalidField("device_id", "must be a 24 character hex ObjectID")
+	}
+
+	filter := primitive.M{"_id": objectID, "states." + deviceId: previous}
+	if previous == (model.GeofenceState{}) {
+		filter["states."+deviceId] = primitive.M{"$exists": false}
+	}
+
+	result, err := r.Collection.UpdateOne(ctx, filter, primitive.M{"$set": primitive.M{"states." + deviceId: state}})
+	if err != nil {
+		return translateGeofenceError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrGeofenceStateConflict
+	}
+
+	return nil
+}
+
+// DeleteGeofence implements GeofenceRepository.
+// The events of the geofence are kept.
+func (r *GeofenceMongoRepository) DeleteGeofence(ctx context.Context, id string) error {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Collection.DeleteOne(ctx, primitive.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrGeofenceNotFound
+	}
+
+	return nil
+}
+
+// InsertGeofenceEvents implements GeofenceRepository.
+func (r *GeofenceMongoRepository) InsertGeofenceEvents(ctx context.Context, events []*model.GeofenceEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		eventDB, err := event.ToGeofenceEventDB()
+		if err != nil {
+			invalid := errs.InvalidField("event", "geofence_id and device_id must be 24 character hex ObjectIDs")
+			invalid.Err = err
+			return invalid
+		}
+		documents = append(documents, eventDB)
+	}
+
+	if _, err := r.EventCollection.InsertMany(ctx, documents); err != nil {
+		return translateGeofenceError(err)
+	}
+
+	return nil
+}
+
+// ListGeofenceEvents implements GeofenceRepository.
+// Pages are read with keyset pagination on time, oldest first.
+func (r *GeofenceMongoRepository) ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error) {
+	geofenceID, err := parseObjectID("geofence_id", query.GeofenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := primitive.M{"geofence_id": geofenceID}
+	if query.DeviceID != "" {
+		deviceID, err := parseObjectID("device_id", query.DeviceID)
+		if err != nil {
+			return nil, err
+		}
+		filter["device_id"] = deviceID
+	}
+
+	eventTime := primitive.M{}
+	if query.From != 0 {
+		eventTime["$gte"] = query.From
+	}
+	if query.To != 0 {
+		eventTime["$lt"] = query.To
+	}
+	if len(eventTime) > 0 {
+		filter["time"] = eventTime
+	}
+
+	if query.PageToken != "" {
+		cursor, err := decodePageToken(query.PageToken, geofenceEventOrder)
+		if err != nil {
+			return nil, err
+		}
+		filter = primitive.M{"$and": primitive.A{filter, geofenceEventOrder.after(cursor)}}
+	}
+
+	// Fetch one extra event to find out whether another page follows.
+	findOptions := options.Find().SetSort(geofenceEventOrder.sort()).SetLimit(int64(size + 1))
+	cursor, err := r.EventCollection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventsDB []*model.GeofenceEventDB
+	if err = cursor.All(ctx, &eventsDB); err != nil {
+		return nil, err
+	}
+
+	page := &model.GeofenceEventPage{}
+	if len(eventsDB) > size {
+		eventsDB = eventsDB[:size]
+		last := eventsDB[size-1]
+		page.NextPageToken, err = encodePageToken(&pageCursor{Order: geofenceEventOrder, Value: last.Time, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, eventDB := range eventsDB {
+		page.Events = append(page.Events, eventDB.ToGeofenceEvent())
+	}
+
+	return page, nil
+}
+
+// toGeofenceDB converts a geofence into its database form, reporting an invalid
+// ID as an InvalidArgument error.
+func toGeofenceDB(geofence *model.Geofence) (*model.GeofenceDB, error) {
+	geofenceDB, err := geofence.ToGeofenceDB()
+	if err != nil {
+		invalid := errs.InvalidField("geofence.id", "must be a 24 character hex ObjectID")
+		invalid.Err = err
+		return nil, invalid
+	}
+
+	return geofenceDB, nil
+}
+
+// translateGeofenceError converts MongoDB driver errors of geofence queries into typed errors.
+func translateGeofenceError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrGeofenceNotFound
+	}
+
+	return translateError(err)
+}
+
+// DeleteDeviceHistory implements DeviceHistory.
+// Only the events of the device are deleted; the geofences it is attached to
+// belong to their users and are left as they are.
+func (r *GeofenceMongoRepository) DeleteDeviceHistory(ctx context.Context, deviceId string, before int64) error {
+	return deleteByDevice(ctx, r.EventCollection, deviceId, "time", historyCutoff(before))
+}
+
+// Ensure GeofenceMongoRepository implements GeofenceRepository interface
+var _ GeofenceRepository = &GeofenceMongoRepository{}
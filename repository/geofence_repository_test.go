@@ -0,0 +1,214 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGeofenceMongoRepository_CreateGeofence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	fence := &model.Geofence{UserID: "user123", Name: "Home", Shape: model.GeofenceCircle, Center: &model.LatLng{Latitude: 1, Longitude: 2}, Radius: 100}
+
+	// Mock the InsertOne method.
+	mockAdapter.EXPECT().
+		InsertOne(ctx, gomock.Any()).
+		Return(&mongo.InsertOneResult{}, nil).
+		Times(1)
+
+	// Call the CreateGeofence method.
+	err := repo.CreateGeofence(ctx, fence)
+
+	// Check that an ID was assigned.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := primitive.ObjectIDFromHex(fence.ID); err != nil {
+		t.Errorf("expected an ObjectID to be assigned, got %q", fence.ID)
+	}
+}
+
+func TestGeofenceMongoRepository_GetGeofenceById_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the FindOne method to find nothing.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": objectID}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetGeofenceById method.
+	_, err := repo.GetGeofenceById(ctx, objectID.Hex())
+
+	// Check for the geofence not found error.
+	if !errors.Is(err, repository.ErrGeofenceNotFound) {
+		t.Errorf("expected ErrGeofenceNotFound, got %v", err)
+	}
+}
+
+func TestGeofenceMongoRepository_GetGeofencesByDeviceId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID().Hex()
+	state := model.GeofenceState{Inside: true, Since: 100, UpdatedAt: 200}
+
+	// Geofences are matched on their list of device IDs.
+	mockAdapter.EXPECT().
+		Find(ctx, primitive.M{"device_ids": deviceID}).
+		Return(mockCursor, nil).
+		Times(1)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.GeofenceDB) = []*model.GeofenceDB{
+				{ID: primitive.NewObjectID(), DeviceIDs: []string{deviceID}, States: map[string]model.GeofenceState{deviceID: state}},
+			}
+			return nil
+		}).
+		Times(1)
+
+	// Call the GetGeofencesByDeviceId method.
+	fences, err := repo.GetGeofencesByDeviceId(ctx, deviceID)
+
+	// Check the returned geofences carry the device state.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(fences) != 1 || fences[0].States[deviceID] != state {
+		t.Errorf("unexpected geofences %+v", fences)
+	}
+}
+
+func TestGeofenceMongoRepository_UpdateGeofenceState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	deviceID := primitive.NewObjectID().Hex()
+	previous := model.GeofenceState{UpdatedAt: 50}
+	state := model.GeofenceState{Inside: true, Since: 100, UpdatedAt: 100}
+
+	// Only the state of the one device is written, while it is still the previous one.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "states." + deviceID: previous}, primitive.M{"$set": primitive.M{"states." + deviceID: state}}).
+		Return(&mongo.UpdateResult{MatchedCount: 1}, nil).
+		Times(1)
+
+	// Call the UpdateGeofenceState method.
+	if err := repo.UpdateGeofenceState(ctx, objectID.Hex(), deviceID, previous, state); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// A device ID that is not an ObjectID could inject a field path.
+	if err := repo.UpdateGeofenceState(ctx, objectID.Hex(), "x.y", previous, state); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestGeofenceMongoRepository_UpdateGeofenceState_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+	deviceID := primitive.NewObjectID().Hex()
+
+	// The first state is only written while the device has none.
+	mockAdapter.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": objectID, "states." + deviceID: primitive.M{"$exists": false}}, gomock.Any()).
+		Return(&mongo.UpdateResult{MatchedCount: 0}, nil).
+		Times(1)
+
+	// Call the UpdateGeofenceState method.
+	err := repo.UpdateGeofenceState(ctx, objectID.Hex(), deviceID, model.GeofenceState{}, model.GeofenceState{Inside: true, UpdatedAt: 100})
+
+	// Check for the conflict error.
+	if !errors.Is(err, repository.ErrGeofenceStateConflict) {
+		t.Errorf("expected ErrGeofenceStateConflict, got %v", err)
+	}
+}
+
+func TestGeofenceMongoRepository_ListGeofenceEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEvents := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewGeofenceMongoRepository(mock_repository.NewMockCollection(ctrl), mockEvents)
+
+	ctx := context.Background()
+	geofenceID := primitive.NewObjectID()
+	deviceID := primitive.NewObjectID()
+
+	// Events are filtered by geofence and device.
+	mockEvents.EXPECT().
+		Find(ctx, primitive.M{"geofence_id": geofenceID, "device_id": deviceID}, gomock.Any()).
+		Return(mockCursor, nil).
+		Times(1)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.GeofenceEventDB) = []*model.GeofenceEventDB{
+				{ID: primitive.NewObjectID(), GeofenceID: geofenceID, DeviceID: deviceID, Type: model.GeofenceEnter, Time: 100},
+			}
+			return nil
+		}).
+		Times(1)
+
+	// Call the ListGeofenceEvents method.
+	page, err := repo.ListGeofenceEvents(ctx, &model.GeofenceEventQuery{GeofenceID: geofenceID.Hex(), DeviceID: deviceID.Hex()})
+
+	// Check the returned page.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(page.Events) != 1 || page.Events[0].Type != model.GeofenceEnter || page.NextPageToken != "" {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrGroupNotFound is returned when no device group matches the query.
+var ErrGroupNotFound = &errs.Error{
+	Kind:    errs.NotFound,
+	Reason:  "DEVICE_GROUP_NOT_FOUND",
+	Message: "device group not found",
+	Err:     mongo.ErrNoDocuments,
+}
+
+type GroupRepository interface {
+	CreateGroup(ctx context.Context, group *model.DeviceGroup) error
+	GetGroupById(ctx context.Context, id string) (*model.DeviceGroup, error)
+	GetGroupsByUserId(ctx context.Context, userId string) ([]*model.DeviceGroup, error)
+	UpdateGroup(ctx context.Context, group *model.DeviceGroup) error
+	DeleteGroup(ctx context.Context, id string) error
+}
+
+// GroupMongoRepository stores device groups. The devices of a group are
+// recorded on the devices themselves, see DeviceRepository.AddDevicesToGroup.
+type GroupMongoRepository struct {
+	Collection Collection
+}
+
+// NewGroupMongoRepository returns a new GroupMongoRepository.
+func NewGroupMongoRepository(collection Collection) *GroupMongoRepository {
+	return &GroupMongoRepository{Collection: collection}
+}
+
+// CreateGroup implements GroupRepository.
+// A group without an ID is assigned a new ObjectID, which is written back to group.
+func (r *GroupMongoRepository) CreateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	if group.ID == "" {
+		group.ID = primitive.NewObjectID().Hex()
+	}
+
+	groupDB, err := toDeviceGroupDB(group)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Collection.InsertOne(ctx, groupDB); err != nil {
+		return translateGroupError(err)
+	}
+
+	return nil
+}
+
+// GetGroupById implements GroupRepository.
+func (r *GroupMongoRepository) GetGroupById(ctx context.Context, id string) (*model.DeviceGroup, error) {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupDB model.DeviceGroupDB
+	if err := r.Collection.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&groupDB); err != nil {
+		return nil, translateGroupError(err)
+	}
+
+	return groupDB.ToDeviceGroup(), nil
+}
+
+// GetGroupsByUserId implements GroupRepository.
+// The groups are ordered by name.
+func (r *GroupMongoRepository) GetGroupsByUserId(ctx context.Context, userId string) ([]*model.DeviceGroup, error) {
+	findOptions := options.Find().SetSort(primitive.D{{Key: "name", Value: 1}})
+	cursor, err := r.Collection.Find(ctx, primitive.M{"user_id": userId}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupsDB []*model.DeviceGroupDB
+	if err = cursor.All(ctx, &groupsDB); err != nil {
+		return nil, err
+	}
+
+	groups := make([]*model.DeviceGroup, 0, len(groupsDB))
+	for _, groupDB := range groupsDB {
+		groups = append(groups, groupDB.ToDeviceGroup())
+	}
+
+	return groups, nil
+}
+
+// UpdateGroup implements GroupRepository.
+// The owner and creation time are left untouched.
+func (r *GroupMongoRepository) UpdateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	groupDB, err := toDeviceGroupDB(group)
+	if err != nil {
+		return err
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"name":        groupDB.Name,
+		"description": groupDB.Description,
+	}}
+
+	result, err := r.Collection.UpdateOne(ctx, primitive.M{"_id": groupDB.ID}, update)
+	if err != nil {
+		return translateGroupError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrGroupNotFound
+	}
+
+	return nil
+}
+
+// DeleteGroup implements GroupRepository.
+func (r *GroupMongoRepository) DeleteGroup(ctx context.Context, id string) error {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Collection.DeleteOne(ctx, primitive.M{"_id": objectID})
+	if err != nil {
+		return translateGroupError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return ErrGroupNotFound
+	}
+
+	return nil
+}
+
+// toDeviceGroupDB converts a group into its database form, reporting an invalid
+// ID as an InvalidArgument error.
+func toDeviceGroupDB(group *model.DeviceGroup) (*model.DeviceGroupDB, error) {
+	groupDB, err := group.ToDeviceGroupDB()
+	if err != nil {
+		invalid := errs.InvalidField("group.id", "must be a 24 character hex ObjectID")
+		invalid.Err = err
+		return nil, invalid
+	}
+
+	return groupDB, nil
+}
+
+// translateGroupError converts MongoDB driver errors of group queries into typed errors.
+// Group names are unique per user.
+func translateGroupError(err error) error {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return ErrGroupNotFound
+	case mongo.IsDuplicateKeyError(err):
+		duplicate := errs.Wrap(errs.AlreadyExists, "DEVICE_GROUP_ALREADY_EXISTS", err)
+		duplicate.Message = "a device group with this name already exists"
+		return duplicate.WithMetadata("field", "group.name")
+	default:
+		return translateError(err)
+	}
+}
+
+// Ensure GroupMongoRepository implements GroupRepository interface
+var _ GroupRepository = &GroupMongoRepository{}
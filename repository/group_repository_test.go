@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGroupMongoRepository_CreateGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewGroupMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	group := &model.DeviceGroup{UserID: "user123", Name: "Vans"}
+
+	// Mock the InsertOne method.
+	mockAdapter.EXPECT().
+		InsertOne(ctx, gomock.Any()).
+		Return(&mongo.InsertOneResult{}, nil).
+		Times(1)
+
+	// Call the CreateGroup method.
+	err := repo.CreateGroup(ctx, group)
+
+	// Check that an ID was assigned.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := primitive.ObjectIDFromHex(group.ID); err != nil {
+		t.Errorf("expected an ObjectID to be assigned, got %q", group.ID)
+	}
+}
+
+func TestGroupMongoRepository_CreateGroup_DuplicateName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewGroupMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+
+	// Mock the InsertOne method to violate the unique name index.
+	mockAdapter.EXPECT().
+		InsertOne(ctx, gomock.Any()).
+		Return(nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}).
+		Times(1)
+
+	// Call the CreateGroup method.
+	err := repo.CreateGroup(ctx, &model.DeviceGroup{UserID: "user123", Name: "Vans"})
+
+	// Check for the already exists error.
+	if !errs.Is(err, errs.AlreadyExists) {
+		t.Errorf("expected AlreadyExists error, got %v", err)
+	}
+}
+
+func TestGroupMongoRepository_GetGroupById_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewGroupMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the FindOne method to find nothing.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": objectID}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetGroupById method.
+	_, err := repo.GetGroupById(ctx, objectID.Hex())
+
+	// Check for the group not found error.
+	if !errors.Is(err, repository.ErrGroupNotFound) {
+		t.Errorf("expected ErrGroupNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/BerryTracer/device-service/selector"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// labelFilter translates a label selector into Mongo conditions on the labels
+// of a device, one per requirement, so that requirements on the same key all apply.
+func labelFilter(input string) (primitive.A, error) {
+	sel, err := parseLabelSelector(input)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make(primitive.A, 0, len(sel))
+	for _, requirement := range sel {
+		field := "labels." + requirement.Key
+
+		var condition interface{}
+		switch requirement.Operator {
+		case selector.Equals:
+			condition = requirement.Values[0]
+		case selector.NotEquals:
+			condition = primitive.M{"$ne": requirement.Values[0]}
+		case selector.In:
+			condition = primitive.M{"$in": requirement.Values}
+		case selector.NotIn:
+			condition = primitive.M{"$nin": requirement.Values}
+		case selector.Exists:
+			condition = primitive.M{"$exists": true}
+		case selector.DoesNotExist:
+			condition = primitive.M{"$exists": false}
+		}
+
+		conditions = append(conditions, primitive.M{field: condition})
+	}
+
+	return conditions, nil
+}
+
+// parseLabelSelector parses a label selector, reporting syntax errors as an
+// InvalidArgument error on the filter.
+func parseLabelSelector(input string) (selector.Selector, error) {
+	sel, err := selector.Parse(input)
+	if err != nil {
+		invalid := errs.InvalidField("filter.label_selector", err.Error())
+		invalid.Err = err
+		return nil, invalid
+	}
+
+	return sel, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// locationOrder is the order of a device's location history: oldest first.
+var locationOrder = sortOrder{Field: "timestamp"}
+
+type LocationRepository interface {
+	InsertLocations(ctx context.Context, locations []*model.Location) error
+	GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error)
+}
+
+// LocationMongoRepository stores the location history of devices, indexed by
+// device_id and timestamp.
+type LocationMongoRepository struct {
+	Collection Collection
+}
+
+// NewLocationMongoRepository returns a new LocationMongoRepository.
+func NewLocationMongoRepository(collection Collection) *LocationMongoRepository {
+	return &LocationMongoRepository{Collection: collection}
+}
+
+// InsertLocations implements LocationRepository.
+func (r *LocationMongoRepository) InsertLocations(ctx context.Context, locations []*model.Location) error {
+	if len(locations) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(locations))
+	for _, location := range locations {
+		locationDB, err := location.ToLocationDB()
+		if err != nil {
+			invalid := errs.InvalidField("device_id", "must be a 24 character hex ObjectID")
+			invalid.Err = err
+			return invalid
+		}
+		documents = append(documents, locationDB)
+	}
+
+	if _, err := r.Collection.InsertMany(ctx, documents); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// GetLocationHistory implements LocationRepository.
+// Pages are read with keyset pagination on timestamp, oldest first.
+func (r *LocationMongoRepository) GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error) {
+	deviceID, err := parseObjectID("device_id", query.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := primitive.M{"device_id": deviceID}
+	timestamp := primitive.M{}
+	if query.From != 0 {
+		timestamp["$gte"] = query.From
+	}
+	if query.To != 0 {
+		timestamp["$lt"] = query.To
+	}
+	if len(timestamp) > 0 {
+		filter["timestamp"] = timestamp
+	}
+
+	if query.PageToken != "" {
+		cursor, err := decodePageToken(query.PageToken, locationOrder)
+		if err != nil {
+			return nil, err
+		}
+		filter = primitive.M{"$and": primitive.A{filter, locationOrder.after(cursor)}}
+	}
+
+	// Fetch one extra location to find out whether another page follows.
+	findOptions := options.Find().SetSort(locationOrder.sort()).SetLimit(int64(size + 1))
+	cursor, err := r.Collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var locationsDB []*model.LocationDB
+	if err = cursor.All(ctx, &locationsDB); err != nil {
+		return nil, err
+	}
+
+	page := &model.LocationPage{}
+	if len(locationsDB) > size {
+		locationsDB = locationsDB[:size]
+		last := locationsDB[size-1]
+		page.NextPageToken, err = encodePageToken(&pageCursor{Order: locationOrder, Value: last.Timestamp, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, locationDB := range locationsDB {
+		page.Locations = append(page.Locations, locationDB.ToLocation())
+	}
+
+	return page, nil
+}
+
+// DeleteDeviceHistory implements DeviceHistory.
+func (r *LocationMongoRepository) DeleteDeviceHistory(ctx context.Context, deviceId string, before int64) error {
+	return deleteByDevice(ctx, r.Collection, deviceId, "timestamp", historyCutoff(before))
+}
+
+// Ensure LocationMongoRepository implements LocationRepository interface
+var _ LocationRepository = &LocationMongoRepository{}
@@ -0,0 +1,145 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestLocationMongoRepository_InsertLocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewLocationMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	locations := []*model.Location{{DeviceID: deviceID.Hex(), Latitude: 52.52, Longitude: 13.40, Timestamp: 1700000000}}
+
+	// Locations are stored as GeoJSON points with longitude first.
+	mockAdapter.EXPECT().
+		InsertMany(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, documents []interface{}, _ ...interface{}) (*mongo.InsertManyResult, error) {
+			locationDB := documents[0].(*model.LocationDB)
+			if locationDB.DeviceID != deviceID || locationDB.Point.Type != "Point" || locationDB.Point.Coordinates[0] != 13.40 {
+				t.Errorf("unexpected document %+v", locationDB)
+			}
+			return &mongo.InsertManyResult{}, nil
+		}).
+		Times(1)
+
+	// Call the InsertLocations method.
+	err := repo.InsertLocations(ctx, locations)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestLocationMongoRepository_GetLocationHistory_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewLocationMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	locationsDB := []*model.LocationDB{
+		{ID: primitive.NewObjectID(), DeviceID: deviceID, Point: model.NewGeoPoint(1, 2), LocationFix: model.LocationFix{Timestamp: 100}},
+		{ID: primitive.NewObjectID(), DeviceID: deviceID, Point: model.NewGeoPoint(3, 4), LocationFix: model.LocationFix{Timestamp: 200}},
+		{ID: primitive.NewObjectID(), DeviceID: deviceID, Point: model.NewGeoPoint(5, 6), LocationFix: model.LocationFix{Timestamp: 300}},
+	}
+
+	// The first page returns one location more than requested.
+	var filters []interface{}
+	mockAdapter.EXPECT().
+		Find(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter interface{}, _ ...interface{}) (*mock.MockCursor, error) {
+			filters = append(filters, filter)
+			return mockCursor, nil
+		}).
+		Times(2)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.LocationDB) = locationsDB
+			return nil
+		}).
+		Times(1)
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.LocationDB) = locationsDB[2:]
+			return nil
+		}).
+		Times(1)
+
+	// Call the GetLocationHistory method for both pages.
+	query := &model.LocationHistoryQuery{DeviceID: deviceID.Hex(), From: 50, PageSize: 2}
+	first, err := repo.GetLocationHistory(ctx, query)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(first.Locations) != 2 || first.NextPageToken == "" || first.Locations[1].Latitude != 3 {
+		t.Fatalf("unexpected first page %+v", first)
+	}
+
+	query.PageToken = first.NextPageToken
+	second, err := repo.GetLocationHistory(ctx, query)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(second.Locations) != 1 || second.NextPageToken != "" {
+		t.Errorf("unexpected second page %+v", second)
+	}
+
+	// The second page continues after the last location of the first.
+	and, ok := filters[1].(primitive.M)["$and"].(primitive.A)
+	if !ok || len(and) != 2 {
+		t.Fatalf("expected the page filter to be combined with the query, got %v", filters[1])
+	}
+
+	if and[0].(primitive.M)["timestamp"].(primitive.M)["$gte"] != int64(50) {
+		t.Errorf("expected the time range in the filter, got %v", and[0])
+	}
+}
+
+func TestLocationMongoRepository_GetLocationHistory_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewLocationMongoRepository(mockAdapter)
+
+	deviceID := primitive.NewObjectID().Hex()
+	queries := []*model.LocationHistoryQuery{
+		{DeviceID: "invalid"},
+		{DeviceID: deviceID, PageSize: -1},
+		{DeviceID: deviceID, PageToken: "not a token"},
+	}
+
+	for _, query := range queries {
+		// Call the GetLocationHistory method with an invalid query.
+		_, err := repo.GetLocationHistory(context.Background(), query)
+
+		// Check for an invalid argument error.
+		if !errs.Is(err, errs.InvalidArgument) {
+			t.Errorf("expected InvalidArgument error for %+v, got %v", query, err)
+		}
+	}
+}
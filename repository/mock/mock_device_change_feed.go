@@ -0,0 +1,198 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/device_change_feed.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	repository "github.com/BerryTracer/device-service/repository"
+	gomock "github.com/golang/mock/gomock"
+	bson "go.mongodb.org/mongo-driver/bson"
+)
+
+// MockDeviceChangeFeed is a mock of DeviceChangeFeed interface.
+type MockDeviceChangeFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceChangeFeedMockRecorder
+}
+
+// MockDeviceChangeFeedMockRecorder is the mock recorder for MockDeviceChangeFeed.
+type MockDeviceChangeFeedMockRecorder struct {
+	mock *MockDeviceChangeFeed
+}
+
+// NewMockDeviceChangeFeed creates a new mock instance.
+func NewMockDeviceChangeFeed(ctrl *gomock.Controller) *MockDeviceChangeFeed {
+	mock := &MockDeviceChangeFeed{ctrl: ctrl}
+	mock.recorder = &MockDeviceChangeFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceChangeFeed) EXPECT() *MockDeviceChangeFeedMockRecorder {
+	return m.recorder
+}
+
+// WatchDevices mocks base method.
+func (m *MockDeviceChangeFeed) WatchDevices(ctx context.Context, userID, groupID, resumeToken string) (repository.DeviceEventStream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchDevices", ctx, userID, groupID, resumeToken)
+	ret0, _ := ret[0].(repository.DeviceEventStream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchDevices indicates an expected call of WatchDevices.
+func (mr *MockDeviceChangeFeedMockRecorder) WatchDevices(ctx, userID, groupID, resumeToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchDevices", reflect.TypeOf((*MockDeviceChangeFeed)(nil).WatchDevices), ctx, userID, groupID, resumeToken)
+}
+
+// MockDeviceEventStream is a mock of DeviceEventStream interface.
+type MockDeviceEventStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceEventStreamMockRecorder
+}
+
+// MockDeviceEventStreamMockRecorder is the mock recorder for MockDeviceEventStream.
+type MockDeviceEventStreamMockRecorder struct {
+	mock *MockDeviceEventStream
+}
+
+// NewMockDeviceEventStream creates a new mock instance.
+func NewMockDeviceEventStream(ctrl *gomock.Controller) *MockDeviceEventStream {
+	mock := &MockDeviceEventStream{ctrl: ctrl}
+	mock.recorder = &MockDeviceEventStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceEventStream) EXPECT() *MockDeviceEventStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockDeviceEventStream) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDeviceEventStreamMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDeviceEventStream)(nil).Close), ctx)
+}
+
+// Next mocks base method.
+func (m *MockDeviceEventStream) Next(ctx context.Context) (*model.DeviceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx)
+	ret0, _ := ret[0].(*model.DeviceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockDeviceEventStreamMockRecorder) Next(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockDeviceEventStream)(nil).Next), ctx)
+}
+
+// MockChangeStream is a mock of ChangeStream interface.
+type MockChangeStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeStreamMockRecorder
+}
+
+// MockChangeStreamMockRecorder is the mock recorder for MockChangeStream.
+type MockChangeStreamMockRecorder struct {
+	mock *MockChangeStream
+}
+
+// NewMockChangeStream creates a new mock instance.
+func NewMockChangeStream(ctrl *gomock.Controller) *MockChangeStream {
+	mock := &MockChangeStream{ctrl: ctrl}
+	mock.recorder = &MockChangeStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeStream) EXPECT() *MockChangeStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockChangeStream) Close(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockChangeStreamMockRecorder) Close(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockChangeStream)(nil).Close), ctx)
+}
+
+// Decode mocks base method.
+func (m *MockChangeStream) Decode(val interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decode", val)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decode indicates an expected call of Decode.
+func (mr *MockChangeStreamMockRecorder) Decode(val interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decode", reflect.TypeOf((*MockChangeStream)(nil).Decode), val)
+}
+
+// Err mocks base method.
+func (m *MockChangeStream) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockChangeStreamMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockChangeStream)(nil).Err))
+}
+
+// Next mocks base method.
+func (m *MockChangeStream) Next(ctx context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockChangeStreamMockRecorder) Next(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockChangeStream)(nil).Next), ctx)
+}
+
+// ResumeToken mocks base method.
+func (m *MockChangeStream) ResumeToken() bson.Raw {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeToken")
+	ret0, _ := ret[0].(bson.Raw)
+	return ret0
+}
+
+// ResumeToken indicates an expected call of ResumeToken.
+func (mr *MockChangeStreamMockRecorder) ResumeToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeToken", reflect.TypeOf((*MockChangeStream)(nil).ResumeToken))
+}
@@ -35,6 +35,92 @@ func (m *MockDeviceRepository) EXPECT() *MockDeviceRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddDevicesToGroup mocks base method.
+func (m *MockDeviceRepository) AddDevicesToGroup(ctx context.Context, userId, groupId string, deviceIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDevicesToGroup", ctx, userId, groupId, deviceIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDevicesToGroup indicates an expected call of AddDevicesToGroup.
+func (mr *MockDeviceRepositoryMockRecorder) AddDevicesToGroup(ctx, userId, groupId, deviceIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDevicesToGroup", reflect.TypeOf((*MockDeviceRepository)(nil).AddDevicesToGroup), ctx, userId, groupId, deviceIds)
+}
+
+// AnswerTransfer mocks base method.
+func (m *MockDeviceRepository) AnswerTransfer(ctx context.Context, id string, change *model.OwnershipChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerTransfer", ctx, id, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AnswerTransfer indicates an expected call of AnswerTransfer.
+func (mr *MockDeviceRepositoryMockRecorder) AnswerTransfer(ctx, id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerTransfer", reflect.TypeOf((*MockDeviceRepository)(nil).AnswerTransfer), ctx, id, change)
+}
+
+// ChangeDeviceStatus mocks base method.
+func (m *MockDeviceRepository) ChangeDeviceStatus(ctx context.Context, id string, change *model.StatusChange, version int64) (*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeDeviceStatus", ctx, id, change, version)
+	ret0, _ := ret[0].(*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeDeviceStatus indicates an expected call of ChangeDeviceStatus.
+func (mr *MockDeviceRepositoryMockRecorder) ChangeDeviceStatus(ctx, id, change, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeDeviceStatus", reflect.TypeOf((*MockDeviceRepository)(nil).ChangeDeviceStatus), ctx, id, change, version)
+}
+
+// ClaimDevice mocks base method.
+func (m *MockDeviceRepository) ClaimDevice(ctx context.Context, id, codeHash, userId string, claimedAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDevice", ctx, id, codeHash, userId, claimedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimDevice indicates an expected call of ClaimDevice.
+func (mr *MockDeviceRepositoryMockRecorder) ClaimDevice(ctx, id, codeHash, userId, claimedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDevice", reflect.TypeOf((*MockDeviceRepository)(nil).ClaimDevice), ctx, id, codeHash, userId, claimedAt)
+}
+
+// CompleteHistoryWipe mocks base method.
+func (m *MockDeviceRepository) CompleteHistoryWipe(ctx context.Context, id string, before int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteHistoryWipe", ctx, id, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteHistoryWipe indicates an expected call of CompleteHistoryWipe.
+func (mr *MockDeviceRepositoryMockRecorder) CompleteHistoryWipe(ctx, id, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteHistoryWipe", reflect.TypeOf((*MockDeviceRepository)(nil).CompleteHistoryWipe), ctx, id, before)
+}
+
+// CountDevicesByType mocks base method.
+func (m *MockDeviceRepository) CountDevicesByType(ctx context.Context, deviceType string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDevicesByType", ctx, deviceType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDevicesByType indicates an expected call of CountDevicesByType.
+func (mr *MockDeviceRepositoryMockRecorder) CountDevicesByType(ctx, deviceType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDevicesByType", reflect.TypeOf((*MockDeviceRepository)(nil).CountDevicesByType), ctx, deviceType)
+}
+
 // CreateDevice mocks base method.
 func (m *MockDeviceRepository) CreateDevice(ctx context.Context, device *model.Device) error {
 	m.ctrl.T.Helper()
@@ -49,6 +135,80 @@ func (mr *MockDeviceRepositoryMockRecorder) CreateDevice(ctx, device interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevice", reflect.TypeOf((*MockDeviceRepository)(nil).CreateDevice), ctx, device)
 }
 
+// CreateDevices mocks base method.
+func (m *MockDeviceRepository) CreateDevices(ctx context.Context, devices []*model.Device) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDevices", ctx, devices)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDevices indicates an expected call of CreateDevices.
+func (mr *MockDeviceRepositoryMockRecorder) CreateDevices(ctx, devices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDevices", reflect.TypeOf((*MockDeviceRepository)(nil).CreateDevices), ctx, devices)
+}
+
+// DeleteDevice mocks base method.
+func (m *MockDeviceRepository) DeleteDevice(ctx context.Context, id, deletedBy string, deletedAt, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDevice", ctx, id, deletedBy, deletedAt, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDevice indicates an expected call of DeleteDevice.
+func (mr *MockDeviceRepositoryMockRecorder) DeleteDevice(ctx, id, deletedBy, deletedAt, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDevice", reflect.TypeOf((*MockDeviceRepository)(nil).DeleteDevice), ctx, id, deletedBy, deletedAt, version)
+}
+
+// FindDevicesNear mocks base method.
+func (m *MockDeviceRepository) FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDevicesNear", ctx, query)
+	ret0, _ := ret[0].([]*model.NearbyDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDevicesNear indicates an expected call of FindDevicesNear.
+func (mr *MockDeviceRepositoryMockRecorder) FindDevicesNear(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDevicesNear", reflect.TypeOf((*MockDeviceRepository)(nil).FindDevicesNear), ctx, query)
+}
+
+// GetDeletedDeviceById mocks base method.
+func (m *MockDeviceRepository) GetDeletedDeviceById(ctx context.Context, id string) (*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedDeviceById", ctx, id)
+	ret0, _ := ret[0].(*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedDeviceById indicates an expected call of GetDeletedDeviceById.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeletedDeviceById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedDeviceById", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeletedDeviceById), ctx, id)
+}
+
+// GetDeletedDevices mocks base method.
+func (m *MockDeviceRepository) GetDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedDevices", ctx, userId)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedDevices indicates an expected call of GetDeletedDevices.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeletedDevices(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedDevices", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeletedDevices), ctx, userId)
+}
+
 // GetDeviceById mocks base method.
 func (m *MockDeviceRepository) GetDeviceById(ctx context.Context, id string) (*model.Device, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +224,21 @@ func (mr *MockDeviceRepositoryMockRecorder) GetDeviceById(ctx, id interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceById", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceById), ctx, id)
 }
 
+// GetDeviceByKeyHash mocks base method.
+func (m *MockDeviceRepository) GetDeviceByKeyHash(ctx context.Context, keyHash string) (*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceByKeyHash", ctx, keyHash)
+	ret0, _ := ret[0].(*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceByKeyHash indicates an expected call of GetDeviceByKeyHash.
+func (mr *MockDeviceRepositoryMockRecorder) GetDeviceByKeyHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceByKeyHash", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceByKeyHash), ctx, keyHash)
+}
+
 // GetDeviceBySerialNumber mocks base method.
 func (m *MockDeviceRepository) GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (*model.Device, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +254,21 @@ func (mr *MockDeviceRepositoryMockRecorder) GetDeviceBySerialNumber(ctx, serialN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceBySerialNumber", reflect.TypeOf((*MockDeviceRepository)(nil).GetDeviceBySerialNumber), ctx, serialNumber)
 }
 
+// GetDevicesByGroupId mocks base method.
+func (m *MockDeviceRepository) GetDevicesByGroupId(ctx context.Context, userId, groupId string) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevicesByGroupId", ctx, userId, groupId)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevicesByGroupId indicates an expected call of GetDevicesByGroupId.
+func (mr *MockDeviceRepositoryMockRecorder) GetDevicesByGroupId(ctx, userId, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevicesByGroupId", reflect.TypeOf((*MockDeviceRepository)(nil).GetDevicesByGroupId), ctx, userId, groupId)
+}
+
 // GetDevicesByUserId mocks base method.
 func (m *MockDeviceRepository) GetDevicesByUserId(ctx context.Context, userId string) ([]*model.Device, error) {
 	m.ctrl.T.Helper()
@@ -93,3 +283,274 @@ func (mr *MockDeviceRepositoryMockRecorder) GetDevicesByUserId(ctx, userId inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevicesByUserId", reflect.TypeOf((*MockDeviceRepository)(nil).GetDevicesByUserId), ctx, userId)
 }
+
+// GetDevicesDeletedBefore mocks base method.
+func (m *MockDeviceRepository) GetDevicesDeletedBefore(ctx context.Context, deletedBefore, limit int64) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevicesDeletedBefore", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevicesDeletedBefore indicates an expected call of GetDevicesDeletedBefore.
+func (mr *MockDeviceRepositoryMockRecorder) GetDevicesDeletedBefore(ctx, deletedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevicesDeletedBefore", reflect.TypeOf((*MockDeviceRepository)(nil).GetDevicesDeletedBefore), ctx, deletedBefore, limit)
+}
+
+// GetDevicesPendingHistoryWipe mocks base method.
+func (m *MockDeviceRepository) GetDevicesPendingHistoryWipe(ctx context.Context, limit int64) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevicesPendingHistoryWipe", ctx, limit)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevicesPendingHistoryWipe indicates an expected call of GetDevicesPendingHistoryWipe.
+func (mr *MockDeviceRepositoryMockRecorder) GetDevicesPendingHistoryWipe(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevicesPendingHistoryWipe", reflect.TypeOf((*MockDeviceRepository)(nil).GetDevicesPendingHistoryWipe), ctx, limit)
+}
+
+// GetIncomingTransfers mocks base method.
+func (m *MockDeviceRepository) GetIncomingTransfers(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingTransfers", ctx, userId, now)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingTransfers indicates an expected call of GetIncomingTransfers.
+func (mr *MockDeviceRepositoryMockRecorder) GetIncomingTransfers(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingTransfers", reflect.TypeOf((*MockDeviceRepository)(nil).GetIncomingTransfers), ctx, userId, now)
+}
+
+// GetSharedDevices mocks base method.
+func (m *MockDeviceRepository) GetSharedDevices(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedDevices", ctx, userId, now)
+	ret0, _ := ret[0].([]*model.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedDevices indicates an expected call of GetSharedDevices.
+func (mr *MockDeviceRepositoryMockRecorder) GetSharedDevices(ctx, userId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedDevices", reflect.TypeOf((*MockDeviceRepository)(nil).GetSharedDevices), ctx, userId, now)
+}
+
+// ListDevices mocks base method.
+func (m *MockDeviceRepository) ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDevices", ctx, query)
+	ret0, _ := ret[0].(*model.DevicePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDevices indicates an expected call of ListDevices.
+func (mr *MockDeviceRepositoryMockRecorder) ListDevices(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDevices", reflect.TypeOf((*MockDeviceRepository)(nil).ListDevices), ctx, query)
+}
+
+// PatchDevice mocks base method.
+func (m *MockDeviceRepository) PatchDevice(ctx context.Context, device *model.Device, paths []string, change *model.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchDevice", ctx, device, paths, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchDevice indicates an expected call of PatchDevice.
+func (mr *MockDeviceRepositoryMockRecorder) PatchDevice(ctx, device, paths, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchDevice", reflect.TypeOf((*MockDeviceRepository)(nil).PatchDevice), ctx, device, paths, change)
+}
+
+// PurgeDevice mocks base method.
+func (m *MockDeviceRepository) PurgeDevice(ctx context.Context, id string, deletedBefore int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDevice", ctx, id, deletedBefore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDevice indicates an expected call of PurgeDevice.
+func (mr *MockDeviceRepositoryMockRecorder) PurgeDevice(ctx, id, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDevice", reflect.TypeOf((*MockDeviceRepository)(nil).PurgeDevice), ctx, id, deletedBefore)
+}
+
+// RecordClaimFailure mocks base method.
+func (m *MockDeviceRepository) RecordClaimFailure(ctx context.Context, id string, maxAttempts int, now, lockedUntil int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClaimFailure", ctx, id, maxAttempts, now, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClaimFailure indicates an expected call of RecordClaimFailure.
+func (mr *MockDeviceRepositoryMockRecorder) RecordClaimFailure(ctx, id, maxAttempts, now, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClaimFailure", reflect.TypeOf((*MockDeviceRepository)(nil).RecordClaimFailure), ctx, id, maxAttempts, now, lockedUntil)
+}
+
+// RemoveDeviceLabels mocks base method.
+func (m *MockDeviceRepository) RemoveDeviceLabels(ctx context.Context, id string, tags, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDeviceLabels", ctx, id, tags, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDeviceLabels indicates an expected call of RemoveDeviceLabels.
+func (mr *MockDeviceRepositoryMockRecorder) RemoveDeviceLabels(ctx, id, tags, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeviceLabels", reflect.TypeOf((*MockDeviceRepository)(nil).RemoveDeviceLabels), ctx, id, tags, keys)
+}
+
+// RemoveDevicesFromGroup mocks base method.
+func (m *MockDeviceRepository) RemoveDevicesFromGroup(ctx context.Context, userId, groupId string, deviceIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDevicesFromGroup", ctx, userId, groupId, deviceIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDevicesFromGroup indicates an expected call of RemoveDevicesFromGroup.
+func (mr *MockDeviceRepositoryMockRecorder) RemoveDevicesFromGroup(ctx, userId, groupId, deviceIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDevicesFromGroup", reflect.TypeOf((*MockDeviceRepository)(nil).RemoveDevicesFromGroup), ctx, userId, groupId, deviceIds)
+}
+
+// RestoreDevice mocks base method.
+func (m *MockDeviceRepository) RestoreDevice(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreDevice", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreDevice indicates an expected call of RestoreDevice.
+func (mr *MockDeviceRepositoryMockRecorder) RestoreDevice(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreDevice", reflect.TypeOf((*MockDeviceRepository)(nil).RestoreDevice), ctx, id)
+}
+
+// SetDeviceCredential mocks base method.
+func (m *MockDeviceRepository) SetDeviceCredential(ctx context.Context, id string, credential *model.DeviceCredential) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeviceCredential", ctx, id, credential)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeviceCredential indicates an expected call of SetDeviceCredential.
+func (mr *MockDeviceRepositoryMockRecorder) SetDeviceCredential(ctx, id, credential interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeviceCredential", reflect.TypeOf((*MockDeviceRepository)(nil).SetDeviceCredential), ctx, id, credential)
+}
+
+// SetDeviceLabels mocks base method.
+func (m *MockDeviceRepository) SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string, maxTags, maxLabels int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeviceLabels", ctx, id, tags, labels, maxTags, maxLabels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeviceLabels indicates an expected call of SetDeviceLabels.
+func (mr *MockDeviceRepositoryMockRecorder) SetDeviceLabels(ctx, id, tags, labels, maxTags, maxLabels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeviceLabels", reflect.TypeOf((*MockDeviceRepository)(nil).SetDeviceLabels), ctx, id, tags, labels, maxTags, maxLabels)
+}
+
+// ShareDevice mocks base method.
+func (m *MockDeviceRepository) ShareDevice(ctx context.Context, id, ownerId string, share *model.DeviceShare, maxShares int, now int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareDevice", ctx, id, ownerId, share, maxShares, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareDevice indicates an expected call of ShareDevice.
+func (mr *MockDeviceRepositoryMockRecorder) ShareDevice(ctx, id, ownerId, share, maxShares, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareDevice", reflect.TypeOf((*MockDeviceRepository)(nil).ShareDevice), ctx, id, ownerId, share, maxShares, now)
+}
+
+// StartTransfer mocks base method.
+func (m *MockDeviceRepository) StartTransfer(ctx context.Context, id, ownerId string, transfer *model.DeviceTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTransfer", ctx, id, ownerId, transfer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartTransfer indicates an expected call of StartTransfer.
+func (mr *MockDeviceRepositoryMockRecorder) StartTransfer(ctx, id, ownerId, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTransfer", reflect.TypeOf((*MockDeviceRepository)(nil).StartTransfer), ctx, id, ownerId, transfer)
+}
+
+// UnshareDevice mocks base method.
+func (m *MockDeviceRepository) UnshareDevice(ctx context.Context, id, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareDevice", ctx, id, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareDevice indicates an expected call of UnshareDevice.
+func (mr *MockDeviceRepositoryMockRecorder) UnshareDevice(ctx, id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareDevice", reflect.TypeOf((*MockDeviceRepository)(nil).UnshareDevice), ctx, id, userId)
+}
+
+// UpdateDevice mocks base method.
+func (m *MockDeviceRepository) UpdateDevice(ctx context.Context, device *model.Device, change *model.StatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDevice", ctx, device, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDevice indicates an expected call of UpdateDevice.
+func (mr *MockDeviceRepositoryMockRecorder) UpdateDevice(ctx, device, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDevice", reflect.TypeOf((*MockDeviceRepository)(nil).UpdateDevice), ctx, device, change)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockDeviceRepository) UpdateLocation(ctx context.Context, id string, location *model.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, id, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockDeviceRepositoryMockRecorder) UpdateLocation(ctx, id, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockDeviceRepository)(nil).UpdateLocation), ctx, id, location)
+}
+
+// UpdateTelemetry mocks base method.
+func (m *MockDeviceRepository) UpdateTelemetry(ctx context.Context, id string, sample *model.TelemetrySample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTelemetry", ctx, id, sample)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTelemetry indicates an expected call of UpdateTelemetry.
+func (mr *MockDeviceRepositoryMockRecorder) UpdateTelemetry(ctx, id, sample interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTelemetry", reflect.TypeOf((*MockDeviceRepository)(nil).UpdateTelemetry), ctx, id, sample)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/device_type_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeviceTypeRepository is a mock of DeviceTypeRepository interface.
+type MockDeviceTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeviceTypeRepositoryMockRecorder
+}
+
+// MockDeviceTypeRepositoryMockRecorder is the mock recorder for MockDeviceTypeRepository.
+type MockDeviceTypeRepositoryMockRecorder struct {
+	mock *MockDeviceTypeRepository
+}
+
+// NewMockDeviceTypeRepository creates a new mock instance.
+func NewMockDeviceTypeRepository(ctrl *gomock.Controller) *MockDeviceTypeRepository {
+	mock := &MockDeviceTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockDeviceTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeviceTypeRepository) EXPECT() *MockDeviceTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeviceType mocks base method.
+func (m *MockDeviceTypeRepository) CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeviceType", ctx, deviceType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeviceType indicates an expected call of CreateDeviceType.
+func (mr *MockDeviceTypeRepositoryMockRecorder) CreateDeviceType(ctx, deviceType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeviceType", reflect.TypeOf((*MockDeviceTypeRepository)(nil).CreateDeviceType), ctx, deviceType)
+}
+
+// DeleteDeviceType mocks base method.
+func (m *MockDeviceTypeRepository) DeleteDeviceType(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeviceType", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeviceType indicates an expected call of DeleteDeviceType.
+func (mr *MockDeviceTypeRepositoryMockRecorder) DeleteDeviceType(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeviceType", reflect.TypeOf((*MockDeviceTypeRepository)(nil).DeleteDeviceType), ctx, id)
+}
+
+// GetDeviceType mocks base method.
+func (m *MockDeviceTypeRepository) GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceType", ctx, id)
+	ret0, _ := ret[0].(*model.DeviceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceType indicates an expected call of GetDeviceType.
+func (mr *MockDeviceTypeRepositoryMockRecorder) GetDeviceType(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceType", reflect.TypeOf((*MockDeviceTypeRepository)(nil).GetDeviceType), ctx, id)
+}
+
+// ListDeviceTypes mocks base method.
+func (m *MockDeviceTypeRepository) ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeviceTypes", ctx)
+	ret0, _ := ret[0].([]*model.DeviceType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeviceTypes indicates an expected call of ListDeviceTypes.
+func (mr *MockDeviceTypeRepositoryMockRecorder) ListDeviceTypes(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeviceTypes", reflect.TypeOf((*MockDeviceTypeRepository)(nil).ListDeviceTypes), ctx)
+}
+
+// UpdateDeviceType mocks base method.
+func (m *MockDeviceTypeRepository) UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeviceType", ctx, deviceType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeviceType indicates an expected call of UpdateDeviceType.
+func (mr *MockDeviceTypeRepositoryMockRecorder) UpdateDeviceType(ctx, deviceType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeviceType", reflect.TypeOf((*MockDeviceTypeRepository)(nil).UpdateDeviceType), ctx, deviceType)
+}
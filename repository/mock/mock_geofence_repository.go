@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/geofence_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGeofenceRepository is a mock of GeofenceRepository interface.
+type MockGeofenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeofenceRepositoryMockRecorder
+}
+
+// MockGeofenceRepositoryMockRecorder is the mock recorder for MockGeofenceRepository.
+type MockGeofenceRepositoryMockRecorder struct {
+	mock *MockGeofenceRepository
+}
+
+// NewMockGeofenceRepository creates a new mock instance.
+func NewMockGeofenceRepository(ctrl *gomock.Controller) *MockGeofenceRepository {
+	mock := &MockGeofenceRepository{ctrl: ctrl}
+	mock.recorder = &MockGeofenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeofenceRepository) EXPECT() *MockGeofenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateGeofence mocks base method.
+func (m *MockGeofenceRepository) CreateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGeofence", ctx, geofence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGeofence indicates an expected call of CreateGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) CreateGeofence(ctx, geofence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).CreateGeofence), ctx, geofence)
+}
+
+// DeleteGeofence mocks base method.
+func (m *MockGeofenceRepository) DeleteGeofence(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGeofence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGeofence indicates an expected call of DeleteGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) DeleteGeofence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).DeleteGeofence), ctx, id)
+}
+
+// GetGeofenceById mocks base method.
+func (m *MockGeofenceRepository) GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofenceById", ctx, id)
+	ret0, _ := ret[0].(*model.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofenceById indicates an expected call of GetGeofenceById.
+func (mr *MockGeofenceRepositoryMockRecorder) GetGeofenceById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofenceById", reflect.TypeOf((*MockGeofenceRepository)(nil).GetGeofenceById), ctx, id)
+}
+
+// GetGeofencesByDeviceId mocks base method.
+func (m *MockGeofenceRepository) GetGeofencesByDeviceId(ctx context.Context, deviceId string) ([]*model.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByDeviceId", ctx, deviceId)
+	ret0, _ := ret[0].([]*model.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByDeviceId indicates an expected call of GetGeofencesByDeviceId.
+func (mr *MockGeofenceRepositoryMockRecorder) GetGeofencesByDeviceId(ctx, deviceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByDeviceId", reflect.TypeOf((*MockGeofenceRepository)(nil).GetGeofencesByDeviceId), ctx, deviceId)
+}
+
+// GetGeofencesByUserId mocks base method.
+func (m *MockGeofenceRepository) GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeofencesByUserId", ctx, userId)
+	ret0, _ := ret[0].([]*model.Geofence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeofencesByUserId indicates an expected call of GetGeofencesByUserId.
+func (mr *MockGeofenceRepositoryMockRecorder) GetGeofencesByUserId(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeofencesByUserId", reflect.TypeOf((*MockGeofenceRepository)(nil).GetGeofencesByUserId), ctx, userId)
+}
+
+// InsertGeofenceEvents mocks base method.
+func (m *MockGeofenceRepository) InsertGeofenceEvents(ctx context.Context, events []*model.GeofenceEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGeofenceEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertGeofenceEvents indicates an expected call of InsertGeofenceEvents.
+func (mr *MockGeofenceRepositoryMockRecorder) InsertGeofenceEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGeofenceEvents", reflect.TypeOf((*MockGeofenceRepository)(nil).InsertGeofenceEvents), ctx, events)
+}
+
+// ListGeofenceEvents mocks base method.
+func (m *MockGeofenceRepository) ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGeofenceEvents", ctx, query)
+	ret0, _ := ret[0].(*model.GeofenceEventPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGeofenceEvents indicates an expected call of ListGeofenceEvents.
+func (mr *MockGeofenceRepositoryMockRecorder) ListGeofenceEvents(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGeofenceEvents", reflect.TypeOf((*MockGeofenceRepository)(nil).ListGeofenceEvents), ctx, query)
+}
+
+// UpdateGeofence mocks base method.
+func (m *MockGeofenceRepository) UpdateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGeofence", ctx, geofence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGeofence indicates an expected call of UpdateGeofence.
+func (mr *MockGeofenceRepositoryMockRecorder) UpdateGeofence(ctx, geofence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGeofence", reflect.TypeOf((*MockGeofenceRepository)(nil).UpdateGeofence), ctx, geofence)
+}
+
+// UpdateGeofenceState mocks base method.
+func (m *MockGeofenceRepository) UpdateGeofenceState(ctx context.Context, id, deviceId string, previous, state model.GeofenceState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGeofenceState", ctx, id, deviceId, previous, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGeofenceState indicates an expected call of UpdateGeofenceState.
+func (mr *MockGeofenceRepositoryMockRecorder) UpdateGeofenceState(ctx, id, deviceId, previous, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGeofenceState", reflect.TypeOf((*MockGeofenceRepository)(nil).UpdateGeofenceState), ctx, id, deviceId, previous, state)
+}
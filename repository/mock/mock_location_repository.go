@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/location_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLocationRepository is a mock of LocationRepository interface.
+type MockLocationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationRepositoryMockRecorder
+}
+
+// MockLocationRepositoryMockRecorder is the mock recorder for MockLocationRepository.
+type MockLocationRepositoryMockRecorder struct {
+	mock *MockLocationRepository
+}
+
+// NewMockLocationRepository creates a new mock instance.
+func NewMockLocationRepository(ctrl *gomock.Controller) *MockLocationRepository {
+	mock := &MockLocationRepository{ctrl: ctrl}
+	mock.recorder = &MockLocationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationRepository) EXPECT() *MockLocationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetLocationHistory mocks base method.
+func (m *MockLocationRepository) GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationHistory", ctx, query)
+	ret0, _ := ret[0].(*model.LocationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationHistory indicates an expected call of GetLocationHistory.
+func (mr *MockLocationRepositoryMockRecorder) GetLocationHistory(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationHistory", reflect.TypeOf((*MockLocationRepository)(nil).GetLocationHistory), ctx, query)
+}
+
+// InsertLocations mocks base method.
+func (m *MockLocationRepository) InsertLocations(ctx context.Context, locations []*model.Location) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLocations", ctx, locations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertLocations indicates an expected call of InsertLocations.
+func (mr *MockLocationRepositoryMockRecorder) InsertLocations(ctx, locations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLocations", reflect.TypeOf((*MockLocationRepository)(nil).InsertLocations), ctx, locations)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/telemetry_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTelemetryRepository is a mock of TelemetryRepository interface.
+type MockTelemetryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTelemetryRepositoryMockRecorder
+}
+
+// MockTelemetryRepositoryMockRecorder is the mock recorder for MockTelemetryRepository.
+type MockTelemetryRepositoryMockRecorder struct {
+	mock *MockTelemetryRepository
+}
+
+// NewMockTelemetryRepository creates a new mock instance.
+func NewMockTelemetryRepository(ctrl *gomock.Controller) *MockTelemetryRepository {
+	mock := &MockTelemetryRepository{ctrl: ctrl}
+	mock.recorder = &MockTelemetryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTelemetryRepository) EXPECT() *MockTelemetryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTelemetryHistory mocks base method.
+func (m *MockTelemetryRepository) GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTelemetryHistory", ctx, query)
+	ret0, _ := ret[0].([]*model.TelemetrySample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTelemetryHistory indicates an expected call of GetTelemetryHistory.
+func (mr *MockTelemetryRepositoryMockRecorder) GetTelemetryHistory(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTelemetryHistory", reflect.TypeOf((*MockTelemetryRepository)(nil).GetTelemetryHistory), ctx, query)
+}
+
+// InsertSamples mocks base method.
+func (m *MockTelemetryRepository) InsertSamples(ctx context.Context, samples []*model.TelemetrySample) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSamples", ctx, samples)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSamples indicates an expected call of InsertSamples.
+func (mr *MockTelemetryRepositoryMockRecorder) InsertSamples(ctx, samples interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSamples", reflect.TypeOf((*MockTelemetryRepository)(nil).InsertSamples), ctx, samples)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/trip_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/BerryTracer/device-service/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTripRepository is a mock of TripRepository interface.
+type MockTripRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTripRepositoryMockRecorder
+}
+
+// MockTripRepositoryMockRecorder is the mock recorder for MockTripRepository.
+type MockTripRepositoryMockRecorder struct {
+	mock *MockTripRepository
+}
+
+// NewMockTripRepository creates a new mock instance.
+func NewMockTripRepository(ctrl *gomock.Controller) *MockTripRepository {
+	mock := &MockTripRepository{ctrl: ctrl}
+	mock.recorder = &MockTripRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTripRepository) EXPECT() *MockTripRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTripById mocks base method.
+func (m *MockTripRepository) GetTripById(ctx context.Context, id string) (*model.Trip, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTripById", ctx, id)
+	ret0, _ := ret[0].(*model.Trip)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTripById indicates an expected call of GetTripById.
+func (mr *MockTripRepositoryMockRecorder) GetTripById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTripById", reflect.TypeOf((*MockTripRepository)(nil).GetTripById), ctx, id)
+}
+
+// GetTripState mocks base method.
+func (m *MockTripRepository) GetTripState(ctx context.Context, deviceId string) (*model.TripState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTripState", ctx, deviceId)
+	ret0, _ := ret[0].(*model.TripState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTripState indicates an expected call of GetTripState.
+func (mr *MockTripRepositoryMockRecorder) GetTripState(ctx, deviceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTripState", reflect.TypeOf((*MockTripRepository)(nil).GetTripState), ctx, deviceId)
+}
+
+// InsertTrips mocks base method.
+func (m *MockTripRepository) InsertTrips(ctx context.Context, trips []*model.Trip) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTrips", ctx, trips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTrips indicates an expected call of InsertTrips.
+func (mr *MockTripRepositoryMockRecorder) InsertTrips(ctx, trips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTrips", reflect.TypeOf((*MockTripRepository)(nil).InsertTrips), ctx, trips)
+}
+
+// ListTrips mocks base method.
+func (m *MockTripRepository) ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrips", ctx, query)
+	ret0, _ := ret[0].(*model.TripPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrips indicates an expected call of ListTrips.
+func (mr *MockTripRepositoryMockRecorder) ListTrips(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrips", reflect.TypeOf((*MockTripRepository)(nil).ListTrips), ctx, query)
+}
+
+// SaveTripState mocks base method.
+func (m *MockTripRepository) SaveTripState(ctx context.Context, deviceId string, state *model.TripState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTripState", ctx, deviceId, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTripState indicates an expected call of SaveTripState.
+func (mr *MockTripRepositoryMockRecorder) SaveTripState(ctx, deviceId, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTripState", reflect.TypeOf((*MockTripRepository)(nil).SaveTripState), ctx, deviceId, state)
+}
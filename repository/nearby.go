@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/BerryTracer/device-service/geofence"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/selector"
+)
+
+// NearbyDevices answers a proximity query over devices held in memory. It
+// mirrors FindDevicesNear of DeviceMongoRepository, for in-memory repositories
+// and test fakes: devices are filtered like a listing, limited to those within
+// the radius and returned nearest first.
+func NearbyDevices(devices []*model.Device, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error) {
+	limit, err := nearbyLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := parseLabelSelector(query.Filter.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	center := geofence.Point{Lat: query.Latitude, Lon: query.Longitude}
+
+	var nearby []*model.NearbyDevice
+	for _, device := range devices {
+		if device.LastLocation == nil || !matchesFilter(device, query.UserID, query.SharedAt, query.Filter, labels) {
+			continue
+		}
+
+		distance := geofence.Distance(center, geofence.Point{Lat: device.LastLocation.Latitude, Lon: device.LastLocation.Longitude})
+		if distance <= query.Radius {
+			nearby = append(nearby, &model.NearbyDevice{Device: device, Distance: distance})
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].Distance < nearby[j].Distance })
+
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+
+	return nearby, nil
+}
+
+// matchesFilter reports whether a device matches the Mongo filter built by
+// deviceFilter. labels is the parsed label selector of filter.
+func matchesFilter(device *model.Device, userID string, sharedAt int64, filter model.DeviceFilter, labels selector.Selector) bool {
+	if device.DeletedAt != 0 {
+		return false
+	}
+
+	if device.UserID != userID && (sharedAt == 0 || device.RoleOf(userID, sharedAt) == "") {
+		return false
+	}
+
+	if filter.Status != "" && device.Status != filter.Status {
+		return false
+	}
+
+	if filter.DeviceType != "" && device.DeviceType != filter.DeviceType {
+		return false
+	}
+
+	if filter.BatteryBelow != nil && device.BatteryLevel >= *filter.BatteryBelow {
+		return false
+	}
+
+	if filter.GroupID != "" && !containsString(device.GroupIDs, filter.GroupID) {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !containsString(device.Tags, tag) {
+			return false
+		}
+	}
+
+	return labels.Matches(device.Labels)
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
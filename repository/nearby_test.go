@@ -0,0 +1,149 @@
+package repository_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDeviceMongoRepository_FindDevicesNear(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	query := &model.NearbyDevicesQuery{UserID: "user123", Latitude: 52.52, Longitude: 13.40, Radius: 500, Filter: model.DeviceFilter{Status: model.StatusActive}}
+
+	// The $geoNear stage searches the caller's devices by their last location.
+	mockAdapter.EXPECT().
+		Aggregate(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, pipeline interface{}, _ ...interface{}) (*mock.MockCursor, error) {
+			geoNear := pipeline.(primitive.A)[0].(primitive.M)["$geoNear"].(primitive.M)
+			if geoNear["key"] != "last_location" || geoNear["maxDistance"] != 500.0 || geoNear["near"].(model.GeoPoint).Longitude() != 13.40 {
+				t.Errorf("unexpected $geoNear stage %v", geoNear)
+			}
+			filter := geoNear["query"].(primitive.M)
+			if filter["user_id"] != "user123" || filter["status"] != model.StatusActive {
+				t.Errorf("unexpected query %v", filter)
+			}
+			return mockCursor, nil
+		}).
+		Times(1)
+
+	// The distance is decoded next to the device.
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			raw, err := bson.Marshal(primitive.M{"_id": primitive.NewObjectID(), "user_id": "user123", "name": "Van", "distance": 123.4})
+			if err != nil {
+				return err
+			}
+			devices := reflect.ValueOf(v).Elem()
+			device := reflect.New(devices.Type().Elem().Elem())
+			if err := bson.Unmarshal(raw, device.Interface()); err != nil {
+				return err
+			}
+			devices.Set(reflect.Append(devices, device))
+			return nil
+		}).
+		Times(1)
+
+	// Call the FindDevicesNear method.
+	devices, err := repo.FindDevicesNear(ctx, query)
+
+	// Check the returned devices.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(devices) != 1 || devices[0].Distance != 123.4 || devices[0].Device.Name != "Van" {
+		t.Errorf("unexpected devices %+v", devices)
+	}
+}
+
+func TestNearbyDevices(t *testing.T) {
+	at := func(lat, lon float64) *model.Location { return &model.Location{Latitude: lat, Longitude: lon} }
+	below := 20
+
+	devices := []*model.Device{
+		{ID: "far", UserID: "user123", Status: model.StatusActive, LastLocation: at(0, 0.01)},                                             // ~1.1 km
+		{ID: "near", UserID: "user123", Status: model.StatusActive, LastLocation: at(0, 0.001), Labels: map[string]string{"env": "prod"}}, // ~111 m
+		{ID: "nearest", UserID: "user123", Status: model.StatusActive, LastLocation: at(0, 0.0001)},                                       // ~11 m
+		{ID: "other user", UserID: "user456", Status: model.StatusActive, LastLocation: at(0, 0)},                                         // Not the caller's
+		{ID: "no location", UserID: "user123", Status: model.StatusActive},                                                                // Never reported
+		{ID: "lost", UserID: "user123", Status: model.StatusLost, LastLocation: at(0, 0.002)},                                             // Filtered by status
+		{ID: "full", UserID: "user123", Status: model.StatusActive, BatteryLevel: 90, LastLocation: at(0, 0.003)},
+		{ID: "shared", UserID: "user456", Status: model.StatusActive, BatteryLevel: 90, LastLocation: at(0, 0.004), Shares: []model.DeviceShare{{UserID: "user123", Role: model.RoleViewer}}},
+		{ID: "expired", UserID: "user456", Status: model.StatusActive, BatteryLevel: 90, LastLocation: at(0, 0.0002), Shares: []model.DeviceShare{{UserID: "user123", Role: model.RoleViewer, ExpiresAt: 100}}},
+	}
+
+	tests := []struct {
+		name  string
+		query *model.NearbyDevicesQuery
+		want  []string
+	}{
+		{
+			name:  "within radius nearest first",
+			query: &model.NearbyDevicesQuery{UserID: "user123", Radius: 500, Filter: model.DeviceFilter{Status: model.StatusActive}},
+			want:  []string{"nearest", "near", "full"},
+		},
+		{
+			name:  "battery filter",
+			query: &model.NearbyDevicesQuery{UserID: "user123", Radius: 500, Filter: model.DeviceFilter{BatteryBelow: &below}},
+			want:  []string{"nearest", "near", "lost"},
+		},
+		{
+			name:  "label selector",
+			query: &model.NearbyDevicesQuery{UserID: "user123", Radius: 500, Filter: model.DeviceFilter{LabelSelector: "env=prod"}},
+			want:  []string{"near"},
+		},
+		{
+			name:  "shared with the user",
+			query: &model.NearbyDevicesQuery{UserID: "user123", Radius: 500, Filter: model.DeviceFilter{Status: model.StatusActive}, SharedAt: 200},
+			want:  []string{"nearest", "near", "full", "shared"},
+		},
+		{
+			name:  "limit",
+			query: &model.NearbyDevicesQuery{UserID: "user123", Radius: 5000, Limit: 2},
+			want:  []string{"nearest", "near"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nearby, err := repository.NearbyDevices(devices, tt.query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var got []string
+			for _, device := range nearby {
+				got = append(got, device.Device.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := repository.NearbyDevices(devices, &model.NearbyDevicesQuery{Limit: -1}); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error for a negative limit, got %v", err)
+	}
+
+	if _, err := repository.NearbyDevices(devices, &model.NearbyDevicesQuery{Filter: model.DeviceFilter{LabelSelector: "!"}}); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error for a malformed label selector, got %v", err)
+	}
+}
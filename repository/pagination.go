@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DefaultPageSize is used when a listing does not ask for a page size.
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of items returned in one page.
+	MaxPageSize = 1000
+
+	// DefaultNearbyLimit is the number of devices returned by a proximity query without a limit.
+	DefaultNearbyLimit = 50
+	// MaxNearbyLimit caps the number of devices returned by a proximity query.
+	MaxNearbyLimit = 1000
+)
+
+// sortableDeviceFields lists the fields a device listing can be ordered by.
+var sortableDeviceFields = map[string]bool{
+	"name":              true,
+	"registration_date": true,
+	"battery_level":     true,
+}
+
+// sortOrder is a parsed order_by clause. Devices are always ordered by _id
+// after the requested field so that every position in the listing is unique.
+type sortOrder struct {
+	Field string `bson:"field"`
+	Desc  bool   `bson:"desc"`
+}
+
+// pageCursor is the decoded form of an opaque page token. It records the sort
+// key of the last device on the previous page.
+type pageCursor struct {
+	Order sortOrder          `bson:"order"`
+	Value interface{}        `bson:"value"`
+	ID    primitive.ObjectID `bson:"id"`
+}
+
+// parseOrderBy parses an order_by clause such as "name" or "battery_level desc".
+func parseOrderBy(orderBy string) (sortOrder, error) {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 {
+		return sortOrder{Field: "_id"}, nil
+	}
+
+	order := sortOrder{Field: fields[0]}
+	if !sortableDeviceFields[order.Field] {
+		return sortOrder{}, errs.InvalidField("order_by", fmt.Sprintf("cannot order by %q", order.Field))
+	}
+
+	if len(fields) > 2 {
+		return sortOrder{}, errs.InvalidField("order_by", "expected a field name optionally followed by asc or desc")
+	}
+
+	if len(fields) == 2 {
+		switch strings.ToLower(fields[1]) {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return sortOrder{}, errs.InvalidField("order_by", fmt.Sprintf("unknown direction %q", fields[1]))
+		}
+	}
+
+	return order, nil
+}
+
+// sort returns the Mongo sort document for the order.
+func (o sortOrder) sort() bson.D {
+	direction := 1
+	if o.Desc {
+		direction = -1
+	}
+
+	if o.Field == "_id" {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+
+	return bson.D{{Key: o.Field, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// after returns the filter matching the devices that follow the cursor position.
+func (o sortOrder) after(cursor *pageCursor) primitive.M {
+	operator := "$gt"
+	if o.Desc {
+		operator = "$lt"
+	}
+
+	if o.Field == "_id" {
+		return primitive.M{"_id": primitive.M{operator: cursor.ID}}
+	}
+
+	return primitive.M{"$or": primitive.A{
+		primitive.M{o.Field: primitive.M{operator: cursor.Value}},
+		primitive.M{o.Field: cursor.Value, "_id": primitive.M{operator: cursor.ID}},
+	}}
+}
+
+// encodePageToken turns a cursor into an opaque, URL-safe page token.
+func encodePageToken(cursor *pageCursor) (string, error) {
+	data, err := bson.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken parses a page token and checks it was issued for the same order.
+func decodePageToken(token string, order sortOrder) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.InvalidField("page_token", "is malformed")
+	}
+
+	var cursor pageCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil {
+		return nil, errs.InvalidField("page_token", "is malformed")
+	}
+
+	if cursor.Order != order {
+		return nil, errs.InvalidField("page_token", "was issued for a different order_by")
+	}
+
+	return &cursor, nil
+}
+
+// pageSize validates the requested page size and applies the defaults.
+func pageSize(requested int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, errs.InvalidField("page_size", "must not be negative")
+	case requested == 0:
+		return DefaultPageSize, nil
+	case requested > MaxPageSize:
+		return MaxPageSize, nil
+	default:
+		return requested, nil
+	}
+}
+
+// nearbyLimit validates the requested number of devices of a proximity query and applies the defaults.
+func nearbyLimit(requested int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, errs.InvalidField("limit", "must not be negative")
+	case requested == 0:
+		return DefaultNearbyLimit, nil
+	case requested > MaxNearbyLimit:
+		return MaxNearbyLimit, nil
+	default:
+		return requested, nil
+	}
+}
@@ -0,0 +1,235 @@
+package repository_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// expectPage sets up a Find returning devicesDB and a CountDocuments returning total.
+func expectPage(ctrl *gomock.Controller, collection *mock_repository.MockCollection, devicesDB []*model.DeviceDB, total int64, filter *interface{}) {
+	cursor := mock.NewMockCursor(ctrl)
+
+	collection.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, f interface{}, _ ...interface{}) (interface{}, error) {
+			*filter = f
+			return cursor, nil
+		}).
+		Times(1)
+
+	cursor.EXPECT().
+		All(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.DeviceDB) = devicesDB
+			return nil
+		}).
+		Times(1)
+
+	collection.EXPECT().
+		CountDocuments(gomock.Any(), gomock.Any()).
+		Return(total, nil).
+		Times(1)
+}
+
+func TestDeviceMongoRepository_ListDevices_Pages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockCollection)
+
+	ctx := context.Background()
+	batteryBelow := 20
+	query := &model.ListDevicesQuery{
+		UserID:   "user123",
+		PageSize: 2,
+		OrderBy:  "battery_level desc",
+		Filter:   model.DeviceFilter{Status: model.StatusActive, BatteryBelow: &batteryBelow},
+	}
+
+	// The repository asks for one device more than the page size.
+	devicesDB := []*model.DeviceDB{
+		{ID: primitive.NewObjectID(), UserID: "user123", BatteryLevel: 15},
+		{ID: primitive.NewObjectID(), UserID: "user123", BatteryLevel: 10},
+		{ID: primitive.NewObjectID(), UserID: "user123", BatteryLevel: 5},
+	}
+
+	var firstFilter interface{}
+	expectPage(ctrl, mockCollection, devicesDB, 3, &firstFilter)
+
+	page, err := repo.ListDevices(ctx, query)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	wantFilter := primitive.M{"user_id": "user123", "deleted_at": primitive.M{"$exists": false}, "status": model.StatusActive, "battery_level": primitive.M{"$lt": 20}}
+	if got, ok := firstFilter.(primitive.M); !ok || len(got) != len(wantFilter) || got["status"] != model.StatusActive {
+		t.Errorf("expected filter %v, got %v", wantFilter, firstFilter)
+	}
+
+	if len(page.Devices) != 2 || page.TotalSize != 3 || page.NextPageToken == "" {
+		t.Fatalf("expected 2 devices of 3 with a next page token, got %+v", page)
+	}
+
+	// The second page continues after the last device of the first page.
+	var secondFilter interface{}
+	expectPage(ctrl, mockCollection, devicesDB[2:], 3, &secondFilter)
+
+	query.PageToken = page.NextPageToken
+	page, err = repo.ListDevices(ctx, query)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	and, ok := secondFilter.(primitive.M)["$and"].(primitive.A)
+	if !ok || len(and) != 2 {
+		t.Fatalf("expected the page filter to be combined with $and, got %v", secondFilter)
+	}
+
+	after := and[1].(primitive.M)["$or"].(primitive.A)
+	if lt := after[0].(primitive.M)["battery_level"].(primitive.M)["$lt"]; lt != int32(10) && lt != int64(10) {
+		t.Errorf("expected the next page to start below battery level 10, got %v", lt)
+	}
+
+	if len(page.Devices) != 1 || page.NextPageToken != "" {
+		t.Errorf("expected a last page with 1 device, got %+v", page)
+	}
+}
+
+func TestDeviceMongoRepository_ListDevices_Shared(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockCollection)
+
+	var filter interface{}
+	expectPage(ctrl, mockCollection, nil, 0, &filter)
+
+	if _, err := repo.ListDevices(context.Background(), &model.ListDevicesQuery{UserID: "user123", SharedAt: 100}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The devices the user owns or holds an active share of are listed.
+	want := primitive.M{
+		"deleted_at": primitive.M{"$exists": false},
+		"$or": primitive.A{
+			primitive.M{"user_id": "user123"},
+			primitive.M{"shares": primitive.M{"$elemMatch": primitive.M{
+				"user_id": "user123",
+				"$or": primitive.A{
+					primitive.M{"expires_at": 0},
+					primitive.M{"expires_at": primitive.M{"$gt": int64(100)}},
+				},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(filter, want) {
+		t.Errorf("expected filter %v, got %v", want, filter)
+	}
+}
+
+func TestDeviceMongoRepository_ListDevices_Labels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockCollection)
+
+	query := &model.ListDevicesQuery{
+		UserID: "user123",
+		Filter: model.DeviceFilter{
+			Tags:          []string{"fleet"},
+			LabelSelector: "env=prod,plate!=X,!retired,tier in (gold,silver)",
+		},
+	}
+
+	var filter interface{}
+	expectPage(ctrl, mockCollection, nil, 0, &filter)
+
+	if _, err := repo.ListDevices(context.Background(), query); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Each requirement becomes a condition on its label.
+	wantFilter := primitive.M{
+		"user_id":    "user123",
+		"deleted_at": primitive.M{"$exists": false},
+		"tags":       primitive.M{"$all": []string{"fleet"}},
+		"$and": primitive.A{
+			primitive.M{"labels.env": "prod"},
+			primitive.M{"labels.plate": primitive.M{"$ne": "X"}},
+			primitive.M{"labels.retired": primitive.M{"$exists": false}},
+			primitive.M{"labels.tier": primitive.M{"$in": []string{"gold", "silver"}}},
+		},
+	}
+	if !reflect.DeepEqual(filter, wantFilter) {
+		t.Errorf("expected filter %v, got %v", wantFilter, filter)
+	}
+}
+
+func TestDeviceMongoRepository_ListDevices_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *model.ListDevicesQuery
+	}{
+		{name: "unknown order field", query: &model.ListDevicesQuery{OrderBy: "serial_number"}},
+		{name: "unknown direction", query: &model.ListDevicesQuery{OrderBy: "name sideways"}},
+		{name: "negative page size", query: &model.ListDevicesQuery{PageSize: -1}},
+		{name: "malformed page token", query: &model.ListDevicesQuery{PageToken: "not a token"}},
+		{name: "malformed label selector", query: &model.ListDevicesQuery{Filter: model.DeviceFilter{LabelSelector: "env=prod,"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// No query is expected for an invalid request.
+			repo := repository.NewDeviceMongoRepository(mock_repository.NewMockCollection(ctrl))
+
+			_, err := repo.ListDevices(context.Background(), tt.query)
+
+			if !errs.Is(err, errs.InvalidArgument) {
+				t.Errorf("expected InvalidArgument error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDeviceMongoRepository_ListDevices_TokenForDifferentOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCollection := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewDeviceMongoRepository(mockCollection)
+
+	devicesDB := []*model.DeviceDB{
+		{ID: primitive.NewObjectID(), Name: "a"},
+		{ID: primitive.NewObjectID(), Name: "b"},
+	}
+
+	var filter interface{}
+	expectPage(ctrl, mockCollection, devicesDB, 2, &filter)
+
+	page, err := repo.ListDevices(context.Background(), &model.ListDevicesQuery{PageSize: 1, OrderBy: "name"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Reusing the token with another order must be rejected.
+	_, err = repo.ListDevices(context.Background(), &model.ListDevicesQuery{PageSize: 1, OrderBy: "name desc", PageToken: page.NextPageToken})
+
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
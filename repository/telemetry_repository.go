@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// DefaultTelemetryLimit is the number of samples returned when the query sets no limit.
+	DefaultTelemetryLimit = 1000
+	// MaxTelemetryLimit is the largest number of samples a single query may return.
+	MaxTelemetryLimit = 10000
+)
+
+type TelemetryRepository interface {
+	InsertSamples(ctx context.Context, samples []*model.TelemetrySample) error
+	GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error)
+}
+
+// TelemetryMongoRepository stores telemetry samples in a time-series collection
+// with "timestamp" as its time field and "device_id" as its meta field.
+type TelemetryMongoRepository struct {
+	Collection Collection
+}
+
+// NewTelemetryMongoRepository returns a new TelemetryMongoRepository.
+func NewTelemetryMongoRepository(collection Collection) *TelemetryMongoRepository {
+	return &TelemetryMongoRepository{Collection: collection}
+}
+
+// InsertSamples implements TelemetryRepository.
+func (r *TelemetryMongoRepository) InsertSamples(ctx context.Context, samples []*model.TelemetrySample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(samples))
+	for _, sample := range samples {
+		sampleDB, err := sample.ToTelemetrySampleDB()
+		if err != nil {
+			invalid := errs.InvalidField("device_id", "must be a 24 character hex ObjectID")
+			invalid.Err = err
+			return invalid
+		}
+		documents = append(documents, sampleDB)
+	}
+
+	if _, err := r.Collection.InsertMany(ctx, documents); err != nil {
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// GetTelemetryHistory implements TelemetryRepository.
+// Samples are returned oldest first. With a query interval they are averaged
+// into buckets of that many seconds, each stamped with the start of its bucket.
+func (r *TelemetryMongoRepository) GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error) {
+	deviceID, err := parseObjectID("device_id", query.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := telemetryLimit(query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Interval < 0 {
+		return nil, errs.InvalidField("interval", "must not be negative")
+	}
+
+	match := primitive.M{"device_id": deviceID}
+	timestamp := primitive.M{}
+	if query.From != 0 {
+		timestamp["$gte"] = time.Unix(query.From, 0).UTC()
+	}
+	if query.To != 0 {
+		timestamp["$lt"] = time.Unix(query.To, 0).UTC()
+	}
+	if len(timestamp) > 0 {
+		match["timestamp"] = timestamp
+	}
+
+	if query.Interval == 0 {
+		return r.findSamples(ctx, match, limit)
+	}
+
+	return r.aggregateSamples(ctx, match, query.Interval, limit)
+}
+
+func (r *TelemetryMongoRepository) findSamples(ctx context.Context, match primitive.M, limit int) ([]*model.TelemetrySample, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(int64(limit))
+	cursor, err := r.Collection.Find(ctx, match, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var samplesDB []*model.TelemetrySampleDB
+	if err = cursor.All(ctx, &samplesDB); err != nil {
+		return nil, err
+	}
+
+	samples := make([]*model.TelemetrySample, 0, len(samplesDB))
+	for _, sampleDB := range samplesDB {
+		samples = append(samples, sampleDB.ToTelemetrySample())
+	}
+
+	return samples, nil
+}
+
+// telemetryBucket is a downsampled bucket as returned by the aggregation.
+type telemetryBucket struct {
+	Start          time.Time           `bson:"_id"`
+	DeviceID       primitive.ObjectID  `bson:"device_id"`
+	BatteryLevel   float64             `bson:"battery_level"`
+	ChargingState  model.ChargingState `bson:"charging_state"`
+	SignalStrength float64             `bson:"signal_strength"`
+}
+
+func (r *TelemetryMongoRepository) aggregateSamples(ctx context.Context, match primitive.M, interval int64, limit int) ([]*model.TelemetrySample, error) {
+	pipeline := primitive.A{
+		primitive.M{"$match": match},
+		primitive.M{"$sort": primitive.M{"timestamp": 1}},
+		primitive.M{"$group": primitive.M{
+			"_id": primitive.M{"$dateTrunc": primitive.M{
+				"date":    "$timestamp",
+				"unit":    "second",
+				"binSize": interval,
+			}},
+			"device_id":       primitive.M{"$first": "$device_id"},
+			"battery_level":   primitive.M{"$avg": "$battery_level"},
+			"charging_state":  primitive.M{"$last": "$charging_state"},
+			"signal_strength": primitive.M{"$avg": "$signal_strength"},
+		}},
+		primitive.M{"$sort": primitive.M{"_id": 1}},
+		primitive.M{"$limit": limit},
+	}
+
+	cursor, err := r.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []*telemetryBucket
+	if err = cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+
+	samples := make([]*model.TelemetrySample, 0, len(buckets))
+	for _, bucket := range buckets {
+		samples = append(samples, &model.TelemetrySample{
+			DeviceID:       bucket.DeviceID.Hex(),
+			Timestamp:      bucket.Start.Unix(),
+			BatteryLevel:   int(math.Round(bucket.BatteryLevel)),
+			ChargingState:  bucket.ChargingState,
+			SignalStrength: int(math.Round(bucket.SignalStrength)),
+		})
+	}
+
+	return samples, nil
+}
+
+// telemetryLimit returns the effective number of samples for a requested limit.
+func telemetryLimit(requested int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, errs.InvalidField("limit", "must not be negative")
+	case requested == 0:
+		return DefaultTelemetryLimit, nil
+	case requested > MaxTelemetryLimit:
+		return MaxTelemetryLimit, nil
+	default:
+		return requested, nil
+	}
+}
+
+// DeleteDeviceHistory implements DeviceHistory.
+func (r *TelemetryMongoRepository) DeleteDeviceHistory(ctx context.Context, deviceId string, before int64) error {
+	var cutoff interface{}
+	if before != 0 {
+		cutoff = time.Unix(before, 0).UTC()
+	}
+	return deleteByDevice(ctx, r.Collection, deviceId, "timestamp", cutoff)
+}
+
+// Ensure TelemetryMongoRepository implements TelemetryRepository interface
+var _ TelemetryRepository = &TelemetryMongoRepository{}
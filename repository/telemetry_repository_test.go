@@ -0,0 +1,219 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestTelemetryMongoRepository_InsertSamples(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	samples := []*model.TelemetrySample{
+		{DeviceID: deviceID.Hex(), Timestamp: 1700000000, BatteryLevel: 90},
+		{DeviceID: deviceID.Hex(), Timestamp: 1700000060, BatteryLevel: 89},
+	}
+
+	// Mock the InsertMany method and capture the stored documents.
+	mockAdapter.EXPECT().
+		InsertMany(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, documents []interface{}, _ ...interface{}) (*mongo.InsertManyResult, error) {
+			if len(documents) != len(samples) {
+				t.Fatalf("expected %d documents, got %d", len(samples), len(documents))
+			}
+			sampleDB := documents[1].(*model.TelemetrySampleDB)
+			if sampleDB.DeviceID != deviceID || !sampleDB.Timestamp.Equal(time.Unix(1700000060, 0)) {
+				t.Errorf("unexpected document %+v", sampleDB)
+			}
+			return &mongo.InsertManyResult{}, nil
+		}).
+		Times(1)
+
+	// Call the InsertSamples method.
+	err := repo.InsertSamples(ctx, samples)
+
+	// Check if the error is nil.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestTelemetryMongoRepository_InsertSamples_InvalidDeviceID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	// Call the InsertSamples method with an invalid device ID.
+	err := repo.InsertSamples(context.Background(), []*model.TelemetrySample{{DeviceID: "invalid"}})
+
+	// Check for an invalid argument error.
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("expected InvalidArgument error, got %v", err)
+	}
+}
+
+func TestTelemetryMongoRepository_GetTelemetryHistory_Raw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	expectedFilter := primitive.M{
+		"device_id": deviceID,
+		"timestamp": primitive.M{"$gte": time.Unix(1000, 0).UTC(), "$lt": time.Unix(2000, 0).UTC()},
+	}
+
+	// Without an interval the samples are read as stored.
+	mockAdapter.EXPECT().
+		Find(ctx, expectedFilter, gomock.Any()).
+		Return(mockCursor, nil).
+		Times(1)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.TelemetrySampleDB) = []*model.TelemetrySampleDB{
+				{DeviceID: deviceID, Timestamp: time.Unix(1500, 0), BatteryLevel: 70},
+			}
+			return nil
+		}).
+		Times(1)
+
+	// Call the GetTelemetryHistory method.
+	samples, err := repo.GetTelemetryHistory(ctx, &model.TelemetryQuery{DeviceID: deviceID.Hex(), From: 1000, To: 2000})
+
+	// Check the returned samples.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(samples) != 1 || samples[0].Timestamp != 1500 || samples[0].DeviceID != deviceID.Hex() {
+		t.Errorf("unexpected samples %+v", samples)
+	}
+}
+
+func TestTelemetryMongoRepository_GetTelemetryHistory_Downsampled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+
+	// With an interval the samples are averaged by the aggregation pipeline.
+	mockAdapter.EXPECT().
+		Aggregate(ctx, gomock.Any()).
+		Return(mockCursor, nil).
+		Times(1)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			// Decode a bucket through BSON to cover the aggregation result fields.
+			raw, err := bson.Marshal(primitive.M{
+				"_id":             time.Unix(3600, 0),
+				"device_id":       deviceID,
+				"battery_level":   55.5,
+				"charging_state":  model.ChargingCharging,
+				"signal_strength": -71.4,
+			})
+			if err != nil {
+				return err
+			}
+			buckets := reflect.ValueOf(v).Elem()
+			bucket := reflect.New(buckets.Type().Elem().Elem())
+			if err := bson.Unmarshal(raw, bucket.Interface()); err != nil {
+				return err
+			}
+			buckets.Set(reflect.Append(buckets, bucket))
+			return nil
+		}).
+		Times(1)
+
+	// Call the GetTelemetryHistory method.
+	samples, err := repo.GetTelemetryHistory(ctx, &model.TelemetryQuery{DeviceID: deviceID.Hex(), Interval: 3600})
+
+	// Check the returned buckets.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := model.TelemetrySample{DeviceID: deviceID.Hex(), Timestamp: 3600, BatteryLevel: 56, ChargingState: model.ChargingCharging, SignalStrength: -71}
+	if len(samples) != 1 || *samples[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, samples)
+	}
+}
+
+func TestTelemetryMongoRepository_GetTelemetryHistory_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	deviceID := primitive.NewObjectID().Hex()
+	queries := []*model.TelemetryQuery{
+		{DeviceID: "invalid"},
+		{DeviceID: deviceID, Limit: -1},
+		{DeviceID: deviceID, Interval: -60},
+	}
+
+	for _, query := range queries {
+		// Call the GetTelemetryHistory method with an invalid query.
+		_, err := repo.GetTelemetryHistory(context.Background(), query)
+
+		// Check for an invalid argument error.
+		if !errs.Is(err, errs.InvalidArgument) {
+			t.Errorf("expected InvalidArgument error for %+v, got %v", query, err)
+		}
+	}
+}
+
+func TestTelemetryMongoRepository_GetTelemetryHistory_FindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTelemetryMongoRepository(mockAdapter)
+
+	// Mock the Find method to return an error.
+	mockAdapter.EXPECT().
+		Find(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("find error")).
+		Times(1)
+
+	// Call the GetTelemetryHistory method.
+	_, err := repo.GetTelemetryHistory(context.Background(), &model.TelemetryQuery{DeviceID: primitive.NewObjectID().Hex()})
+
+	// Check if an error is returned.
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tripOrder is the order of trips: earliest start first.
+var tripOrder = sortOrder{Field: "start_time"}
+
+type TripRepository interface {
+	InsertTrips(ctx context.Context, trips []*model.Trip) error
+	GetTripById(ctx context.Context, id string) (*model.Trip, error)
+	ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error)
+	GetTripState(ctx context.Context, deviceId string) (*model.TripState, error)
+	SaveTripState(ctx context.Context, deviceId string, state *model.TripState) error
+}
+
+// TripMongoRepository stores completed trips and stops, and the detection in
+// progress of each device in a separate collection keyed by device ID.
+type TripMongoRepository struct {
+	Collection      Collection
+	StateCollection Collection
+}
+
+// NewTripMongoRepository returns a new TripMongoRepository.
+func NewTripMongoRepository(collection Collection, stateCollection Collection) *TripMongoRepository {
+	return &TripMongoRepository{Collection: collection, StateCollection: stateCollection}
+}
+
+// InsertTrips implements TripRepository.
+// Each trip gets an ID derived from its device, kind and start time, so a
+// segment that is detected again is not stored twice: trips that already
+// exist are skipped.
+func (r *TripMongoRepository) InsertTrips(ctx context.Context, trips []*model.Trip) error {
+	if len(trips) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(trips))
+	for _, trip := range trips {
+		tripDB, err := trip.ToTripDB()
+		if err != nil {
+			invalid := errs.InvalidField("device_id", "must be a 24 character hex ObjectID")
+			invalid.Err = err
+			return invalid
+		}
+		tripDB.ID = tripID(tripDB)
+		documents = append(documents, tripDB)
+	}
+
+	_, err := r.Collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return translateTripError(err)
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKey {
+			return translateTripError(err)
+		}
+	}
+
+	return nil
+}
+
+// tripID derives the ID of a trip from its device, kind and start time. Like
+// a generated ObjectID it begins with a timestamp, the start time, so trips
+// keep sorting by start.
+func tripID(trip *model.TripDB) primitive.ObjectID {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[:4], uint32(trip.StartTime))
+
+	sum := sha256.Sum256(append(trip.DeviceID[:], trip.Kind...))
+	copy(id[4:], sum[:])
+	return id
+}
+
+// GetTripById implements TripRepository.
+func (r *TripMongoRepository) GetTripById(ctx context.Context, id string) (*model.Trip, error) {
+	objectID, err := parseObjectID("id", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tripDB model.TripDB
+	if err := r.Collection.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&tripDB); err != nil {
+		return nil, translateTripError(err)
+	}
+
+	return tripDB.ToTrip(), nil
+}
+
+// ListTrips implements TripRepository.
+// Pages are read with keyset pagination on the start time, oldest first.
+func (r *TripMongoRepository) ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error) {
+	deviceID, err := parseObjectID("device_id", query.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := pageSize(query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := primitive.M{"device_id": deviceID}
+	if !query.IncludeStops {
+		filter["kind"] = model.TripKindTrip
+	}
+
+	startTime := primitive.M{}
+	if query.From != 0 {
+		startTime["$gte"] = query.From
+	}
+	if query.To != 0 {
+		startTime["$lt"] = query.To
+	}
+	if len(startTime) > 0 {
+		filter["start_time"] = startTime
+	}
+
+	if query.PageToken != "" {
+		cursor, err := decodePageToken(query.PageToken, tripOrder)
+		if err != nil {
+			return nil, err
+		}
+		filter = primitive.M{"$and": primitive.A{filter, tripOrder.after(cursor)}}
+	}
+
+	// Fetch one extra trip to find out whether another page follows.
+	findOptions := options.Find().SetSort(tripOrder.sort()).SetLimit(int64(size + 1))
+	cursor, err := r.Collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var tripsDB []*model.TripDB
+	if err = cursor.All(ctx, &tripsDB); err != nil {
+		return nil, err
+	}
+
+	page := &model.TripPage{}
+	if len(tripsDB) > size {
+		tripsDB = tripsDB[:size]
+		last := tripsDB[size-1]
+		page.NextPageToken, err = encodePageToken(&pageCursor{Order: tripOrder, Value: last.StartTime, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, tripDB := range tripsDB {
+		page.Trips = append(page.Trips, tripDB.ToTrip())
+	}
+
+	return page, nil
+}
+
+// GetTripState implements TripRepository.
+// A device without a saved state gets the empty state.
+func (r *TripMongoRepository) GetTripState(ctx context.Context, deviceId string) (*model.TripState, error) {
+	objectID, err := parseObjectID("device_id", deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	var state model.TripState
+	if err := r.StateCollection.FindOne(ctx, primitive.M{"_id": objectID}).Decode(&state); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &model.TripState{}, nil
+		}
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// SaveTripState implements TripRepository.
+// Every field is written, so that parts of the state that are gone are cleared.
+// The state is only written over state.Version, which is then incremented; a
+// state saved since by another detection fails with ErrTripStateConflict.
+func (r *TripMongoRepository) SaveTripState(ctx context.Context, deviceId string, state *model.TripState) error {
+	objectID, err := parseObjectID("device_id", deviceId)
+	if err != nil {
+		return err
+	}
+
+	// States saved before they were versioned match version 0.
+	filter := primitive.M{"_id": objectID, "version": state.Version}
+	if state.Version == 0 {
+		filter["version"] = primitive.M{"$exists": false}
+	}
+
+	update := primitive.M{"$set": primitive.M{
+		"current":             state.Current,
+		"pending":             state.Pending,
+		"stationary":          state.Stationary,
+		"stationary_distance": state.StationaryDistance,
+		"last":                state.Last,
+		"version":             state.Version + 1,
+	}}
+
+	// A state of another version is not matched, so the upsert collides with it on _id.
+	_, err = r.StateCollection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrTripStateConflict
+	}
+	if err != nil {
+		return err
+	}
+
+	state.Version++
+	return nil
+}
+
+// DeleteDeviceHistory implements DeviceHistory.
+// The trips that started by before are deleted together with the detection
+// state, so detection starts over with the next location. A state that later
+// locations have already continued is kept.
+func (r *TripMongoRepository) DeleteDeviceHistory(ctx context.Context, deviceId string, before int64) error {
+	if err := deleteByDevice(ctx, r.Collection, deviceId, "start_time", historyCutoff(before)); err != nil {
+		return err
+	}
+
+	objectID, err := parseObjectID("device_id", deviceId)
+	if err != nil {
+		return err
+	}
+
+	filter := primitive.M{"_id": objectID}
+	if before != 0 {
+		filter["last.time"] = primitive.M{"$lte": before}
+	}
+
+	_, err = r.StateCollection.DeleteOne(ctx, filter)
+	return err
+}
+
+// translateTripError converts MongoDB driver errors of trip queries into typed errors.
+func translateTripError(err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return ErrTripNotFound
+	}
+
+	return translateError(err)
+}
+
+// Ensure TripMongoRepository implements TripRepository interface
+var _ TripRepository = &TripMongoRepository{}
@@ -0,0 +1,293 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock "github.com/BerryTracer/common-service/adapter/database/mongodb/mock"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	mock_repository "github.com/BerryTracer/device-service/repository/mock"
+	"github.com/golang/mock/gomock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestTripMongoRepository_ListTrips(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockCursor := mock.NewMockCursor(ctrl)
+	repo := repository.NewTripMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	tripsDB := []*model.TripDB{
+		{ID: primitive.NewObjectID(), DeviceID: deviceID, Kind: model.TripKindTrip, StartTime: 100, EndTime: 200, Distance: 1500},
+		{ID: primitive.NewObjectID(), DeviceID: deviceID, Kind: model.TripKindTrip, StartTime: 300, EndTime: 400, Distance: 800},
+	}
+
+	// Stops are left out unless asked for.
+	mockAdapter.EXPECT().
+		Find(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter interface{}, _ ...interface{}) (*mock.MockCursor, error) {
+			m := filter.(primitive.M)
+			if m["device_id"] != deviceID || m["kind"] != model.TripKindTrip || m["start_time"].(primitive.M)["$lt"] != int64(1000) {
+				t.Errorf("unexpected filter %v", filter)
+			}
+			return mockCursor, nil
+		}).
+		Times(1)
+
+	mockCursor.EXPECT().
+		All(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, v interface{}) error {
+			*v.(*[]*model.TripDB) = tripsDB
+			return nil
+		}).
+		Times(1)
+
+	// Call the ListTrips method with a page that fits all trips.
+	page, err := repo.ListTrips(ctx, &model.TripQuery{DeviceID: deviceID.Hex(), To: 1000, PageSize: 2})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(page.Trips) != 2 || page.NextPageToken != "" || page.Trips[0].DeviceID != deviceID.Hex() || page.Trips[1].Distance != 800 {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
+
+func TestTripMongoRepository_InsertTrips(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTripMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID().Hex()
+	trips := []*model.Trip{
+		{DeviceID: deviceID, Kind: model.TripKindStop, StartTime: 600, EndTime: 700},
+		{DeviceID: deviceID, Kind: model.TripKindTrip, StartTime: 700, EndTime: 1100},
+	}
+
+	// The same segments get the same IDs each time they are inserted.
+	var ids [][]primitive.ObjectID
+	mockAdapter.EXPECT().
+		InsertMany(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, documents []interface{}, _ ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+			var inserted []primitive.ObjectID
+			for _, document := range documents {
+				inserted = append(inserted, document.(*model.TripDB).ID)
+			}
+			ids = append(ids, inserted)
+			return nil, mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{
+				{WriteError: mongo.WriteError{Index: 0, Code: 11000, Message: "E11000 duplicate key error"}},
+			}}
+		}).
+		Times(2)
+
+	// Call the InsertTrips method twice; the trips already stored are skipped.
+	for i := 0; i < 2; i++ {
+		if err := repo.InsertTrips(ctx, trips); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if ids[0][0] == ids[0][1] || ids[0][0] != ids[1][0] || ids[0][1] != ids[1][1] || ids[0][0].IsZero() {
+		t.Errorf("expected distinct IDs that repeat for the same trips, got %v", ids)
+	}
+
+	if ids[0][0].Timestamp().Unix() != 600 {
+		t.Errorf("expected the ID to begin with the start time, got %v", ids[0][0].Timestamp())
+	}
+}
+
+func TestTripMongoRepository_GetTripById_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAdapter := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewTripMongoRepository(mockAdapter, mock_repository.NewMockCollection(ctrl))
+
+	ctx := context.Background()
+	objectID := primitive.NewObjectID()
+
+	// Mock the FindOne method to find nothing.
+	mockAdapter.EXPECT().
+		FindOne(ctx, primitive.M{"_id": objectID}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetTripById method.
+	_, err := repo.GetTripById(ctx, objectID.Hex())
+
+	// Check for the trip not found error.
+	if !errors.Is(err, repository.ErrTripNotFound) {
+		t.Errorf("expected ErrTripNotFound, got %v", err)
+	}
+}
+
+func TestTripMongoRepository_GetTripState_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStates := mock_repository.NewMockCollection(ctrl)
+	mockSingleResult := mock.NewMockSingleResult(ctrl)
+	repo := repository.NewTripMongoRepository(mock_repository.NewMockCollection(ctrl), mockStates)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+
+	// A device that never reported a location has no state yet.
+	mockStates.EXPECT().
+		FindOne(ctx, primitive.M{"_id": deviceID}).
+		Return(mockSingleResult).
+		Times(1)
+
+	mockSingleResult.EXPECT().
+		Decode(gomock.Any()).
+		Return(mongo.ErrNoDocuments).
+		Times(1)
+
+	// Call the GetTripState method.
+	state, err := repo.GetTripState(ctx, deviceID.Hex())
+
+	// Check that the empty state is returned.
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if state == nil || state.Last != nil || state.Current != nil {
+		t.Errorf("expected the empty state, got %+v", state)
+	}
+}
+
+func TestTripMongoRepository_SaveTripState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStates := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTripMongoRepository(mock_repository.NewMockCollection(ctrl), mockStates)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+	last := &model.TripPoint{Latitude: 1, Longitude: 2, Time: 100}
+	state := &model.TripState{Current: &model.TripSegment{Kind: model.TripKindStop, Start: *last, End: *last}, Last: last, Version: 4}
+
+	// The state is upserted over the version it was read at, clearing the parts that are gone.
+	mockStates.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": deviceID, "version": int64(4)}, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+			set := update.(primitive.M)["$set"].(primitive.M)
+			if set["last"] != last || set["pending"] != (*model.TripSegment)(nil) || set["version"] != int64(5) {
+				t.Errorf("unexpected update %v", update)
+			}
+			if len(opts) != 1 || opts[0].Upsert == nil || !*opts[0].Upsert {
+				t.Errorf("expected an upsert, got %v", opts)
+			}
+			return &mongo.UpdateResult{UpsertedCount: 1}, nil
+		}).
+		Times(1)
+
+	// Call the SaveTripState method.
+	if err := repo.SaveTripState(ctx, deviceID.Hex(), state); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if state.Version != 5 {
+		t.Errorf("expected the saved version 5, got %d", state.Version)
+	}
+}
+
+func TestTripMongoRepository_SaveTripState_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStates := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTripMongoRepository(mock_repository.NewMockCollection(ctrl), mockStates)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+
+	// Another detection saved the first state, so the upsert collides with it.
+	mockStates.EXPECT().
+		UpdateOne(ctx, primitive.M{"_id": deviceID, "version": primitive.M{"$exists": false}}, gomock.Any(), gomock.Any()).
+		Return(nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "E11000 duplicate key error"}}}).
+		Times(1)
+
+	// Call the SaveTripState method.
+	err := repo.SaveTripState(ctx, deviceID.Hex(), &model.TripState{})
+
+	// Check for the conflict error.
+	if !errors.Is(err, repository.ErrTripStateConflict) {
+		t.Errorf("expected ErrTripStateConflict, got %v", err)
+	}
+}
+
+func TestTripMongoRepository_DeleteDeviceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrips := mock_repository.NewMockCollection(ctrl)
+	mockStates := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTripMongoRepository(mockTrips, mockStates)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+
+	// The trips and the detection state of the device are both deleted.
+	mockTrips.EXPECT().
+		DeleteMany(ctx, primitive.M{"device_id": deviceID}).
+		Return(&mongo.DeleteResult{DeletedCount: 3}, nil).
+		Times(1)
+
+	mockStates.EXPECT().
+		DeleteOne(ctx, primitive.M{"_id": deviceID}).
+		Return(&mongo.DeleteResult{DeletedCount: 1}, nil).
+		Times(1)
+
+	// Call the DeleteDeviceHistory method.
+	if err := repo.DeleteDeviceHistory(ctx, deviceID.Hex(), 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestTripMongoRepository_DeleteDeviceHistory_Before(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTrips := mock_repository.NewMockCollection(ctrl)
+	mockStates := mock_repository.NewMockCollection(ctrl)
+	repo := repository.NewTripMongoRepository(mockTrips, mockStates)
+
+	ctx := context.Background()
+	deviceID := primitive.NewObjectID()
+
+	// A wipe retried after the device has reported to its new owner leaves
+	// the trips started since, and the detection they continue, alone.
+	mockTrips.EXPECT().
+		DeleteMany(ctx, primitive.M{"device_id": deviceID, "start_time": primitive.M{"$lte": int64(200)}}).
+		Return(&mongo.DeleteResult{DeletedCount: 3}, nil).
+		Times(1)
+
+	mockStates.EXPECT().
+		DeleteOne(ctx, primitive.M{"_id": deviceID, "last.time": primitive.M{"$lte": int64(200)}}).
+		Return(&mongo.DeleteResult{}, nil).
+		Times(1)
+
+	// Call the DeleteDeviceHistory method.
+	if err := repo.DeleteDeviceHistory(ctx, deviceID.Hex(), 200); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
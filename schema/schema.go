@@ -0,0 +1,275 @@
+// Package schema validates JSON values against a JSON Schema. It implements the
+// subset of JSON Schema (draft 2020-12) that describes flat or nested metadata
+// such as device attributes: type, enum, const, the numeric and string bounds,
+// pattern, properties, required, additionalProperties, items and the item
+// bounds. Keywords outside this subset are rejected when a schema is compiled,
+// so a schema never appears to enforce more than it does. Patterns are RE2
+// regular expressions, as in package regexp, rather than ECMA-262 ones: they
+// match anywhere in the string unless anchored, and lookarounds and
+// backreferences are rejected. It has no dependencies on storage or transport.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	never bool // The schema false, which no value satisfies
+
+	types      []string
+	enum       []interface{}
+	constValue interface{}
+	hasConst   bool
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+
+	items    *Schema
+	minItems *int
+	maxItems *int
+}
+
+// annotations are keywords that describe a schema without constraining values.
+var annotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+}
+
+// types are the values of the type keyword.
+var types = map[string]bool{
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+// Compile parses a JSON Schema document.
+func Compile(document []byte) (*Schema, error) {
+	var node interface{}
+	if err := json.Unmarshal(document, &node); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %v", err)
+	}
+
+	return compile(node, "")
+}
+
+func compile(node interface{}, path string) (*Schema, error) {
+	switch node := node.(type) {
+	case bool:
+		return &Schema{never: !node}, nil
+	case map[string]interface{}:
+		return compileObject(node, path)
+	default:
+		return nil, schemaErrorf(path, "", "must be an object or a boolean")
+	}
+}
+
+func compileObject(node map[string]interface{}, path string) (*Schema, error) {
+	s := &Schema{}
+
+	for _, keyword := range sortedKeys(node) {
+		value := node[keyword]
+		var err error
+
+		switch keyword {
+		case "type":
+			s.types, err = compileTypes(value, path)
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok || len(values) == 0 {
+				err = schemaErrorf(path, keyword, "must be a non-empty array")
+			}
+			s.enum = values
+		case "const":
+			s.constValue, s.hasConst = value, true
+		case "minimum":
+			s.minimum, err = compileNumber(value, path, keyword)
+		case "maximum":
+			s.maximum, err = compileNumber(value, path, keyword)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = compileNumber(value, path, keyword)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = compileNumber(value, path, keyword)
+		case "minLength":
+			s.minLength, err = compileCount(value, path, keyword)
+		case "maxLength":
+			s.maxLength, err = compileCount(value, path, keyword)
+		case "pattern":
+			source, ok := value.(string)
+			if !ok {
+				err = schemaErrorf(path, keyword, "must be a string")
+				break
+			}
+			if s.pattern, err = regexp.Compile(source); err != nil {
+				err = schemaErrorf(path, keyword, "is not a valid RE2 regular expression, which has no lookarounds or backreferences: %v", err)
+			}
+		case "properties":
+			s.properties, err = compileProperties(value, path)
+		case "required":
+			s.required, err = compileRequired(value, path)
+		case "additionalProperties":
+			s.additionalProperties, err = compile(value, childPath(path, keyword))
+		case "items":
+			s.items, err = compile(value, childPath(path, keyword))
+		case "minItems":
+			s.minItems, err = compileCount(value, path, keyword)
+		case "maxItems":
+			s.maxItems, err = compileCount(value, path, keyword)
+		default:
+			if !annotations[keyword] {
+				err = schemaErrorf(path, keyword, "is not a supported keyword")
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func compileTypes(value interface{}, path string) ([]string, error) {
+	var names []interface{}
+	switch value := value.(type) {
+	case string:
+		names = []interface{}{value}
+	case []interface{}:
+		names = value
+	}
+
+	if len(names) == 0 {
+		return nil, schemaErrorf(path, "type", "must be a type name or a non-empty array of type names")
+	}
+
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		typeName, ok := name.(string)
+		if !ok || !types[typeName] {
+			return nil, schemaErrorf(path, "type", "has unknown type %v", name)
+		}
+		result = append(result, typeName)
+	}
+
+	return result, nil
+}
+
+func compileNumber(value interface{}, path, keyword string) (*float64, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return nil, schemaErrorf(path, keyword, "must be a number")
+	}
+	return &number, nil
+}
+
+func compileCount(value interface{}, path, keyword string) (*int, error) {
+	number, ok := value.(float64)
+	if !ok || number < 0 || number != math.Trunc(number) {
+		return nil, schemaErrorf(path, keyword, "must be a non-negative integer")
+	}
+	count := int(number)
+	return &count, nil
+}
+
+func compileProperties(value interface{}, path string) (map[string]*Schema, error) {
+	node, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, schemaErrorf(path, "properties", "must be an object")
+	}
+
+	properties := make(map[string]*Schema, len(node))
+	for _, name := range sortedKeys(node) {
+		property, err := compile(node[name], childPath(childPath(path, "properties"), name))
+		if err != nil {
+			return nil, err
+		}
+		properties[name] = property
+	}
+
+	return properties, nil
+}
+
+func compileRequired(value interface{}, path string) ([]string, error) {
+	names, ok := value.([]interface{})
+	if !ok {
+		return nil, schemaErrorf(path, "required", "must be an array of property names")
+	}
+
+	required := make([]string, 0, len(names))
+	for _, name := range names {
+		property, ok := name.(string)
+		if !ok {
+			return nil, schemaErrorf(path, "required", "must be an array of property names")
+		}
+		required = append(required, property)
+	}
+
+	return required, nil
+}
+
+// schemaErrorf reports a problem with a keyword of the schema at path.
+func schemaErrorf(path, keyword, format string, args ...interface{}) error {
+	location := childPath(path, keyword)
+	if location == "" {
+		location = "schema"
+	}
+	return fmt.Errorf("%s %s", location, fmt.Sprintf(format, args...))
+}
+
+// childPath appends a property name to a dotted path.
+func childPath(path, name string) string {
+	switch {
+	case name == "":
+		return path
+	case path == "":
+		return name
+	default:
+		return path + "." + name
+	}
+}
+
+func sortedKeys(node map[string]interface{}) []string {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describe formats a value of an enum or const for an error message.
+func describe(values []interface{}) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		parts = append(parts, string(encoded))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,114 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/BerryTracer/device-service/schema"
+)
+
+const cellularSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Cellular unit",
+	"type": "object",
+	"required": ["imei"],
+	"properties": {
+		"imei": {"type": "string", "pattern": "^[0-9]{15}$"},
+		"carrier": {"enum": ["vodafone", "telekom"]},
+		"antennas": {"type": "integer", "minimum": 1, "maximum": 4},
+		"bands": {"type": "array", "items": {"type": "integer"}, "maxItems": 3},
+		"sim": {
+			"type": "object",
+			"properties": {"iccid": {"type": "string", "minLength": 19, "maxLength": 20}},
+			"additionalProperties": false
+		}
+	}
+}`
+
+func decode(t *testing.T, document string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatalf("invalid test document %s: %v", document, err)
+	}
+	return value
+}
+
+func TestSchema_Validate(t *testing.T) {
+	s, err := schema.Compile([]byte(cellularSchema))
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		document string
+		want     []string
+	}{
+		{"valid", `{"imei": "490154203237518", "carrier": "telekom", "antennas": 2, "bands": [3, 20], "sim": {"iccid": "8949020000012345678"}}`, nil},
+		{"undeclared properties are allowed", `{"imei": "490154203237518", "firmware": "1.2"}`, nil},
+		{"missing required", `{}`, []string{"imei is required"}},
+		{"wrong type", `[]`, []string{"must be an object"}},
+		{"pattern", `{"imei": "4901-5420"}`, []string{"imei must match the pattern ^[0-9]{15}$"}},
+		{"enum", `{"imei": "490154203237518", "carrier": "acme"}`, []string{`carrier must be one of "vodafone", "telekom"`}},
+		{"integer", `{"imei": "490154203237518", "antennas": 1.5}`, []string{"antennas must be an integer"}},
+		{"maximum", `{"imei": "490154203237518", "antennas": 5}`, []string{"antennas must be at most 4"}},
+		{"items", `{"imei": "490154203237518", "bands": [3, "20", 8, 1]}`, []string{"bands must have at most 3 items", "bands[1] must be an integer"}},
+		{"nested", `{"imei": "490154203237518", "sim": {"iccid": "89", "pin": "0000"}}`, []string{"sim.iccid must be at least 19 characters long", "sim.pin is not allowed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, violation := range s.Validate(decode(t, tt.document)) {
+				got = append(got, violation.Error())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %q, want %q", tt.document, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_Validate_GoNumbers(t *testing.T) {
+	s, err := schema.Compile([]byte(`{"properties": {"count": {"type": "integer", "enum": [1, 2]}}}`))
+	if err != nil {
+		t.Fatalf("Compile returned error: %v", err)
+	}
+
+	// Values read back from the database carry Go integer types.
+	if violations := s.Validate(map[string]interface{}{"count": int32(2)}); len(violations) != 0 {
+		t.Errorf("expected an int32 to be an integer, got %v", violations)
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := []struct {
+		document string
+		want     string
+	}{
+		{`{"type": "object"`, "not valid JSON"},
+		{`"object"`, "schema must be an object or a boolean"},
+		{`{"type": "text"}`, "type has unknown type text"},
+		{`{"pattern": "("}`, "pattern is not a valid RE2 regular expression"},
+		{`{"pattern": "^(?!0)[0-9]+$"}`, "pattern is not a valid RE2 regular expression"},
+		{`{"items": [{"type": "string"}]}`, "items must be an object or a boolean"},
+		{`{"minLength": -1}`, "minLength must be a non-negative integer"},
+		{`{"enum": []}`, "enum must be a non-empty array"},
+		{`{"properties": {"imei": {"format": "imei"}}}`, "properties.imei.format is not a supported keyword"},
+		{`{"$ref": "#/definitions/imei"}`, "$ref is not a supported keyword"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.document, func(t *testing.T) {
+			_, err := schema.Compile([]byte(tt.document))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile(%s) = %v, want an error containing %q", tt.document, err, tt.want)
+			}
+		})
+	}
+}
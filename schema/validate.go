@@ -0,0 +1,249 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError is a value that does not satisfy a schema.
+type ValidationError struct {
+	Path    string // Dotted path of the value, e.g. "sim.iccid" or "sensors[0]"; empty for the root
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + " " + e.Message
+}
+
+// Validate checks value against s and returns every violation, ordered by
+// path. value is a decoded JSON value: nil, bool, a number, string,
+// []interface{} or map[string]interface{}. Numbers may be of any Go integer or
+// float type.
+func (s *Schema) Validate(value interface{}) []ValidationError {
+	v := &validator{}
+	v.validate(s, value, "")
+	sort.SliceStable(v.errors, func(i, j int) bool { return v.errors[i].Path < v.errors[j].Path })
+	return v.errors
+}
+
+type validator struct {
+	errors []ValidationError
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) validate(s *Schema, value interface{}, path string) {
+	if s.never {
+		v.errorf(path, "is not allowed")
+		return
+	}
+
+	if len(s.types) > 0 && !hasType(s.types, value) {
+		v.errorf(path, "must be %s", typeList(s.types))
+		return
+	}
+
+	if s.hasConst && !equal(value, s.constValue) {
+		v.errorf(path, "must be %s", describe([]interface{}{s.constValue}))
+	}
+
+	if len(s.enum) > 0 && !contains(s.enum, value) {
+		v.errorf(path, "must be one of %s", describe(s.enum))
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.validateString(s, value, path)
+	case []interface{}:
+		v.validateArray(s, value, path)
+	case map[string]interface{}:
+		v.validateObject(s, value, path)
+	default:
+		if number, ok := toNumber(value); ok {
+			v.validateNumber(s, number, path)
+		}
+	}
+}
+
+func (v *validator) validateNumber(s *Schema, number float64, path string) {
+	if s.minimum != nil && number < *s.minimum {
+		v.errorf(path, "must be at least %v", *s.minimum)
+	}
+	if s.maximum != nil && number > *s.maximum {
+		v.errorf(path, "must be at most %v", *s.maximum)
+	}
+	if s.exclusiveMinimum != nil && number <= *s.exclusiveMinimum {
+		v.errorf(path, "must be greater than %v", *s.exclusiveMinimum)
+	}
+	if s.exclusiveMaximum != nil && number >= *s.exclusiveMaximum {
+		v.errorf(path, "must be less than %v", *s.exclusiveMaximum)
+	}
+}
+
+func (v *validator) validateString(s *Schema, value string, path string) {
+	length := utf8.RuneCountInString(value)
+	if s.minLength != nil && length < *s.minLength {
+		v.errorf(path, "must be at least %d characters long", *s.minLength)
+	}
+	if s.maxLength != nil && length > *s.maxLength {
+		v.errorf(path, "must be at most %d characters long", *s.maxLength)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		v.errorf(path, "must match the pattern %s", s.pattern)
+	}
+}
+
+func (v *validator) validateArray(s *Schema, values []interface{}, path string) {
+	if s.minItems != nil && len(values) < *s.minItems {
+		v.errorf(path, "must have at least %d items", *s.minItems)
+	}
+	if s.maxItems != nil && len(values) > *s.maxItems {
+		v.errorf(path, "must have at most %d items", *s.maxItems)
+	}
+	if s.items != nil {
+		for i, item := range values {
+			v.validate(s.items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, object map[string]interface{}, path string) {
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			v.errorf(childPath(path, name), "is required")
+		}
+	}
+
+	for _, name := range sortedKeys(object) {
+		if property, ok := s.properties[name]; ok {
+			v.validate(property, object[name], childPath(path, name))
+		} else if s.additionalProperties != nil {
+			v.validate(s.additionalProperties, object[name], childPath(path, name))
+		}
+	}
+}
+
+// hasType reports whether value is of one of the JSON types.
+func hasType(types []string, value interface{}) bool {
+	for _, typeName := range types {
+		switch typeName {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "number":
+			if _, ok := toNumber(value); ok {
+				return true
+			}
+		case "integer":
+			if number, ok := toNumber(value); ok && number == math.Trunc(number) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeList(types []string) string {
+	articles := make([]string, 0, len(types))
+	for _, typeName := range types {
+		switch typeName {
+		case "null":
+			articles = append(articles, "null")
+		case "array", "object", "integer":
+			articles = append(articles, "an "+typeName)
+		default:
+			articles = append(articles, "a "+typeName)
+		}
+	}
+	return strings.Join(articles, " or ")
+}
+
+// toNumber converts the numeric types a decoded value may have to float64.
+func toNumber(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	default:
+		return 0, false
+	}
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if equal(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares decoded JSON values, treating numbers of different Go types
+// as equal when their values are.
+func equal(a, b interface{}) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+
+	switch a := a.(type) {
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,180 @@
+package selector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses a comma separated list of requirements. Each requirement is one of
+//
+//	key              the label is set
+//	!key             the label is not set
+//	key=value        the label is set to value; key==value is the same
+//	key!=value       the label is not set to value, or not set at all
+//	key in (a,b)     the label is set to one of the values
+//	key notin (a,b)  the label is not set to any of the values, or not set at all
+//
+// Whitespace around keys, values and operators is ignored. An empty string
+// parses into an empty selector.
+func Parse(input string) (Selector, error) {
+	p := &parser{input: input}
+
+	var selector Selector
+	p.skipSpace()
+	if p.done() {
+		return selector, nil
+	}
+
+	for {
+		requirement, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		selector = append(selector, requirement)
+
+		p.skipSpace()
+		if p.done() {
+			return selector, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' after %q", requirement.String())
+		}
+		p.skipSpace()
+	}
+}
+
+// parser reads a selector from left to right.
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) requirement() (Requirement, error) {
+	if p.consume("!") {
+		p.skipSpace()
+		key, err := p.key()
+		if err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: DoesNotExist}, nil
+	}
+
+	key, err := p.key()
+	if err != nil {
+		return Requirement{}, err
+	}
+	p.skipSpace()
+
+	switch {
+	case p.done() || p.peek(","):
+		return Requirement{Key: key, Operator: Exists}, nil
+	case p.consume("!="):
+		value, err := p.value()
+		return Requirement{Key: key, Operator: NotEquals, Values: []string{value}}, err
+	case p.consume("=="), p.consume("="):
+		value, err := p.value()
+		return Requirement{Key: key, Operator: Equals, Values: []string{value}}, err
+	}
+
+	start := p.pos
+	switch word := p.word(); word {
+	case string(In), string(NotIn):
+		values, err := p.set()
+		return Requirement{Key: key, Operator: Operator(word), Values: values}, err
+	default:
+		p.pos = start
+		return Requirement{}, p.errorf("expected an operator after %q", key)
+	}
+}
+
+// key reads and validates a label key.
+func (p *parser) key() (string, error) {
+	start := p.pos
+	key := p.word()
+	if key == "" {
+		return "", p.errorf("expected a label key")
+	}
+	if err := ValidateKey(key); err != nil {
+		return "", &SyntaxError{Selector: p.input, Pos: start, Msg: err.Error()}
+	}
+	return key, nil
+}
+
+// value reads and validates a label value, which may be empty.
+func (p *parser) value() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	value := p.word()
+	if err := ValidateValue(value); err != nil {
+		return "", &SyntaxError{Selector: p.input, Pos: start, Msg: err.Error()}
+	}
+	return value, nil
+}
+
+// set reads a parenthesized, comma separated list of at least one value.
+func (p *parser) set() ([]string, error) {
+	p.skipSpace()
+	if !p.consume("(") {
+		return nil, p.errorf("expected '('")
+	}
+	p.skipSpace()
+	if p.peek(")") {
+		return nil, p.errorf("expected at least one value")
+	}
+
+	var values []string
+	for {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		p.skipSpace()
+		if p.consume(")") {
+			return values, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+// word reads the longest run of characters that may appear in a key or value.
+func (p *parser) word() string {
+	start := p.pos
+	for !p.done() && isWordChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *parser) peek(token string) bool {
+	return strings.HasPrefix(p.input[p.pos:], token)
+}
+
+func (p *parser) consume(token string) bool {
+	if !p.peek(token) {
+		return false
+	}
+	p.pos += len(token)
+	return true
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) errorf(format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{Selector: p.input, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,139 @@
+// Package selector parses label selectors in the syntax of Kubernetes, such as
+// "env=prod,plate!=X,!retired,tier in (gold,silver)", and checks label keys and
+// values. A selector is a list of requirements that must all hold. It has no
+// dependencies on storage or transport.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxLength is the longest label key or value.
+const MaxLength = 63
+
+// Operator is how a requirement compares the value of a label.
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single condition on the label with Key. Equals and
+// NotEquals have one value, In and NotIn at least one, and Exists and
+// DoesNotExist none.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Selector is a list of requirements that must all hold. An empty selector
+// matches everything.
+type Selector []Requirement
+
+// SyntaxError reports where a selector could not be parsed.
+type SyntaxError struct {
+	Selector string
+	Pos      int // Byte offset in Selector
+	Msg      string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+var (
+	// Keys become part of document field paths, so they cannot contain dots.
+	keyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_-]*[A-Za-z0-9])?$`)
+	valuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?)?$`)
+)
+
+// ValidateKey checks that key can name a label: up to MaxLength letters,
+// digits, '-' and '_', starting and ending with a letter or digit.
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("label key must not be empty")
+	}
+	if len(key) > MaxLength || !keyPattern.MatchString(key) {
+		return fmt.Errorf("label key %q must be at most %d letters, digits, '-' or '_', starting and ending with a letter or digit", key, MaxLength)
+	}
+	return nil
+}
+
+// ValidateValue checks that value can be the value of a label: empty, or up to
+// MaxLength letters, digits, '-', '_' and '.', starting and ending with a
+// letter or digit.
+func ValidateValue(value string) error {
+	if len(value) > MaxLength || !valuePattern.MatchString(value) {
+		return fmt.Errorf("label value %q must be at most %d letters, digits, '-', '_' or '.', starting and ending with a letter or digit", value, MaxLength)
+	}
+	return nil
+}
+
+// Matches reports whether labels satisfy every requirement of s.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether labels satisfy r. A label that is missing satisfies
+// NotEquals and NotIn.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+
+	switch r.Operator {
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	case Equals, In:
+		return ok && contains(r.Values, value)
+	case NotEquals, NotIn:
+		return !ok || !contains(r.Values, value)
+	default:
+		return false
+	}
+}
+
+// String returns s in selector syntax.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, requirement := range s {
+		parts = append(parts, requirement.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// String returns r in selector syntax.
+func (r Requirement) String() string {
+	switch r.Operator {
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return "!" + r.Key
+	case In, NotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	default:
+		return r.Key + string(r.Operator) + strings.Join(r.Values, "")
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
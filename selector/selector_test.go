@@ -0,0 +1,119 @@
+package selector_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/BerryTracer/device-service/selector"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  selector.Selector
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"env=prod", selector.Selector{{Key: "env", Operator: selector.Equals, Values: []string{"prod"}}}},
+		{"env == prod", selector.Selector{{Key: "env", Operator: selector.Equals, Values: []string{"prod"}}}},
+		{"env=", selector.Selector{{Key: "env", Operator: selector.Equals, Values: []string{""}}}},
+		{"env=prod,plate!=X,!retired", selector.Selector{
+			{Key: "env", Operator: selector.Equals, Values: []string{"prod"}},
+			{Key: "plate", Operator: selector.NotEquals, Values: []string{"X"}},
+			{Key: "retired", Operator: selector.DoesNotExist},
+		}},
+		{"customer, tier in (gold, silver.2)", selector.Selector{
+			{Key: "customer", Operator: selector.Exists},
+			{Key: "tier", Operator: selector.In, Values: []string{"gold", "silver.2"}},
+		}},
+		{"tier notin (free)", selector.Selector{{Key: "tier", Operator: selector.NotIn, Values: []string{"free"}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := selector.Parse(test.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", test.input, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{"env=prod,", 9},
+		{"=prod", 0},
+		{"env prod", 4},
+		{"env=prod plate=X", 9},
+		{"tier in gold", 8},
+		{"tier in (gold", 13},
+		{"tier in ()", 9},
+		{"dotted.key=x", 0},
+		{"env=-prod", 4},
+		{"!", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			_, err := selector.Parse(test.input)
+
+			var syntaxErr *selector.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) = %v, want a syntax error", test.input, err)
+			}
+			if syntaxErr.Pos != test.pos {
+				t.Errorf("Parse(%q) reported position %d, want %d: %v", test.input, syntaxErr.Pos, test.pos, err)
+			}
+		})
+	}
+}
+
+func TestSelector_Matches(t *testing.T) {
+	labels := map[string]string{"env": "prod", "tier": "gold"}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env!=prod", false},
+		{"plate!=X", true},
+		{"!retired", true},
+		{"!env", false},
+		{"tier in (gold,silver)", true},
+		{"tier notin (gold)", false},
+		{"region notin (eu)", true},
+		{"env=prod,region", false},
+	}
+
+	for _, test := range tests {
+		sel, err := selector.Parse(test.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", test.input, err)
+		}
+		if got := sel.Matches(labels); got != test.want {
+			t.Errorf("%q matches %v = %v, want %v", test.input, labels, got, test.want)
+		}
+	}
+}
+
+func TestSelector_String(t *testing.T) {
+	input := "env=prod,plate!=X,!retired,customer,tier in (gold,silver)"
+
+	sel, err := selector.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", input, err)
+	}
+
+	if got := sel.String(); got != input {
+		t.Errorf("String() = %q, want %q", got, input)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"github.com/BerryTracer/device-service/auth"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+var (
+	// ErrUnauthenticated is returned when the context carries no caller identity.
+	ErrUnauthenticated = errs.New(errs.Unauthenticated, "MISSING_IDENTITY", "no caller identity in context")
+	// ErrPermissionDenied is returned when the caller does not own the device.
+	ErrPermissionDenied = errs.New(errs.PermissionDenied, "DEVICE_ACCESS_DENIED", "device belongs to another user")
+	// ErrRoleRequired is returned when the device is shared with the caller with a role that does not allow the call.
+	ErrRoleRequired = errs.New(errs.PermissionDenied, "DEVICE_ROLE_REQUIRED", "device is shared with the caller with a role that does not allow this")
+	// ErrAdminRequired is returned when a call is reserved for admins.
+	ErrAdminRequired = errs.New(errs.PermissionDenied, "ADMIN_REQUIRED", "only admins may call this method")
+)
+
+// callerIdentity returns the identity of the caller stored in ctx.
+func callerIdentity(ctx context.Context) (*auth.Identity, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return identity, nil
+}
+
+// authorizeOwner checks that the caller owns resources of ownerID or is an admin.
+// It guards resources that belong to a user rather than to a device. Devices
+// never act as their owner; they may only access themselves through authorizeRole.
+func authorizeOwner(ctx context.Context, ownerID string) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.IsDevice() || (identity.UserID != ownerID && !identity.IsAdmin()) {
+		return ErrPermissionDenied
+	}
+
+	return nil
+}
+
+// authorizeAdmin checks that the caller is an admin user.
+func authorizeAdmin(ctx context.Context) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.IsDevice() || !identity.IsAdmin() {
+		return ErrAdminRequired
+	}
+
+	return nil
+}
+
+// authorizeRole checks that the caller holds at least role on device, with the
+// shares that have not expired at the time of clock. Admins hold every role. A
+// device calling with its own key acts as an editor of itself and has no access
+// to other devices.
+func authorizeRole(ctx context.Context, clock Clock, device *model.Device, role model.DeviceRole) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if identity.IsDevice() {
+		if identity.DeviceID != device.ID || !model.RoleEditor.Includes(role) {
+			return ErrPermissionDenied
+		}
+		return nil
+	}
+
+	if identity.IsAdmin() {
+		return nil
+	}
+
+	callerRole := device.RoleOf(identity.UserID, clock().Unix())
+	if callerRole == "" {
+		return ErrPermissionDenied
+	}
+	if !callerRole.Includes(role) {
+		return ErrRoleRequired
+	}
+
+	return nil
+}
+
+// authorizeDevice looks up a device and checks that the caller holds at least role on it.
+func authorizeDevice(ctx context.Context, deviceRepository repository.DeviceRepository, clock Clock, deviceID string, role model.DeviceRole) (*model.Device, error) {
+	device, err := deviceRepository.GetDeviceById(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeRole(ctx, clock, device, role); err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/BerryTracer/device-service/auth"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// deviceKeyPrefix starts every device key, so that keys are easy to recognise
+// in configuration files and by secret scanners.
+const deviceKeyPrefix = "btdk_"
+
+// ErrInvalidDeviceKey is returned when a device key does not belong to any device.
+var ErrInvalidDeviceKey = errs.New(errs.Unauthenticated, "INVALID_DEVICE_KEY", "device key is invalid or revoked")
+
+type CredentialService interface {
+	RotateDeviceKey(ctx context.Context, deviceId string) (string, *model.DeviceCredential, error)
+	RevokeDeviceKey(ctx context.Context, deviceId string) error
+	AuthenticateDevice(ctx context.Context, key string) (*auth.Identity, error)
+}
+
+// CredentialServiceImpl manages the keys devices authenticate their own calls
+// with. Keys are issued when a device is created; only their owner or an admin
+// can rotate or revoke them.
+type CredentialServiceImpl struct {
+	DeviceRepository repository.DeviceRepository
+	Clock            Clock
+}
+
+// NewCredentialService returns a new CredentialServiceImpl that uses the system clock.
+func NewCredentialService(deviceRepository repository.DeviceRepository) *CredentialServiceImpl {
+	return &CredentialServiceImpl{
+		DeviceRepository: deviceRepository,
+		Clock:            time.Now,
+	}
+}
+
+// RotateDeviceKey implements CredentialService.
+// The device gets a new key, which is returned once; the previous key stops working.
+func (s *CredentialServiceImpl) RotateDeviceKey(ctx context.Context, deviceId string) (string, *model.DeviceCredential, error) {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, model.RoleOwner); err != nil {
+		return "", nil, err
+	}
+
+	key, credential, err := newDeviceKey(s.Clock())
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := s.DeviceRepository.SetDeviceCredential(ctx, deviceId, credential); err != nil {
+		return "", nil, err
+	}
+
+	return key, credential, nil
+}
+
+// RevokeDeviceKey implements CredentialService.
+// Revoking a device without a key succeeds.
+func (s *CredentialServiceImpl) RevokeDeviceKey(ctx context.Context, deviceId string) error {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, model.RoleOwner); err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.SetDeviceCredential(ctx, deviceId, nil)
+}
+
+// AuthenticateDevice implements CredentialService.
+// It returns the identity of the device the key belongs to. Decommissioned
+// devices cannot authenticate.
+func (s *CredentialServiceImpl) AuthenticateDevice(ctx context.Context, key string) (*auth.Identity, error) {
+	if !strings.HasPrefix(key, deviceKeyPrefix) {
+		return nil, ErrInvalidDeviceKey
+	}
+
+	device, err := s.DeviceRepository.GetDeviceByKeyHash(ctx, hashDeviceKey(key))
+	if errs.Is(err, errs.NotFound) {
+		return nil, ErrInvalidDeviceKey
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if device.Status == model.StatusDecommissioned {
+		return nil, ErrInvalidDeviceKey
+	}
+
+	return &auth.Identity{UserID: device.UserID, DeviceID: device.ID}, nil
+}
+
+// newDeviceKey generates a random device key and the credential that stores its hash.
+func newDeviceKey(now time.Time) (string, *model.DeviceCredential, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+
+	key := deviceKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, &model.DeviceCredential{KeyHash: hashDeviceKey(key), IssuedAt: now.Unix()}, nil
+}
+
+// hashDeviceKey returns the hash a device key is stored and looked up by. Keys
+// carry 256 random bits, so a fast hash is enough to keep them from being
+// recovered from the database.
+func hashDeviceKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure CredentialServiceImpl implements CredentialService interface
+var _ CredentialService = &CredentialServiceImpl{}
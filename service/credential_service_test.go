@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/auth"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestCredentialService_RotateDeviceKey(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	var stored *model.DeviceCredential
+	repository.On("SetDeviceCredential", mock.Anything, id, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(2).(*model.DeviceCredential) }).
+		Return(nil)
+
+	credentialService := service.NewCredentialService(repository)
+	credentialService.Clock = func() time.Time { return now }
+
+	// Act
+	key, credential, err := credentialService.RotateDeviceKey(callerContext("caller", nil), id)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while rotating the device key: %s", err)
+	}
+
+	if !strings.HasPrefix(key, "btdk_") {
+		t.Errorf("Expected a prefixed key, got %q", key)
+	}
+
+	if stored != credential || credential.KeyHash != keyHash(key) || credential.IssuedAt != now.Unix() {
+		t.Errorf("Expected the hash of the returned key to be stored, got %+v", stored)
+	}
+}
+
+func TestCredentialService_RevokeDeviceKey(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+	repository.On("SetDeviceCredential", mock.Anything, id, (*model.DeviceCredential)(nil)).Return(nil)
+
+	credentialService := service.NewCredentialService(repository)
+
+	// Act
+	err := credentialService.RevokeDeviceKey(callerContext("caller", nil), id)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while revoking the device key: %s", err)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestCredentialService_RotateDeviceKey_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+
+	credentialService := service.NewCredentialService(repository)
+
+	// Act
+	_, _, err := credentialService.RotateDeviceKey(callerContext("intruder", nil), id)
+
+	// Assert
+	if !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	repository.AssertNotCalled(t, "SetDeviceCredential", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCredentialService_AuthenticateDevice(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	retiredID := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceByKeyHash", mock.Anything, keyHash("btdk_valid")).
+		Return(&model.Device{ID: id, UserID: "owner", Status: model.StatusActive}, nil)
+	deviceRepository.On("GetDeviceByKeyHash", mock.Anything, keyHash("btdk_retired")).
+		Return(&model.Device{ID: retiredID, UserID: "owner", Status: model.StatusDecommissioned}, nil)
+	deviceRepository.On("GetDeviceByKeyHash", mock.Anything, keyHash("btdk_unknown")).
+		Return((*model.Device)(nil), repository.ErrDeviceNotFound)
+
+	credentialService := service.NewCredentialService(deviceRepository)
+
+	// Act
+	identity, err := credentialService.AuthenticateDevice(context.Background(), "btdk_valid")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while authenticating the device: %s", err)
+	}
+
+	if identity.DeviceID != id || identity.UserID != "owner" || !identity.IsDevice() {
+		t.Errorf("Expected the identity of the device, got %+v", identity)
+	}
+
+	// Keys without the prefix, unknown keys and decommissioned devices are rejected.
+	for _, key := range []string{"valid", "btdk_unknown", "btdk_retired"} {
+		if _, err := credentialService.AuthenticateDevice(context.Background(), key); !errs.Is(err, errs.Unauthenticated) {
+			t.Errorf("Expected unauthenticated for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestCredentialService_DeviceIdentity(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	otherID := primitive.NewObjectID().Hex()
+	page := &model.TripPage{}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+	deviceRepository.On("GetDeviceById", mock.Anything, otherID).Return(&model.Device{ID: otherID, UserID: "owner"}, nil)
+
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("ListTrips", mock.Anything, mock.Anything).Return(page, nil)
+
+	tripService := service.NewTripService(tripRepository, deviceRepository, nil)
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+	ctx := auth.NewContext(context.Background(), &auth.Identity{UserID: "owner", DeviceID: id})
+
+	// Act
+	_, own := tripService.ListTrips(ctx, &model.TripQuery{DeviceID: id})
+	_, other := tripService.ListTrips(ctx, &model.TripQuery{DeviceID: otherID})
+	owner := deviceService.DeleteDevice(ctx, id, 0)
+
+	// Assert
+	if own != nil {
+		t.Errorf("Error was not expected while a device reads its own data: %s", own)
+	}
+
+	if !errs.Is(other, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied for another device of the same owner, got %v", other)
+	}
+
+	if !errs.Is(owner, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied for an owner-only call, got %v", owner)
+	}
+}
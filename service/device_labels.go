@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/selector"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+const (
+	// MaxDeviceTags is the largest number of tags on a device.
+	MaxDeviceTags = 50
+	// MaxDeviceLabels is the largest number of labels on a device.
+	MaxDeviceLabels = 50
+	// MaxTagLength is the longest tag, in bytes.
+	MaxTagLength = 64
+)
+
+// SetDeviceLabels implements DeviceService.
+// The tags are added to the device and the labels set, keeping the others.
+func (s *DeviceServiceImpl) SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string) (*model.Device, error) {
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, id, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 && len(labels) == 0 {
+		return nil, errs.InvalidField("tags", "at least one tag or label must be given")
+	}
+
+	violations := append(validateTags("tags", tags), validateLabels("labels", labels)...)
+	if len(violations) > 0 {
+		return nil, errs.InvalidFields(violations...)
+	}
+
+	// The repository checks the limits again, against changes made since the device was read.
+	if count := len(mergeTags(device.Tags, tags)); count > MaxDeviceTags {
+		return nil, labelLimitReached(fmt.Sprintf("a device can have at most %d tags", MaxDeviceTags))
+	}
+	if count := len(mergeLabels(device.Labels, labels)); count > MaxDeviceLabels {
+		return nil, labelLimitReached(fmt.Sprintf("a device can have at most %d labels", MaxDeviceLabels))
+	}
+
+	if err := s.DeviceRepository.SetDeviceLabels(ctx, id, tags, labels, MaxDeviceTags, MaxDeviceLabels); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
+
+// RemoveDeviceLabels implements DeviceService.
+// Tags and label keys the device does not have are ignored.
+func (s *DeviceServiceImpl) RemoveDeviceLabels(ctx context.Context, id string, tags []string, keys []string) (*model.Device, error) {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, id, model.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 && len(keys) == 0 {
+		return nil, errs.InvalidField("tags", "at least one tag or label key must be given")
+	}
+
+	var violations []errs.FieldViolation
+	for i, key := range keys {
+		if err := selector.ValidateKey(key); err != nil {
+			violations = append(violations, errs.FieldViolation{Field: fmt.Sprintf("label_keys[%d]", i), Description: err.Error()})
+		}
+	}
+	if len(violations) > 0 {
+		return nil, errs.InvalidFields(violations...)
+	}
+
+	if err := s.DeviceRepository.RemoveDeviceLabels(ctx, id, tags, keys); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
+
+// labelLimitReached returns repository.ErrLabelLimitReached with a message
+// telling which limit was reached.
+func labelLimitReached(message string) error {
+	err := *repository.ErrLabelLimitReached
+	err.Message = message
+	return &err
+}
+
+// reload reads a device again after it was changed and presents it to the caller.
+func (s *DeviceServiceImpl) reload(ctx context.Context, id string) (*model.Device, error) {
+	device, err := s.DeviceRepository.GetDeviceById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, device), nil
+}
+
+// validateTags checks that tags are non-empty, unique and at most MaxTagLength bytes.
+func validateTags(field string, tags []string) []errs.FieldViolation {
+	if len(tags) > MaxDeviceTags {
+		return []errs.FieldViolation{{Field: field, Description: fmt.Sprintf("must have at most %d tags", MaxDeviceTags)}}
+	}
+
+	var violations []errs.FieldViolation
+	seen := make(map[string]bool, len(tags))
+	for i, tag := range tags {
+		switch {
+		case tag == "" || len(tag) > MaxTagLength:
+			violations = append(violations, errs.FieldViolation{Field: fmt.Sprintf("%s[%d]", field, i), Description: fmt.Sprintf("must be between 1 and %d bytes", MaxTagLength)})
+		case seen[tag]:
+			violations = append(violations, errs.FieldViolation{Field: fmt.Sprintf("%s[%d]", field, i), Description: "is listed more than once"})
+		}
+		seen[tag] = true
+	}
+
+	return violations
+}
+
+// validateLabels checks label keys and values against the syntax of label selectors,
+// so that every label can be selected.
+func validateLabels(field string, labels map[string]string) []errs.FieldViolation {
+	if len(labels) > MaxDeviceLabels {
+		return []errs.FieldViolation{{Field: field, Description: fmt.Sprintf("must have at most %d labels", MaxDeviceLabels)}}
+	}
+
+	var violations []errs.FieldViolation
+	for key, value := range labels {
+		if err := selector.ValidateKey(key); err != nil {
+			violations = append(violations, errs.FieldViolation{Field: field, Description: err.Error()})
+			continue
+		}
+		if err := selector.ValidateValue(value); err != nil {
+			violations = append(violations, errs.FieldViolation{Field: fmt.Sprintf("%s[%s]", field, key), Description: err.Error()})
+		}
+	}
+
+	return violations
+}
+
+// mergeTags returns the tags of existing followed by the added tags it does not have yet.
+func mergeTags(existing, added []string) []string {
+	merged := append([]string(nil), existing...)
+	for _, tag := range added {
+		found := false
+		for _, candidate := range existing {
+			if candidate == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// mergeLabels returns the labels of existing overwritten by the added labels.
+func mergeLabels(existing, added map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(added))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range added {
+		merged[key] = value
+	}
+	return merged
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/BerryTracer/device-service/model"
 	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
 )
 
 type DeviceService interface {
@@ -12,35 +16,467 @@ type DeviceService interface {
 	GetDeviceById(ctx context.Context, id string) (*model.Device, error)
 	GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (*model.Device, error)
 	GetDevicesByUserId(ctx context.Context, userId string) ([]*model.Device, error)
+	ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error)
+	FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error)
+	UpdateDevice(ctx context.Context, device *model.Device) (*model.Device, error)
+	PatchDevice(ctx context.Context, device *model.Device, paths []string) (*model.Device, error)
+	ChangeDeviceStatus(ctx context.Context, id string, status model.DeviceStatus, reason string, version int64) (*model.Device, error)
+	DeleteDevice(ctx context.Context, id string, version int64) error
+	WatchDevices(ctx context.Context, userId string, groupId string, resumeToken string) (repository.DeviceEventStream, error)
+	SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string) (*model.Device, error)
+	RemoveDeviceLabels(ctx context.Context, id string, tags []string, keys []string) (*model.Device, error)
+	ListDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error)
+	RestoreDevice(ctx context.Context, id string) (*model.Device, error)
 }
 
+// MaxNearbyRadius is the largest radius of a proximity query, in meters.
+const MaxNearbyRadius = 1000000
+
+// Clock returns the current time. It is replaced in tests.
+type Clock func() time.Time
+
+// DeviceServiceImpl enforces device access: callers may read devices they own
+// or that are shared with them, change them as owner or editor, and delete only
+// their own devices, unless their token carries the admin role. Device types
+// are checked against the catalog in DeviceTypeRepository.
 type DeviceServiceImpl struct {
-	DeviceRepository repository.DeviceRepository
+	DeviceRepository     repository.DeviceRepository
+	DeviceTypeRepository repository.DeviceTypeRepository
+	DeviceChangeFeed     repository.DeviceChangeFeed
+	Clock                Clock
 }
 
-// NewDeviceService returns a new DeviceServiceImpl.
-func NewDeviceService(deviceRepository repository.DeviceRepository) *DeviceServiceImpl {
-	return &DeviceServiceImpl{DeviceRepository: deviceRepository}
+// NewDeviceService returns a new DeviceServiceImpl that uses the system clock.
+func NewDeviceService(deviceRepository repository.DeviceRepository, deviceTypeRepository repository.DeviceTypeRepository, deviceChangeFeed repository.DeviceChangeFeed) *DeviceServiceImpl {
+	return &DeviceServiceImpl{
+		DeviceRepository:     deviceRepository,
+		DeviceTypeRepository: deviceTypeRepository,
+		DeviceChangeFeed:     deviceChangeFeed,
+		Clock:                time.Now,
+	}
 }
 
 // CreateDevice implements DeviceService.
+// The device is owned by the caller unless an admin creates it for another user.
+// The ID, registration date and device key are assigned by the server and written back to device.
 func (s *DeviceServiceImpl) CreateDevice(ctx context.Context, device *model.Device) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	var violations []errs.FieldViolation
+	if device.ID != "" {
+		violations = append(violations, errs.FieldViolation{Field: "device.id", Description: "is assigned by the server and must be empty"})
+	}
+	if device.RegistrationDate != 0 {
+		violations = append(violations, errs.FieldViolation{Field: "device.registration_date", Description: "is assigned by the server and must be empty"})
+	}
+	violations = append(violations, validateTags("device.tags", device.Tags)...)
+	violations = append(violations, validateLabels("device.labels", device.Labels)...)
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	if device.UserID == "" {
+		device.UserID = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, device.UserID); err != nil {
+		return err
+	}
+
+	if device.Status == "" {
+		device.Status = model.StatusProvisioned
+	}
+
+	if err := validateStatus("device.status", device.Status); err != nil {
+		return err
+	}
+
+	if err := s.checkDeviceType(ctx, device); err != nil {
+		return err
+	}
+
+	now := s.Clock()
+	device.RegistrationDate = now.Unix()
+
+	// The device gets its own key, which is only ever returned here.
+	device.DeviceKey, device.Credential, err = newDeviceKey(now)
+	if err != nil {
+		return err
+	}
+
 	return s.DeviceRepository.CreateDevice(ctx, device)
 }
 
 // GetDeviceById implements DeviceService.
 func (s *DeviceServiceImpl) GetDeviceById(ctx context.Context, id string) (*model.Device, error) {
-	return s.DeviceRepository.GetDeviceById(ctx, id)
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, id, model.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, device), nil
 }
 
 // GetDevicesByUserId implements DeviceService.
+// An empty userId lists the caller's own devices. The devices shared with the
+// user follow the devices they own, each with the user's role.
 func (s *DeviceServiceImpl) GetDevicesByUserId(ctx context.Context, userId string) ([]*model.Device, error) {
-	return s.DeviceRepository.GetDevicesByUserId(ctx, userId)
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		userId = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	owned, err := s.DeviceRepository.GetDevicesByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock().Unix()
+	shared, err := s.DeviceRepository.GetSharedDevices(ctx, userId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(withRoles(owned, userId, now), withRoles(shared, userId, now)...), nil
+}
+
+// ListDevices implements DeviceService.
+// An empty query.UserID lists the caller's own devices. The devices shared
+// with the user are listed along with the devices they own, each with the
+// user's role.
+func (s *DeviceServiceImpl) ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.UserID == "" {
+		query.UserID = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, query.UserID); err != nil {
+		return nil, err
+	}
+
+	query.SharedAt = s.Clock().Unix()
+	page, err := s.DeviceRepository.ListDevices(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	withRoles(page.Devices, query.UserID, query.SharedAt)
+	return page, nil
+}
+
+// FindDevicesNear implements DeviceService.
+// An empty query.UserID searches the caller's own devices. The devices shared
+// with the user are searched too, and each result carries the user's role.
+func (s *DeviceServiceImpl) FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.UserID == "" {
+		query.UserID = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, query.UserID); err != nil {
+		return nil, err
+	}
+
+	violations := validateLatLng("point", model.LatLng{Latitude: query.Latitude, Longitude: query.Longitude})
+	if !(query.Radius > 0 && query.Radius <= MaxNearbyRadius) {
+		violations = append(violations, errs.FieldViolation{Field: "radius", Description: fmt.Sprintf("must be above 0 and at most %d meters", MaxNearbyRadius)})
+	}
+	if len(violations) > 0 {
+		return nil, errs.InvalidFields(violations...)
+	}
+
+	if query.Filter.Status != "" {
+		if err := validateStatus("filter.status", query.Filter.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	query.SharedAt = s.Clock().Unix()
+	nearby, err := s.DeviceRepository.FindDevicesNear(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range nearby {
+		withRoles([]*model.Device{result.Device}, query.UserID, query.SharedAt)
+	}
+	return nearby, nil
 }
 
 // GetDeviceBySerialNumber implements DeviceService.
 func (s *DeviceServiceImpl) GetDeviceBySerialNumber(ctx context.Context, serialNumber string) (*model.Device, error) {
-	return s.DeviceRepository.GetDeviceBySerialNumber(ctx, serialNumber)
+	device, err := s.DeviceRepository.GetDeviceBySerialNumber(ctx, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeRole(ctx, s.Clock, device, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, device), nil
+}
+
+// UpdateDevice implements DeviceService.
+// Only admins may move a device to another owner. The registration date is
+// kept from the stored device, and a new status must be a legal transition.
+// A changed type, serial number or attributes are checked against the catalog.
+// A non-zero device.Version must be the version of the stored device. A status
+// change is written and recorded in the same update as the other fields. The
+// updated device is returned with its new version.
+func (s *DeviceServiceImpl) UpdateDevice(ctx context.Context, device *model.Device) (*model.Device, error) {
+	existing, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, device.ID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	device.RegistrationDate = existing.RegistrationDate
+
+	if device.UserID == "" {
+		device.UserID = existing.UserID
+	}
+
+	if device.UserID != existing.UserID {
+		if err := authorizeAdmin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if device.Status == "" {
+		device.Status = existing.Status
+	}
+
+	if device.DeviceType != existing.DeviceType || device.SerialNumber != existing.SerialNumber || !reflect.DeepEqual(device.Attributes, existing.Attributes) {
+		if err := s.checkDeviceType(ctx, device); err != nil {
+			return nil, err
+		}
+	}
+
+	var change *model.StatusChange
+	if device.Status != existing.Status {
+		if change, err = s.statusChange(ctx, existing, device.Status, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.DeviceRepository.UpdateDevice(ctx, device, change); err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, device), nil
+}
+
+// PatchDevice implements DeviceService.
+// A status in the mask is applied as a status change, in the same update as
+// the remaining paths. A non-zero device.Version must be the version of the
+// stored device. The updated device is returned with its new version.
+func (s *DeviceServiceImpl) PatchDevice(ctx context.Context, device *model.Device, paths []string) (*model.Device, error) {
+	existing, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, device.ID, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	// The patched device must still match its type. The serial number cannot
+	// be patched, so the stored one must match a new type.
+	patched := &model.Device{DeviceType: existing.DeviceType, SerialNumber: existing.SerialNumber, Attributes: existing.Attributes}
+	checkType := false
+
+	var remaining []string
+	for _, path := range paths {
+		switch path {
+		case "device_type":
+			patched.DeviceType = device.DeviceType
+			checkType = checkType || device.DeviceType != existing.DeviceType
+		case "attributes":
+			patched.Attributes = device.Attributes
+			checkType = true
+		}
+		if path != "status" {
+			remaining = append(remaining, path)
+		}
+	}
+
+	if checkType {
+		if err := s.checkDeviceType(ctx, patched); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(remaining) == len(paths) {
+		return s.patch(ctx, device, paths, nil)
+	}
+
+	var change *model.StatusChange
+	if device.Status != existing.Status {
+		if change, err = s.statusChange(ctx, existing, device.Status, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if change == nil && len(remaining) == 0 {
+		return s.present(ctx, existing), nil
+	}
+
+	return s.patch(ctx, device, remaining, change)
+}
+
+// patch writes the patch and returns the updated device.
+func (s *DeviceServiceImpl) patch(ctx context.Context, device *model.Device, paths []string, change *model.StatusChange) (*model.Device, error) {
+	if err := s.DeviceRepository.PatchDevice(ctx, device, paths, change); err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, device), nil
+}
+
+// ChangeDeviceStatus implements DeviceService.
+// A non-zero version must be the version of the stored device. The updated
+// device is returned with its new version.
+func (s *DeviceServiceImpl) ChangeDeviceStatus(ctx context.Context, id string, status model.DeviceStatus, reason string, version int64) (*model.Device, error) {
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, id, model.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := s.statusChange(ctx, device, status, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.DeviceRepository.ChangeDeviceStatus(ctx, id, change, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.present(ctx, updated), nil
+}
+
+// DeleteDevice implements DeviceService.
+// Only the owner, or an admin, may delete a device. The device is moved to the
+// trash, from where it can be restored until it is purged. A non-zero version
+// must be the version of the stored device.
+func (s *DeviceServiceImpl) DeleteDevice(ctx context.Context, id string, version int64) error {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, id, model.RoleOwner); err != nil {
+		return err
+	}
+
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.DeleteDevice(ctx, id, identity.UserID, s.Clock().Unix(), version)
+}
+
+// WatchDevices implements DeviceService.
+// An empty userId watches the caller's own devices; a groupId narrows them to one group.
+// The devices shared with the user are watched too, with the user's role.
+func (s *DeviceServiceImpl) WatchDevices(ctx context.Context, userId string, groupId string, resumeToken string) (repository.DeviceEventStream, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		userId = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	stream, err := s.DeviceChangeFeed.WatchDevices(ctx, userId, groupId, resumeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &roleEventStream{DeviceEventStream: stream, userId: userId, clock: s.Clock}, nil
+}
+
+// roleEventStream sets the role of the watching user on the devices of a
+// stream. A device the user can no longer access, such as one whose share
+// was revoked or has expired, is reported as deleted and only by its ID.
+type roleEventStream struct {
+	repository.DeviceEventStream
+	userId string
+	clock  func() time.Time
+}
+
+func (s *roleEventStream) Next(ctx context.Context) (*model.DeviceEvent, error) {
+	event, err := s.DeviceEventStream.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	withRoles([]*model.Device{event.Device}, s.userId, s.clock().Unix())
+	if event.Device.Role == "" {
+		event.Type = model.DeviceDeleted
+		event.Device = &model.Device{ID: event.Device.ID}
+	}
+
+	return event, nil
+}
+
+// checkDeviceType checks that the device type of device is in the catalog and
+// that its serial number matches the type.
+func (s *DeviceServiceImpl) checkDeviceType(ctx context.Context, device *model.Device) error {
+	violations, err := newDeviceTypeChecker(s.DeviceTypeRepository).check(ctx, "device", device)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	return nil
+}
+
+// present sets the caller's role on an authorized device. Only the owner and
+// admins see whom the device is shared with.
+func (s *DeviceServiceImpl) present(ctx context.Context, device *model.Device) *model.Device {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return device
+	}
+
+	device.Role = device.RoleOf(identity.UserID, s.Clock().Unix())
+	if device.Role != model.RoleOwner && !identity.IsAdmin() {
+		device.Shares = nil
+	}
+
+	return device
+}
+
+// withRoles sets the role of userId on devices owned by or shared with them
+// and hides whom the devices they do not own are shared with.
+func withRoles(devices []*model.Device, userId string, now int64) []*model.Device {
+	for _, device := range devices {
+		device.Role = device.RoleOf(userId, now)
+		if device.Role != model.RoleOwner {
+			device.Shares = nil
+		}
+	}
+	return devices
 }
 
 // Ensure DeviceServiceImpl implements DeviceService interface
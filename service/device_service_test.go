@@ -2,10 +2,16 @@ package service_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/BerryTracer/device-service/auth"
 	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
 	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
 	"github.com/stretchr/testify/mock"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -20,6 +26,11 @@ func (r *DeviceRepositoryMock) CreateDevice(ctx context.Context, device *model.D
 	return args.Error(0)
 }
 
+func (r *DeviceRepositoryMock) CreateDevices(ctx context.Context, devices []*model.Device) ([]*model.Device, error) {
+	args := r.Called(ctx, devices)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
 func (r *DeviceRepositoryMock) GetDeviceById(ctx context.Context, id string) (*model.Device, error) {
 	args := r.Called(ctx, id)
 	return args.Get(0).(*model.Device), args.Error(1)
@@ -35,26 +46,190 @@ func (r *DeviceRepositoryMock) GetDevicesByUserId(ctx context.Context, userId st
 	return args.Get(0).([]*model.Device), args.Error(1)
 }
 
+func (r *DeviceRepositoryMock) CountDevicesByType(ctx context.Context, deviceType string) (int64, error) {
+	args := r.Called(ctx, deviceType)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) ListDevices(ctx context.Context, query *model.ListDevicesQuery) (*model.DevicePage, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).(*model.DevicePage), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) FindDevicesNear(ctx context.Context, query *model.NearbyDevicesQuery) ([]*model.NearbyDevice, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).([]*model.NearbyDevice), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) UpdateDevice(ctx context.Context, device *model.Device, change *model.StatusChange) error {
+	args := r.Called(ctx, device, change)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) PatchDevice(ctx context.Context, device *model.Device, paths []string, change *model.StatusChange) error {
+	args := r.Called(ctx, device, paths, change)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) ChangeDeviceStatus(ctx context.Context, id string, change *model.StatusChange, version int64) (*model.Device, error) {
+	args := r.Called(ctx, id, change, version)
+	return args.Get(0).(*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) UpdateTelemetry(ctx context.Context, id string, sample *model.TelemetrySample) error {
+	args := r.Called(ctx, id, sample)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) UpdateLocation(ctx context.Context, id string, location *model.Location) error {
+	args := r.Called(ctx, id, location)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) GetDeviceByKeyHash(ctx context.Context, keyHash string) (*model.Device, error) {
+	args := r.Called(ctx, keyHash)
+	return args.Get(0).(*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) SetDeviceCredential(ctx context.Context, id string, credential *model.DeviceCredential) error {
+	args := r.Called(ctx, id, credential)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) ClaimDevice(ctx context.Context, id string, codeHash string, userId string, claimedAt int64) error {
+	args := r.Called(ctx, id, codeHash, userId, claimedAt)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) RecordClaimFailure(ctx context.Context, id string, maxAttempts int, now int64, lockedUntil int64) error {
+	args := r.Called(ctx, id, maxAttempts, now, lockedUntil)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) StartTransfer(ctx context.Context, id string, ownerId string, transfer *model.DeviceTransfer) error {
+	args := r.Called(ctx, id, ownerId, transfer)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) AnswerTransfer(ctx context.Context, id string, change *model.OwnershipChange) error {
+	args := r.Called(ctx, id, change)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) GetIncomingTransfers(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	args := r.Called(ctx, userId, now)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) GetSharedDevices(ctx context.Context, userId string, now int64) ([]*model.Device, error) {
+	args := r.Called(ctx, userId, now)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) ShareDevice(ctx context.Context, id string, ownerId string, share *model.DeviceShare, maxShares int, now int64) error {
+	args := r.Called(ctx, id, ownerId, share, maxShares, now)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) UnshareDevice(ctx context.Context, id string, userId string) error {
+	args := r.Called(ctx, id, userId)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) GetDevicesByGroupId(ctx context.Context, userId string, groupId string) ([]*model.Device, error) {
+	args := r.Called(ctx, userId, groupId)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) AddDevicesToGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error {
+	args := r.Called(ctx, userId, groupId, deviceIds)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) RemoveDevicesFromGroup(ctx context.Context, userId string, groupId string, deviceIds []string) error {
+	args := r.Called(ctx, userId, groupId, deviceIds)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) SetDeviceLabels(ctx context.Context, id string, tags []string, labels map[string]string, maxTags int, maxLabels int) error {
+	args := r.Called(ctx, id, tags, labels, maxTags, maxLabels)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) RemoveDeviceLabels(ctx context.Context, id string, tags []string, keys []string) error {
+	args := r.Called(ctx, id, tags, keys)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) DeleteDevice(ctx context.Context, id string, deletedBy string, deletedAt int64, version int64) error {
+	args := r.Called(ctx, id, deletedBy, deletedAt, version)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) GetDeletedDeviceById(ctx context.Context, id string) (*model.Device, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) GetDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) GetDevicesDeletedBefore(ctx context.Context, deletedBefore int64, limit int64) ([]*model.Device, error) {
+	args := r.Called(ctx, deletedBefore, limit)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) RestoreDevice(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) PurgeDevice(ctx context.Context, id string, deletedBefore int64) error {
+	args := r.Called(ctx, id, deletedBefore)
+	return args.Error(0)
+}
+
+func (r *DeviceRepositoryMock) GetDevicesPendingHistoryWipe(ctx context.Context, limit int64) ([]*model.Device, error) {
+	args := r.Called(ctx, limit)
+	return args.Get(0).([]*model.Device), args.Error(1)
+}
+
+func (r *DeviceRepositoryMock) CompleteHistoryWipe(ctx context.Context, id string, before int64) error {
+	args := r.Called(ctx, id, before)
+	return args.Error(0)
+}
+
+// callerContext returns a context authenticated as the given user.
+func callerContext(userID string, claims map[string]string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Identity{UserID: userID, Claims: claims})
+}
+
 func TestDeviceService_CreateDevice(t *testing.T) {
 	// Arrange
 	device := &model.Device{
-		ID:           primitive.NewObjectID().Hex(),
 		SerialNumber: "123456789",
+		DeviceType:   "tracker",
 		UserID:       "123456789",
 	}
 
 	repository := new(DeviceRepositoryMock)
 	repository.On("CreateDevice", mock.Anything, device).Return(nil)
 
-	deviceService := service.NewDeviceService(repository)
+	deviceService := service.NewDeviceService(repository, newCatalog(trackerType), nil)
 
 	// Act
-	err := deviceService.CreateDevice(context.Background(), device)
+	err := deviceService.CreateDevice(callerContext(device.UserID, nil), device)
 
 	// Assert
 	if err != nil {
 		t.Errorf("Error was not expected while creating device: %s", err)
 	}
+
+	if device.DeviceKey == "" || device.Credential == nil || device.Credential.KeyHash != keyHash(device.DeviceKey) {
+		t.Errorf("Expected a device key to be issued, got %+v", device.Credential)
+	}
 }
 
 func TestDeviceService_GetDeviceById(t *testing.T) {
@@ -68,10 +243,10 @@ func TestDeviceService_GetDeviceById(t *testing.T) {
 	repository := new(DeviceRepositoryMock)
 	repository.On("GetDeviceById", mock.Anything, device.ID).Return(device, nil)
 
-	deviceService := service.NewDeviceService(repository)
+	deviceService := service.NewDeviceService(repository, nil, nil)
 
 	// Act
-	result, err := deviceService.GetDeviceById(context.Background(), device.ID)
+	result, err := deviceService.GetDeviceById(callerContext(device.UserID, nil), device.ID)
 
 	// Assert
 	if err != nil {
@@ -94,10 +269,10 @@ func TestDeviceService_GetDeviceBySerialNumber(t *testing.T) {
 	repository := new(DeviceRepositoryMock)
 	repository.On("GetDeviceBySerialNumber", mock.Anything, device.SerialNumber).Return(device, nil)
 
-	deviceService := service.NewDeviceService(repository)
+	deviceService := service.NewDeviceService(repository, nil, nil)
 
 	// Act
-	result, err := deviceService.GetDeviceBySerialNumber(context.Background(), device.SerialNumber)
+	result, err := deviceService.GetDeviceBySerialNumber(callerContext(device.UserID, nil), device.SerialNumber)
 
 	// Assert
 	if err != nil {
@@ -119,11 +294,12 @@ func TestDeviceService_GetDevicesByUserId(t *testing.T) {
 
 	repository := new(DeviceRepositoryMock)
 	repository.On("GetDevicesByUserId", mock.Anything, device.UserID).Return([]*model.Device{device}, nil)
+	repository.On("GetSharedDevices", mock.Anything, device.UserID, mock.Anything).Return([]*model.Device{}, nil)
 
-	deviceService := service.NewDeviceService(repository)
+	deviceService := service.NewDeviceService(repository, nil, nil)
 
 	// Act
-	result, err := deviceService.GetDevicesByUserId(context.Background(), device.UserID)
+	result, err := deviceService.GetDevicesByUserId(callerContext(device.UserID, nil), device.UserID)
 
 	// Assert
 	if err != nil {
@@ -134,3 +310,800 @@ func TestDeviceService_GetDevicesByUserId(t *testing.T) {
 		t.Errorf("Result was expected while getting devices by user id")
 	}
 }
+
+func TestDeviceService_ListDevices(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	query := &model.ListDevicesQuery{PageSize: 10, OrderBy: "name"}
+	owned := &model.Device{UserID: "caller", Shares: []model.DeviceShare{{UserID: "friend", Role: model.RoleViewer}}}
+	shared := &model.Device{UserID: "owner", Shares: []model.DeviceShare{{UserID: "caller", Role: model.RoleEditor}, {UserID: "friend", Role: model.RoleViewer}}}
+	page := &model.DevicePage{Devices: []*model.Device{owned, shared}, TotalSize: 2}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("ListDevices", mock.Anything, query).Return(page, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+	deviceService.Clock = func() time.Time { return now }
+
+	// Act
+	result, err := deviceService.ListDevices(callerContext("caller", nil), query)
+	_, otherErr := deviceService.ListDevices(callerContext("caller", nil), &model.ListDevicesQuery{UserID: "owner"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while listing devices: %s", err)
+	}
+
+	if result != page || query.UserID != "caller" || query.SharedAt != now.Unix() {
+		t.Errorf("Expected the caller's own and shared devices to be listed, got %+v", query)
+	}
+
+	if owned.Role != model.RoleOwner || len(owned.Shares) != 1 {
+		t.Errorf("Expected the owner role and the shares on an owned device, got %+v", owned)
+	}
+
+	if shared.Role != model.RoleEditor || shared.Shares != nil {
+		t.Errorf("Expected the editor role and no shares on a shared device, got %+v", shared)
+	}
+
+	if !errors.Is(otherErr, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied for another user's devices, got %v", otherErr)
+	}
+}
+
+func TestDeviceService_UpdateDevice(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		ID:           primitive.NewObjectID().Hex(),
+		SerialNumber: "123456789",
+		UserID:       "123456789",
+		Name:         "Renamed Device",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, device.ID).Return(&model.Device{ID: device.ID, UserID: device.UserID, SerialNumber: device.SerialNumber}, nil)
+	repository.On("UpdateDevice", mock.Anything, device, (*model.StatusChange)(nil)).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	updated, err := deviceService.UpdateDevice(callerContext(device.UserID, nil), device)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while updating device: %s", err)
+	}
+
+	if updated != device || updated.Role != model.RoleOwner {
+		t.Errorf("Expected the updated device with the owner role, got %+v", updated)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeviceService_UpdateDevice_StatusConflict(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: id, UserID: "caller", Status: model.StatusLost, Version: 4}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Status: model.StatusActive, Version: 5}, nil)
+	deviceRepository.On("UpdateDevice", mock.Anything, device, mock.AnythingOfType("*model.StatusChange")).Return(repository.ErrVersionMismatch)
+
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+
+	// Act
+	_, err := deviceService.UpdateDevice(callerContext("caller", nil), device)
+
+	// Assert
+	if !errs.Is(err, errs.Aborted) {
+		t.Errorf("Expected a version mismatch, got %v", err)
+	}
+
+	// A rejected update leaves the status alone as well.
+	deviceRepository.AssertNotCalled(t, "ChangeDeviceStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeviceService_PatchDevice(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		ID:   primitive.NewObjectID().Hex(),
+		Name: "Renamed Device",
+	}
+	paths := []string{"name"}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, device.ID).Return(&model.Device{ID: device.ID, UserID: "123456789"}, nil)
+	repository.On("PatchDevice", mock.Anything, device, paths, (*model.StatusChange)(nil)).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, err := deviceService.PatchDevice(callerContext("123456789", nil), device, paths)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while patching device: %s", err)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeviceService_DeleteDevice(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "123456789"}, nil)
+	repository.On("DeleteDevice", mock.Anything, id, "123456789", now.Unix(), int64(0)).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+	deviceService.Clock = func() time.Time { return now }
+
+	// Act
+	err := deviceService.DeleteDevice(callerContext("123456789", nil), id, 0)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while deleting device: %s", err)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeviceService_CreateDevice_UsesCallerUserID(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		SerialNumber: "123456789",
+		DeviceType:   "tracker",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("CreateDevice", mock.Anything, device).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(trackerType), nil)
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), device)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while creating device: %s", err)
+	}
+
+	if device.UserID != "caller" {
+		t.Errorf("Expected device to be owned by the caller, got %q", device.UserID)
+	}
+}
+
+func TestDeviceService_CreateDevice_ForAnotherUser(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		SerialNumber: "123456789",
+		DeviceType:   "tracker",
+		UserID:       "someone-else",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("CreateDevice", mock.Anything, device).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(trackerType), nil)
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), device)
+	adminErr := deviceService.CreateDevice(callerContext("admin", map[string]string{"role": "admin"}), device)
+
+	// Assert
+	if !errors.Is(err, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied for a regular user, got %v", err)
+	}
+
+	if adminErr != nil {
+		t.Errorf("Error was not expected while an admin creates a device: %s", adminErr)
+	}
+
+	repository.AssertNumberOfCalls(t, "CreateDevice", 1)
+}
+
+func TestDeviceService_CreateDevice_AssignsRegistrationDate(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	device := &model.Device{
+		SerialNumber: "123456789",
+		DeviceType:   "tracker",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("CreateDevice", mock.Anything, device).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(trackerType), nil)
+	deviceService.Clock = func() time.Time { return now }
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), device)
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while creating device: %s", err)
+	}
+
+	if device.RegistrationDate != now.Unix() {
+		t.Errorf("Expected registration date %d, got %d", now.Unix(), device.RegistrationDate)
+	}
+}
+
+func TestDeviceService_CreateDevice_RejectsServerAssignedFields(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		ID:               primitive.NewObjectID().Hex(),
+		SerialNumber:     "123456789",
+		RegistrationDate: 1,
+	}
+
+	repository := new(DeviceRepositoryMock)
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), device)
+
+	// Assert
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument {
+		t.Fatalf("Expected invalid argument error, got %v", err)
+	}
+
+	if len(typed.FieldViolations) != 2 {
+		t.Errorf("Expected violations for id and registration_date, got %+v", typed.FieldViolations)
+	}
+
+	repository.AssertNotCalled(t, "CreateDevice", mock.Anything, device)
+}
+
+func TestDeviceService_CreateDevice_Unauthenticated(t *testing.T) {
+	// Arrange
+	repository := new(DeviceRepositoryMock)
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	err := deviceService.CreateDevice(context.Background(), &model.Device{})
+
+	// Assert
+	if !errors.Is(err, service.ErrUnauthenticated) {
+		t.Errorf("Expected unauthenticated error, got %v", err)
+	}
+}
+
+func TestDeviceService_GetDeviceById_OtherOwner(t *testing.T) {
+	// Arrange
+	device := &model.Device{
+		ID:     primitive.NewObjectID().Hex(),
+		UserID: "owner",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, device.ID).Return(device, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, err := deviceService.GetDeviceById(callerContext("intruder", nil), device.ID)
+	_, adminErr := deviceService.GetDeviceById(callerContext("admin", map[string]string{"roles": "support, admin"}), device.ID)
+
+	// Assert
+	if !errors.Is(err, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	if adminErr != nil {
+		t.Errorf("Error was not expected for an admin: %s", adminErr)
+	}
+}
+
+func TestDeviceService_GetDeviceById_ShareExpired(t *testing.T) {
+	// Arrange
+	now := time.Now().Add(2 * time.Hour)
+	device := &model.Device{
+		ID:     primitive.NewObjectID().Hex(),
+		UserID: "owner",
+		Shares: []model.DeviceShare{{UserID: "friend", Role: model.RoleViewer, ExpiresAt: now.Add(-time.Hour).Unix()}},
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, device.ID).Return(device, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+	deviceService.Clock = func() time.Time { return now }
+
+	// Act
+	_, err := deviceService.GetDeviceById(callerContext("friend", nil), device.ID)
+
+	// Assert: the share has expired by the service's clock, though not by the wall clock.
+	if !errors.Is(err, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+}
+
+func TestDeviceService_GetDevicesByUserId_OtherUser(t *testing.T) {
+	// Arrange
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDevicesByUserId", mock.Anything, "caller").Return([]*model.Device{}, nil)
+	repository.On("GetSharedDevices", mock.Anything, "caller", mock.Anything).Return([]*model.Device{}, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, err := deviceService.GetDevicesByUserId(callerContext("caller", nil), "owner")
+	_, ownErr := deviceService.GetDevicesByUserId(callerContext("caller", nil), "")
+
+	// Assert
+	if !errors.Is(err, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	if ownErr != nil {
+		t.Errorf("Error was not expected while listing own devices: %s", ownErr)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeviceService_DeleteDevice_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	err := deviceService.DeleteDevice(callerContext("intruder", nil), id, 0)
+
+	// Assert
+	if !errors.Is(err, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	repository.AssertNotCalled(t, "DeleteDevice", mock.Anything, id, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeviceService_WatchDevices(t *testing.T) {
+	// Arrange
+	changeFeed := repository.NewDeviceMemoryChangeFeed(10)
+	deviceService := service.NewDeviceService(new(DeviceRepositoryMock), nil, changeFeed)
+
+	ctx, cancel := context.WithTimeout(callerContext("caller", nil), time.Second)
+	defer cancel()
+
+	// Act
+	stream, err := deviceService.WatchDevices(ctx, "", "", "")
+	_, otherErr := deviceService.WatchDevices(ctx, "owner", "", "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while watching devices: %s", err)
+	}
+
+	if !errors.Is(otherErr, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied for another user's devices, got %v", otherErr)
+	}
+
+	changeFeed.Publish(model.DeviceEvent{Type: model.DeviceCreated, Device: &model.Device{ID: "device1", UserID: "caller"}})
+
+	event, err := stream.Next(ctx)
+	if err != nil || event.Device.ID != "device1" || event.Device.Role != model.RoleOwner {
+		t.Errorf("Expected the caller's device event, got %+v (%v)", event, err)
+	}
+}
+
+func TestDeviceService_WatchDevices_Shared(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	changeFeed := repository.NewDeviceMemoryChangeFeed(10)
+	deviceService := service.NewDeviceService(new(DeviceRepositoryMock), nil, changeFeed)
+	deviceService.Clock = func() time.Time { return now }
+
+	ctx, cancel := context.WithTimeout(callerContext("caller", nil), time.Second)
+	defer cancel()
+
+	stream, err := deviceService.WatchDevices(ctx, "", "", "")
+	if err != nil {
+		t.Fatalf("Error was not expected while watching devices: %s", err)
+	}
+
+	// Act
+	changeFeed.Publish(model.DeviceEvent{Type: model.DeviceUpdated, Device: &model.Device{
+		ID: "device1", UserID: "owner", Name: "Van",
+		Shares: []model.DeviceShare{{UserID: "caller", Role: model.RoleViewer}, {UserID: "friend", Role: model.RoleEditor}},
+	}})
+	changeFeed.Publish(model.DeviceEvent{Type: model.DeviceUpdated, Device: &model.Device{
+		ID: "device2", UserID: "owner", Name: "Bike",
+		Shares: []model.DeviceShare{{UserID: "caller", Role: model.RoleViewer, ExpiresAt: now.Unix()}},
+	}})
+
+	shared, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("Error was not expected while reading the shared device: %s", err)
+	}
+	expired, err := stream.Next(ctx)
+	if err != nil {
+		t.Fatalf("Error was not expected while reading the expired device: %s", err)
+	}
+
+	// Assert
+	if shared.Device.Role != model.RoleViewer || shared.Device.Shares != nil {
+		t.Errorf("Expected the shared device with the viewer role and no shares, got %+v", shared.Device)
+	}
+
+	if expired.Type != model.DeviceDeleted || expired.Device.ID != "device2" || expired.Device.Name != "" {
+		t.Errorf("Expected the expired share to be reported as a deletion, got %+v", expired)
+	}
+}
+
+func TestDeviceService_WatchDevices_SharedWatchers(t *testing.T) {
+	// Arrange
+	changeFeed := repository.NewDeviceMemoryChangeFeed(10)
+	deviceService := service.NewDeviceService(new(DeviceRepositoryMock), nil, changeFeed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// The owner and both users the device is shared with watch it.
+	streams := map[string]repository.DeviceEventStream{}
+	for _, user := range []string{"first", "second", "owner"} {
+		stream, err := deviceService.WatchDevices(callerContext(user, nil), "", "", "")
+		if err != nil {
+			t.Fatalf("Error was not expected while %s watches devices: %s", user, err)
+		}
+		streams[user] = stream
+	}
+
+	// Act
+	changeFeed.Publish(model.DeviceEvent{Type: model.DeviceUpdated, Device: &model.Device{
+		ID: "device1", UserID: "owner",
+		Shares: []model.DeviceShare{{UserID: "first", Role: model.RoleViewer}, {UserID: "second", Role: model.RoleEditor}},
+	}})
+
+	// Assert: presenting the device to one watcher does not change it for the others.
+	wants := []struct {
+		user   string
+		role   model.DeviceRole
+		shares int
+	}{
+		{"first", model.RoleViewer, 0},
+		{"second", model.RoleEditor, 0},
+		{"owner", model.RoleOwner, 2},
+	}
+	for _, want := range wants {
+		event, err := streams[want.user].Next(ctx)
+		if err != nil {
+			t.Fatalf("Expected an event for %s, got %v", want.user, err)
+		}
+
+		if event.Device.Role != want.role || len(event.Device.Shares) != want.shares {
+			t.Errorf("Expected %s to see the %s role and %d shares, got %+v", want.user, want.role, want.shares, event.Device)
+		}
+	}
+}
+
+func TestDeviceService_CreateDevice_DefaultStatus(t *testing.T) {
+	// Arrange
+	device := &model.Device{SerialNumber: "123456789", DeviceType: "tracker"}
+	invalid := &model.Device{SerialNumber: "987654321", DeviceType: "tracker", Status: "broken"}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("CreateDevice", mock.Anything, device).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(trackerType), nil)
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), device)
+	invalidErr := deviceService.CreateDevice(callerContext("caller", nil), invalid)
+
+	// Assert
+	if err != nil || device.Status != model.StatusProvisioned {
+		t.Errorf("Expected a provisioned device, got %q (%v)", device.Status, err)
+	}
+
+	if !errs.Is(invalidErr, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument for an unknown status, got %v", invalidErr)
+	}
+}
+
+func TestDeviceService_ChangeDeviceStatus(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := primitive.NewObjectID().Hex()
+	expectedChange := &model.StatusChange{
+		From:      model.StatusActive,
+		To:        model.StatusLost,
+		ChangedBy: "caller",
+		ChangedAt: now.Unix(),
+		Reason:    "left on the bus",
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Status: model.StatusActive}, nil)
+	repository.On("ChangeDeviceStatus", mock.Anything, id, expectedChange, int64(4)).
+		Return(&model.Device{ID: id, UserID: "caller", Status: model.StatusLost, LastStatusChange: expectedChange, Version: 5}, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+	deviceService.Clock = func() time.Time { return now }
+
+	// Act
+	device, err := deviceService.ChangeDeviceStatus(callerContext("caller", nil), id, model.StatusLost, "left on the bus", 4)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while changing device status: %s", err)
+	}
+
+	if device.Status != model.StatusLost || device.LastStatusChange == nil || device.LastStatusChange.ChangedBy != "caller" {
+		t.Errorf("Expected the recorded change on the returned device, got %+v", device)
+	}
+
+	if device.Version != 5 || device.Role != model.RoleOwner {
+		t.Errorf("Expected the updated device with its new version, got %+v", device)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeviceService_ChangeDeviceStatus_IllegalTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from model.DeviceStatus
+		to   model.DeviceStatus
+	}{
+		{name: "decommissioned to active", from: model.StatusDecommissioned, to: model.StatusActive},
+		{name: "provisioned to lost", from: model.StatusProvisioned, to: model.StatusLost},
+		{name: "same status", from: model.StatusActive, to: model.StatusActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID().Hex()
+
+			repository := new(DeviceRepositoryMock)
+			repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Status: tt.from}, nil)
+
+			deviceService := service.NewDeviceService(repository, nil, nil)
+
+			// Act
+			_, err := deviceService.ChangeDeviceStatus(callerContext("caller", nil), id, tt.to, "", 0)
+
+			// Assert
+			if !errs.Is(err, errs.FailedPrecondition) {
+				t.Errorf("Expected failed precondition, got %v", err)
+			}
+
+			repository.AssertNotCalled(t, "ChangeDeviceStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeviceService_PatchDevice_Status(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: id, Name: "Renamed Device", Status: model.StatusMaintenance}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Status: model.StatusActive}, nil)
+	var change *model.StatusChange
+	repository.On("PatchDevice", mock.Anything, device, []string{"name"}, mock.Anything).
+		Run(func(args mock.Arguments) { change = args.Get(3).(*model.StatusChange) }).
+		Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, err := deviceService.PatchDevice(callerContext("caller", nil), device, []string{"name", "status"})
+
+	// Assert
+	if err != nil {
+		t.Errorf("Error was not expected while patching device: %s", err)
+	}
+
+	// The status change is written together with the name, not on its own.
+	if change == nil || change.From != model.StatusActive || change.To != model.StatusMaintenance || change.ChangedBy != "caller" {
+		t.Errorf("Expected the status change to be part of the patch, got %+v", change)
+	}
+
+	repository.AssertExpectations(t)
+	repository.AssertNotCalled(t, "ChangeDeviceStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeviceService_FindDevicesNear(t *testing.T) {
+	// Arrange
+	query := &model.NearbyDevicesQuery{Latitude: 52.52, Longitude: 13.40, Radius: 500}
+	nearby := []*model.NearbyDevice{
+		{Device: &model.Device{ID: "1", UserID: "caller"}, Distance: 42},
+		{Device: &model.Device{ID: "2", UserID: "owner", Shares: []model.DeviceShare{{UserID: "caller", Role: model.RoleViewer}}}, Distance: 84},
+	}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("FindDevicesNear", mock.Anything, query).Return(nearby, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	result, err := deviceService.FindDevicesNear(callerContext("caller", nil), query)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while finding devices: %s", err)
+	}
+
+	if query.UserID != "caller" || query.SharedAt == 0 || len(result) != 2 {
+		t.Errorf("Expected the caller's nearby devices, got %+v for %+v", result, query)
+	}
+
+	if result[0].Device.Role != model.RoleOwner || result[1].Device.Role != model.RoleViewer || result[1].Device.Shares != nil {
+		t.Errorf("Expected the caller's role on each nearby device, got %+v and %+v", result[0].Device, result[1].Device)
+	}
+}
+
+func TestDeviceService_FindDevicesNear_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *model.NearbyDevicesQuery
+		kind  errs.Kind
+	}{
+		{name: "latitude out of range", query: &model.NearbyDevicesQuery{Latitude: 95, Radius: 500}, kind: errs.InvalidArgument},
+		{name: "no radius", query: &model.NearbyDevicesQuery{}, kind: errs.InvalidArgument},
+		{name: "radius too large", query: &model.NearbyDevicesQuery{Radius: 2000000}, kind: errs.InvalidArgument},
+		{name: "unknown status", query: &model.NearbyDevicesQuery{Radius: 500, Filter: model.DeviceFilter{Status: "parked"}}, kind: errs.InvalidArgument},
+		{name: "another user", query: &model.NearbyDevicesQuery{UserID: "someone else", Radius: 500}, kind: errs.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			deviceService := service.NewDeviceService(new(DeviceRepositoryMock), nil, nil)
+
+			// Act
+			_, err := deviceService.FindDevicesNear(callerContext("caller", nil), tt.query)
+
+			// Assert
+			if !errs.Is(err, tt.kind) {
+				t.Errorf("Expected %v, got %v", tt.kind, err)
+			}
+		})
+	}
+}
+
+func TestDeviceService_SetDeviceLabels(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	tags := []string{"fleet"}
+	labels := map[string]string{"env": "prod"}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{
+		ID:     id,
+		UserID: "owner",
+		Shares: []model.DeviceShare{{UserID: "editor", Role: model.RoleEditor}, {UserID: "viewer", Role: model.RoleViewer}},
+	}, nil)
+	repository.On("SetDeviceLabels", mock.Anything, id, tags, labels, service.MaxDeviceTags, service.MaxDeviceLabels).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, viewerErr := deviceService.SetDeviceLabels(callerContext("viewer", nil), id, tags, labels)
+	device, err := deviceService.SetDeviceLabels(callerContext("editor", nil), id, tags, labels)
+
+	// Assert
+	if !errs.Is(viewerErr, errs.PermissionDenied) {
+		t.Errorf("Expected a viewer to be denied, got %v", viewerErr)
+	}
+
+	if err != nil {
+		t.Fatalf("Error was not expected while setting labels: %s", err)
+	}
+
+	if device.Role != model.RoleEditor {
+		t.Errorf("Expected the device read back for the editor, got %+v", device)
+	}
+
+	repository.AssertNumberOfCalls(t, "SetDeviceLabels", 1)
+}
+
+func TestDeviceService_SetDeviceLabels_Invalid(t *testing.T) {
+	tooMany := make([]string, 0, service.MaxDeviceTags)
+	for i := 0; i < service.MaxDeviceTags; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("tag-%d", i))
+	}
+
+	tests := []struct {
+		name   string
+		tags   []string
+		labels map[string]string
+	}{
+		{"nothing to set", nil, nil},
+		{"empty tag", []string{""}, nil},
+		{"duplicate tag", []string{"fleet", "fleet"}, nil},
+		{"dotted key", nil, map[string]string{"env.name": "prod"}},
+		{"invalid value", nil, map[string]string{"env": "prod!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			id := primitive.NewObjectID().Hex()
+
+			repository := new(DeviceRepositoryMock)
+			repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Tags: []string{"spare"}}, nil)
+
+			deviceService := service.NewDeviceService(repository, nil, nil)
+
+			// Act
+			_, err := deviceService.SetDeviceLabels(callerContext("caller", nil), id, tt.tags, tt.labels)
+
+			// Assert
+			if !errs.Is(err, errs.InvalidArgument) {
+				t.Errorf("Expected invalid argument error, got %v", err)
+			}
+
+			repository.AssertNotCalled(t, "SetDeviceLabels", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeviceService_SetDeviceLabels_LimitReached(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	tooMany := make([]string, 0, service.MaxDeviceTags)
+	for i := 0; i < service.MaxDeviceTags; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("tag-%d", i))
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Tags: []string{"spare"}}, nil)
+	// Other labels were set since the device was read.
+	deviceRepository.On("SetDeviceLabels", mock.Anything, id, []string{"fleet"}, map[string]string(nil), service.MaxDeviceTags, service.MaxDeviceLabels).Return(repository.ErrLabelLimitReached)
+
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+
+	// Act
+	_, readErr := deviceService.SetDeviceLabels(callerContext("caller", nil), id, tooMany, nil)
+	_, writeErr := deviceService.SetDeviceLabels(callerContext("caller", nil), id, []string{"fleet"}, nil)
+
+	// Assert
+	if !errs.Is(readErr, errs.FailedPrecondition) {
+		t.Errorf("Expected a failed precondition for too many tags on the device, got %v", readErr)
+	}
+
+	if !errs.Is(writeErr, errs.FailedPrecondition) {
+		t.Errorf("Expected a failed precondition once the device has too many labels, got %v", writeErr)
+	}
+
+	deviceRepository.AssertNumberOfCalls(t, "SetDeviceLabels", 1)
+}
+
+func TestDeviceService_RemoveDeviceLabels(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+	repository.On("RemoveDeviceLabels", mock.Anything, id, []string{"fleet"}, []string{"env"}).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, err := deviceService.RemoveDeviceLabels(callerContext("caller", nil), id, []string{"fleet"}, []string{"env"})
+	_, invalidErr := deviceService.RemoveDeviceLabels(callerContext("caller", nil), id, nil, []string{"env.name"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while removing labels: %s", err)
+	}
+
+	if !errs.Is(invalidErr, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument error for a dotted key, got %v", invalidErr)
+	}
+
+	repository.AssertNumberOfCalls(t, "RemoveDeviceLabels", 1)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// statusTransitions lists the statuses each status may move to. A
+// decommissioned device is retired for good.
+var statusTransitions = map[model.DeviceStatus][]model.DeviceStatus{
+	model.StatusProvisioned: {model.StatusActive, model.StatusInactive, model.StatusDecommissioned},
+	model.StatusActive:      {model.StatusInactive, model.StatusLost, model.StatusMaintenance, model.StatusDecommissioned},
+	model.StatusInactive:    {model.StatusActive, model.StatusLost, model.StatusMaintenance, model.StatusDecommissioned},
+	model.StatusLost:        {model.StatusActive, model.StatusInactive, model.StatusDecommissioned},
+	model.StatusMaintenance: {model.StatusActive, model.StatusInactive, model.StatusDecommissioned},
+}
+
+// canTransition reports whether a device may move from one status to another.
+// Devices still carrying a status from before the lifecycle was introduced
+// may move to any status.
+func canTransition(from, to model.DeviceStatus) bool {
+	if !from.Valid() {
+		return true
+	}
+
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateStatus checks that status is one of the defined statuses.
+func validateStatus(field string, status model.DeviceStatus) error {
+	if !status.Valid() {
+		return errs.InvalidField(field, fmt.Sprintf("unknown status %q", status))
+	}
+	return nil
+}
+
+// statusChange checks the transition table and returns the change that moves
+// device to status on behalf of the caller.
+func (s *DeviceServiceImpl) statusChange(ctx context.Context, device *model.Device, status model.DeviceStatus, reason string) (*model.StatusChange, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateStatus("status", status); err != nil {
+		return nil, err
+	}
+
+	if !canTransition(device.Status, status) {
+		return nil, errs.New(errs.FailedPrecondition, "ILLEGAL_STATUS_TRANSITION",
+			fmt.Sprintf("device cannot move from %s to %s", device.Status, status)).
+			WithMetadata("from", string(device.Status)).
+			WithMetadata("to", string(status))
+	}
+
+	return &model.StatusChange{
+		From:      device.Status,
+		To:        status,
+		ChangedBy: identity.UserID,
+		ChangedAt: s.Clock().Unix(),
+		Reason:    reason,
+	}, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+)
+
+const (
+	// DefaultTrashRetention is how long deleted devices stay in the trash before they are purged.
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// DefaultPurgeInterval is how often the trash is checked for devices to purge.
+	DefaultPurgeInterval = time.Hour
+	// purgeBatchSize is the number of devices read from the trash at once.
+	purgeBatchSize = 100
+)
+
+// ListDeletedDevices implements DeviceService.
+// An empty userId lists the caller's own devices in the trash.
+func (s *DeviceServiceImpl) ListDeletedDevices(ctx context.Context, userId string) ([]*model.Device, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		userId = identity.UserID
+	}
+
+	if err := authorizeOwner(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	devices, err := s.DeviceRepository.GetDeletedDevices(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, device := range devices {
+		device.Role = model.RoleOwner
+	}
+
+	return devices, nil
+}
+
+// RestoreDevice implements DeviceService.
+// Only the owner, or an admin, may take a device out of the trash. Restoring
+// fails with AlreadyExists when its serial number was registered again.
+func (s *DeviceServiceImpl) RestoreDevice(ctx context.Context, id string) (*model.Device, error) {
+	device, err := s.DeviceRepository.GetDeletedDeviceById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwner(ctx, device.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.DeviceRepository.RestoreDevice(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.reload(ctx, id)
+}
+
+// DevicePurger removes devices from the trash for good once they have been in
+// it for longer than Retention, together with their history.
+type DevicePurger struct {
+	DeviceRepository repository.DeviceRepository
+	History          []repository.DeviceHistory
+	Retention        time.Duration
+	Interval         time.Duration
+	Clock            Clock
+}
+
+// NewDevicePurger returns a new DevicePurger that uses the system clock and
+// checks the trash every DefaultPurgeInterval. The history repositories are
+// erased for every purged device.
+func NewDevicePurger(deviceRepository repository.DeviceRepository, retention time.Duration, history ...repository.DeviceHistory) *DevicePurger {
+	return &DevicePurger{
+		DeviceRepository: deviceRepository,
+		History:          history,
+		Retention:        retention,
+		Interval:         DefaultPurgeInterval,
+		Clock:            time.Now,
+	}
+}
+
+// Run purges the trash, and finishes interrupted history wipes, every
+// Interval until ctx is done.
+func (p *DevicePurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := p.PurgeDeletedDevices(ctx); err != nil {
+			log.Printf("failed to purge deleted devices: %v", err)
+		} else if purged > 0 {
+			log.Printf("purged %d deleted devices", purged)
+		}
+
+		if wiped, err := p.FinishHistoryWipes(ctx); err != nil {
+			log.Printf("failed to finish history wipes: %v", err)
+		} else if wiped > 0 {
+			log.Printf("finished %d history wipes", wiped)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeDeletedDevices removes every device whose retention has expired and
+// returns how many were removed. The history is removed before the device, so
+// a purge that fails halfway leaves the device in the trash to be purged again.
+// A device restored in the meantime only loses history that was due anyway.
+func (p *DevicePurger) PurgeDeletedDevices(ctx context.Context) (int, error) {
+	deletedBefore := p.Clock().Add(-p.Retention).Unix()
+	purged := 0
+
+	for {
+		devices, err := p.DeviceRepository.GetDevicesDeletedBefore(ctx, deletedBefore, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, device := range devices {
+			for _, history := range p.History {
+				if err := history.DeleteDeviceHistory(ctx, device.ID, 0); err != nil {
+					return purged, err
+				}
+			}
+
+			err := p.DeviceRepository.PurgeDevice(ctx, device.ID, deletedBefore)
+			if errors.Is(err, repository.ErrDeviceNotFound) {
+				// Restored, deleted again or purged by another instance.
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			purged++
+		}
+
+		if len(devices) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// FinishHistoryWipes erases the history of every device whose history wipe on
+// a transfer was interrupted, and returns how many were finished.
+func (p *DevicePurger) FinishHistoryWipes(ctx context.Context) (int, error) {
+	wiped := 0
+
+	for {
+		devices, err := p.DeviceRepository.GetDevicesPendingHistoryWipe(ctx, purgeBatchSize)
+		if err != nil {
+			return wiped, err
+		}
+
+		for _, device := range devices {
+			err := wipeDeviceHistory(ctx, p.DeviceRepository, p.History, device.ID, device.HistoryWipeBefore)
+			if errors.Is(err, repository.ErrDeviceNotFound) {
+				// Purged or transferred again in the meantime.
+				continue
+			}
+			if err != nil {
+				return wiped, err
+			}
+			wiped++
+		}
+
+		if len(devices) < purgeBatchSize {
+			return wiped, nil
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDeviceService_ListDeletedDevices(t *testing.T) {
+	// Arrange
+	deleted := []*model.Device{{ID: primitive.NewObjectID().Hex(), UserID: "owner", DeletedAt: 1700000000}}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeletedDevices", mock.Anything, "owner").Return(deleted, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	devices, err := deviceService.ListDeletedDevices(callerContext("owner", nil), "")
+	_, otherErr := deviceService.ListDeletedDevices(callerContext("intruder", nil), "owner")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while listing deleted devices: %s", err)
+	}
+
+	if len(devices) != 1 || devices[0].Role != model.RoleOwner {
+		t.Errorf("Expected the caller's deleted device with the owner role, got %+v", devices)
+	}
+
+	if !errors.Is(otherErr, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied for another user's trash, got %v", otherErr)
+	}
+
+	repository.AssertNumberOfCalls(t, "GetDeletedDevices", 1)
+}
+
+func TestDeviceService_RestoreDevice(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	restored := &model.Device{ID: id, UserID: "owner", SerialNumber: "SN-1"}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeletedDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner", DeletedAt: 1700000000}, nil)
+	repository.On("RestoreDevice", mock.Anything, id).Return(nil).Once()
+	repository.On("GetDeviceById", mock.Anything, id).Return(restored, nil)
+
+	deviceService := service.NewDeviceService(repository, nil, nil)
+
+	// Act
+	_, intruderErr := deviceService.RestoreDevice(callerContext("intruder", nil), id)
+	device, err := deviceService.RestoreDevice(callerContext("owner", nil), id)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while restoring the device: %s", err)
+	}
+
+	if device.ID != id || device.Role != model.RoleOwner {
+		t.Errorf("Expected the restored device with the owner role, got %+v", device)
+	}
+
+	if !errors.Is(intruderErr, service.ErrPermissionDenied) {
+		t.Errorf("Expected permission denied for another user's device, got %v", intruderErr)
+	}
+
+	repository.AssertExpectations(t)
+}
+
+func TestDevicePurger_PurgeDeletedDevices(t *testing.T) {
+	// Arrange
+	now := time.Unix(1700000000, 0)
+	expired := &model.Device{ID: primitive.NewObjectID().Hex(), DeletedAt: 1}
+	restored := &model.Device{ID: primitive.NewObjectID().Hex(), DeletedAt: 2}
+	history := &fakeDeviceHistory{}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDevicesDeletedBefore", mock.Anything, now.Add(-service.DefaultTrashRetention).Unix(), mock.Anything).
+		Return([]*model.Device{expired, restored}, nil)
+	deviceRepository.On("PurgeDevice", mock.Anything, expired.ID, now.Add(-service.DefaultTrashRetention).Unix()).Return(nil)
+	// The second device was restored after it was read.
+	deviceRepository.On("PurgeDevice", mock.Anything, restored.ID, mock.Anything).Return(repository.ErrDeviceNotFound)
+
+	purger := service.NewDevicePurger(deviceRepository, service.DefaultTrashRetention, history)
+	purger.Clock = func() time.Time { return now }
+
+	// Act
+	purged, err := purger.PurgeDeletedDevices(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while purging deleted devices: %s", err)
+	}
+
+	if purged != 1 {
+		t.Errorf("Expected one purged device, got %d", purged)
+	}
+
+	if len(history.deleted) != 2 || history.deleted[0] != expired.ID {
+		t.Errorf("Expected the history of both expired devices to be erased, got %v", history.deleted)
+	}
+}
+
+func TestDevicePurger_PurgeDeletedDevices_HistoryFailure(t *testing.T) {
+	// Arrange
+	expired := &model.Device{ID: primitive.NewObjectID().Hex(), DeletedAt: 1}
+	history := &fakeDeviceHistory{err: errors.New("history unavailable")}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDevicesDeletedBefore", mock.Anything, mock.Anything, mock.Anything).Return([]*model.Device{expired}, nil)
+
+	purger := service.NewDevicePurger(deviceRepository, service.DefaultTrashRetention, history)
+
+	// Act
+	_, err := purger.PurgeDeletedDevices(context.Background())
+
+	// Assert
+	if err == nil {
+		t.Fatal("Expected the history failure to be returned")
+	}
+
+	// The device stays in the trash, so its history is erased on the next run.
+	deviceRepository.AssertNotCalled(t, "PurgeDevice", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDevicePurger_FinishHistoryWipes(t *testing.T) {
+	// Arrange
+	pending := &model.Device{ID: primitive.NewObjectID().Hex(), HistoryWipeBefore: 1700000000}
+	history := &fakeDeviceHistory{}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDevicesPendingHistoryWipe", mock.Anything, mock.Anything).Return([]*model.Device{pending}, nil)
+	deviceRepository.On("CompleteHistoryWipe", mock.Anything, pending.ID, pending.HistoryWipeBefore).Return(nil).Once()
+
+	purger := service.NewDevicePurger(deviceRepository, service.DefaultTrashRetention, history)
+
+	// Act
+	wiped, err := purger.FinishHistoryWipes(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while finishing history wipes: %s", err)
+	}
+
+	if wiped != 1 || len(history.deleted) != 1 || history.deleted[0] != pending.ID {
+		t.Errorf("Expected the pending history to be wiped, got %d and %v", wiped, history.deleted)
+	}
+
+	// The retried wipe stops where the transfer was accepted, so what the
+	// device has reported to its new owner since is kept.
+	if history.before[0] != pending.HistoryWipeBefore {
+		t.Errorf("Expected the history up to %d to be wiped, got %v", pending.HistoryWipeBefore, history.before)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/schema"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// ErrDeviceTypeInUse is returned when a device type that devices still have is deleted.
+var ErrDeviceTypeInUse = errs.New(errs.FailedPrecondition, "DEVICE_TYPE_IN_USE", "device type is still used by devices")
+
+// deviceTypeIDPattern allows IDs such as "gps-tracker": lowercase letters,
+// digits and '-', starting and ending with a letter or digit.
+var deviceTypeIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type DeviceTypeService interface {
+	CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error
+	GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error)
+	ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error)
+	UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error
+	DeleteDeviceType(ctx context.Context, id string) error
+}
+
+// DeviceTypeServiceImpl maintains the device-type catalog. Every caller may
+// read the catalog; only admins may change it.
+type DeviceTypeServiceImpl struct {
+	DeviceTypeRepository repository.DeviceTypeRepository
+	DeviceRepository     repository.DeviceRepository
+	Clock                Clock
+}
+
+// NewDeviceTypeService returns a new DeviceTypeServiceImpl that uses the system clock.
+func NewDeviceTypeService(deviceTypeRepository repository.DeviceTypeRepository, deviceRepository repository.DeviceRepository) *DeviceTypeServiceImpl {
+	return &DeviceTypeServiceImpl{
+		DeviceTypeRepository: deviceTypeRepository,
+		DeviceRepository:     deviceRepository,
+		Clock:                time.Now,
+	}
+}
+
+// CreateDeviceType implements DeviceTypeService.
+func (s *DeviceTypeServiceImpl) CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	violations := validateDeviceType(deviceType)
+	if !deviceTypeIDPattern.MatchString(deviceType.ID) {
+		violations = append([]errs.FieldViolation{{Field: "device_type.id", Description: "must be at most 63 lowercase letters, digits or '-', starting and ending with a letter or digit"}}, violations...)
+	}
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	now := s.Clock().Unix()
+	deviceType.CreatedAt = now
+	deviceType.UpdatedAt = now
+
+	return s.DeviceTypeRepository.CreateDeviceType(ctx, deviceType)
+}
+
+// GetDeviceType implements DeviceTypeService.
+func (s *DeviceTypeServiceImpl) GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error) {
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.DeviceTypeRepository.GetDeviceType(ctx, id)
+}
+
+// ListDeviceTypes implements DeviceTypeService.
+func (s *DeviceTypeServiceImpl) ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error) {
+	if _, err := callerIdentity(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.DeviceTypeRepository.ListDeviceTypes(ctx)
+}
+
+// UpdateDeviceType implements DeviceTypeService.
+// Devices that already exist are not checked against a changed serial number
+// pattern or attributes schema.
+func (s *DeviceTypeServiceImpl) UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	existing, err := s.DeviceTypeRepository.GetDeviceType(ctx, deviceType.ID)
+	if err != nil {
+		return err
+	}
+
+	if violations := validateDeviceType(deviceType); len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	deviceType.CreatedAt = existing.CreatedAt
+	deviceType.UpdatedAt = s.Clock().Unix()
+
+	return s.DeviceTypeRepository.UpdateDeviceType(ctx, deviceType)
+}
+
+// DeleteDeviceType implements DeviceTypeService.
+// A device type can only be deleted once no device has it. Devices are counted
+// again after the delete, and the type is put back if a device was given it in
+// the meantime; only a device written after that second count can still end up
+// with a type the catalog no longer has.
+func (s *DeviceTypeServiceImpl) DeleteDeviceType(ctx context.Context, id string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	deviceType, err := s.DeviceTypeRepository.GetDeviceType(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.DeviceRepository.CountDevicesByType(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return deviceTypeInUse(count)
+	}
+
+	if err := s.DeviceTypeRepository.DeleteDeviceType(ctx, id); err != nil {
+		return err
+	}
+
+	count, err = s.DeviceRepository.CountDevicesByType(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		if err := s.DeviceTypeRepository.CreateDeviceType(ctx, deviceType); err != nil {
+			return err
+		}
+		return deviceTypeInUse(count)
+	}
+
+	return nil
+}
+
+// deviceTypeInUse returns ErrDeviceTypeInUse for a type that count devices have.
+func deviceTypeInUse(count int64) error {
+	inUse := *ErrDeviceTypeInUse
+	return inUse.WithMetadata("device_count", strconv.FormatInt(count, 10))
+}
+
+// validateDeviceType reports every invalid field of a catalog entry other than its ID.
+func validateDeviceType(deviceType *model.DeviceType) []errs.FieldViolation {
+	var violations []errs.FieldViolation
+
+	if deviceType.DisplayName == "" {
+		violations = append(violations, errs.FieldViolation{Field: "device_type.display_name", Description: "must not be empty"})
+	}
+
+	seen := make(map[model.Capability]bool, len(deviceType.Capabilities))
+	for i, capability := range deviceType.Capabilities {
+		field := fmt.Sprintf("device_type.capabilities[%d]", i)
+		switch {
+		case !capability.Valid():
+			violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf("unknown capability %q", capability)})
+		case seen[capability]:
+			violations = append(violations, errs.FieldViolation{Field: field, Description: "is listed more than once"})
+		}
+		seen[capability] = true
+	}
+
+	if _, err := serialNumberPattern(deviceType.SerialNumberPattern); err != nil {
+		violations = append(violations, errs.FieldViolation{Field: "device_type.serial_number_pattern", Description: err.Error()})
+	}
+
+	if _, err := attributesSchema(deviceType.AttributesSchema); err != nil {
+		violations = append(violations, errs.FieldViolation{Field: "device_type.attributes_schema", Description: err.Error()})
+	}
+
+	if !deviceType.BatteryChemistry.Valid() {
+		violations = append(violations, errs.FieldViolation{Field: "device_type.battery_chemistry", Description: fmt.Sprintf("unknown battery chemistry %q", deviceType.BatteryChemistry)})
+	}
+
+	if deviceType.ReportingInterval < 0 {
+		violations = append(violations, errs.FieldViolation{Field: "device_type.reporting_interval", Description: "must not be negative"})
+	}
+
+	return violations
+}
+
+// serialNumberPattern compiles the serial number pattern of a device type so
+// that it has to match the whole serial number. An empty pattern matches every
+// serial number and compiles to nil.
+func serialNumberPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// attributesSchema compiles the JSON Schema of the attributes of a device
+// type. An empty schema allows any attributes and compiles to nil.
+func attributesSchema(document string) (*schema.Schema, error) {
+	if document == "" {
+		return nil, nil
+	}
+
+	return schema.Compile([]byte(document))
+}
+
+// deviceTypeChecker checks devices against the catalog, looking up and
+// compiling each device type once.
+type deviceTypeChecker struct {
+	repository repository.DeviceTypeRepository
+	types      map[string]*compiledDeviceType
+}
+
+// compiledDeviceType is a device type with its patterns compiled. A nil
+// compiledDeviceType stands for a type that is not in the catalog.
+type compiledDeviceType struct {
+	*model.DeviceType
+	serialNumber *regexp.Regexp
+	attributes   *schema.Schema
+}
+
+func newDeviceTypeChecker(deviceTypeRepository repository.DeviceTypeRepository) *deviceTypeChecker {
+	return &deviceTypeChecker{repository: deviceTypeRepository, types: make(map[string]*compiledDeviceType)}
+}
+
+// check reports whether the device type of device is in the catalog, and its
+// serial number and attributes match the type. field prefixes the fields of
+// the violations, e.g. "device" or "devices[3]".
+func (c *deviceTypeChecker) check(ctx context.Context, field string, device *model.Device) ([]errs.FieldViolation, error) {
+	if device.DeviceType == "" {
+		return []errs.FieldViolation{{Field: field + ".device_type", Description: "is required"}}, nil
+	}
+
+	deviceType, err := c.lookup(ctx, device.DeviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if deviceType == nil {
+		return []errs.FieldViolation{{Field: field + ".device_type", Description: fmt.Sprintf("unknown device type %q; see ListDeviceTypes", device.DeviceType)}}, nil
+	}
+
+	var violations []errs.FieldViolation
+	if deviceType.serialNumber != nil && !deviceType.serialNumber.MatchString(device.SerialNumber) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".serial_number", Description: fmt.Sprintf("does not match the pattern %q of device type %q", deviceType.SerialNumberPattern, deviceType.ID)})
+	}
+
+	if deviceType.attributes != nil {
+		attributes := map[string]interface{}{}
+		if device.Attributes != nil {
+			attributes = device.Attributes
+		}
+		for _, invalid := range deviceType.attributes.Validate(attributes) {
+			attributeField := field + ".attributes"
+			if invalid.Path != "" {
+				attributeField += "." + invalid.Path
+			}
+			violations = append(violations, errs.FieldViolation{Field: attributeField, Description: invalid.Message})
+		}
+	}
+
+	return violations, nil
+}
+
+// lookup returns the compiled catalog entry of a device type, or nil if the
+// catalog does not have it.
+func (c *deviceTypeChecker) lookup(ctx context.Context, id string) (*compiledDeviceType, error) {
+	if compiled, ok := c.types[id]; ok {
+		return compiled, nil
+	}
+
+	deviceType, err := c.repository.GetDeviceType(ctx, id)
+	if errs.Is(err, errs.NotFound) {
+		c.types[id] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &compiledDeviceType{DeviceType: deviceType}
+	if compiled.serialNumber, err = serialNumberPattern(deviceType.SerialNumberPattern); err != nil {
+		return nil, err
+	}
+	if compiled.attributes, err = attributesSchema(deviceType.AttributesSchema); err != nil {
+		return nil, err
+	}
+
+	c.types[id] = compiled
+	return compiled, nil
+}
+
+// Ensure DeviceTypeServiceImpl implements DeviceTypeService interface
+var _ DeviceTypeService = &DeviceTypeServiceImpl{}
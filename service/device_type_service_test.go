@@ -0,0 +1,313 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the device type repository
+type DeviceTypeRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *DeviceTypeRepositoryMock) CreateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	args := r.Called(ctx, deviceType)
+	return args.Error(0)
+}
+
+func (r *DeviceTypeRepositoryMock) GetDeviceType(ctx context.Context, id string) (*model.DeviceType, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(*model.DeviceType), args.Error(1)
+}
+
+func (r *DeviceTypeRepositoryMock) ListDeviceTypes(ctx context.Context) ([]*model.DeviceType, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]*model.DeviceType), args.Error(1)
+}
+
+func (r *DeviceTypeRepositoryMock) UpdateDeviceType(ctx context.Context, deviceType *model.DeviceType) error {
+	args := r.Called(ctx, deviceType)
+	return args.Error(0)
+}
+
+func (r *DeviceTypeRepositoryMock) DeleteDeviceType(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+// trackerType is the device type of the devices created in tests.
+var trackerType = &model.DeviceType{ID: "tracker", DisplayName: "Tracker"}
+
+// newCatalog returns a device type repository holding deviceTypes; every
+// other device type is not found.
+func newCatalog(deviceTypes ...*model.DeviceType) *DeviceTypeRepositoryMock {
+	catalog := new(DeviceTypeRepositoryMock)
+	for _, deviceType := range deviceTypes {
+		catalog.On("GetDeviceType", mock.Anything, deviceType.ID).Return(deviceType, nil)
+	}
+	catalog.On("GetDeviceType", mock.Anything, mock.Anything).Return((*model.DeviceType)(nil), repository.ErrDeviceTypeNotFound)
+	return catalog
+}
+
+func TestDeviceTypeService_CreateDeviceType(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	deviceType := &model.DeviceType{
+		ID:                  "gps-tracker",
+		DisplayName:         "GPS Tracker",
+		Capabilities:        []model.Capability{model.CapabilityGPS, model.CapabilityCellular},
+		SerialNumberPattern: `GT-[0-9]{6}`,
+		BatteryChemistry:    model.ChemistryLithiumIon,
+		ReportingInterval:   60,
+	}
+
+	catalog := new(DeviceTypeRepositoryMock)
+	catalog.On("CreateDeviceType", mock.Anything, deviceType).Return(nil)
+
+	deviceTypeService := service.NewDeviceTypeService(catalog, nil)
+	deviceTypeService.Clock = func() time.Time { return now }
+
+	// Act
+	userErr := deviceTypeService.CreateDeviceType(callerContext("user", nil), deviceType)
+	err := deviceTypeService.CreateDeviceType(callerContext("admin", adminClaims), deviceType)
+
+	// Assert
+	if !errors.Is(userErr, service.ErrAdminRequired) {
+		t.Errorf("Expected admin required for a user, got %v", userErr)
+	}
+
+	if err != nil {
+		t.Fatalf("Error was not expected while creating a device type: %s", err)
+	}
+
+	if deviceType.CreatedAt != now.Unix() || deviceType.UpdatedAt != now.Unix() {
+		t.Errorf("Expected the creation time to be set, got %+v", deviceType)
+	}
+
+	catalog.AssertNumberOfCalls(t, "CreateDeviceType", 1)
+}
+
+func TestDeviceTypeService_CreateDeviceType_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceType *model.DeviceType
+		field      string
+	}{
+		{"id with spaces", &model.DeviceType{ID: "GPS Tracker", DisplayName: "GPS Tracker"}, "device_type.id"},
+		{"id with underscore", &model.DeviceType{ID: "gps_tracker", DisplayName: "GPS Tracker"}, "device_type.id"},
+		{"missing display name", &model.DeviceType{ID: "gps-tracker"}, "device_type.display_name"},
+		{"unknown capability", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", Capabilities: []model.Capability{"teleport"}}, "device_type.capabilities[0]"},
+		{"duplicate capability", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", Capabilities: []model.Capability{model.CapabilityGPS, model.CapabilityGPS}}, "device_type.capabilities[1]"},
+		{"malformed pattern", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", SerialNumberPattern: "GT-("}, "device_type.serial_number_pattern"},
+		{"unknown chemistry", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", BatteryChemistry: "potato"}, "device_type.battery_chemistry"},
+		{"negative interval", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", ReportingInterval: -1}, "device_type.reporting_interval"},
+		{"unsupported schema keyword", &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", AttributesSchema: `{"$ref": "#/imei"}`}, "device_type.attributes_schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			catalog := new(DeviceTypeRepositoryMock)
+			deviceTypeService := service.NewDeviceTypeService(catalog, nil)
+
+			// Act
+			err := deviceTypeService.CreateDeviceType(callerContext("admin", adminClaims), tt.deviceType)
+
+			// Assert
+			var typed *errs.Error
+			if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument {
+				t.Fatalf("Expected invalid argument error, got %v", err)
+			}
+
+			if len(typed.FieldViolations) != 1 || typed.FieldViolations[0].Field != tt.field {
+				t.Errorf("Expected a violation of %s, got %+v", tt.field, typed.FieldViolations)
+			}
+
+			catalog.AssertNotCalled(t, "CreateDeviceType", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeviceTypeService_ListDeviceTypes(t *testing.T) {
+	// Arrange
+	deviceTypes := []*model.DeviceType{{ID: "gps-tracker", DisplayName: "GPS Tracker"}}
+
+	catalog := new(DeviceTypeRepositoryMock)
+	catalog.On("ListDeviceTypes", mock.Anything).Return(deviceTypes, nil)
+
+	deviceTypeService := service.NewDeviceTypeService(catalog, nil)
+
+	// Act
+	listed, err := deviceTypeService.ListDeviceTypes(callerContext("user", nil))
+	_, anonymousErr := deviceTypeService.ListDeviceTypes(context.Background())
+
+	// Assert
+	if err != nil || len(listed) != 1 {
+		t.Errorf("Expected every caller to read the catalog, got %v, %v", listed, err)
+	}
+
+	if !errors.Is(anonymousErr, service.ErrUnauthenticated) {
+		t.Errorf("Expected unauthenticated error, got %v", anonymousErr)
+	}
+}
+
+func TestDeviceTypeService_DeleteDeviceType_InUse(t *testing.T) {
+	// Arrange
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("CountDevicesByType", mock.Anything, "gps-tracker").Return(int64(3), nil)
+
+	catalog := newCatalog(&model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker"})
+	deviceTypeService := service.NewDeviceTypeService(catalog, deviceRepository)
+
+	// Act
+	err := deviceTypeService.DeleteDeviceType(callerContext("admin", adminClaims), "gps-tracker")
+
+	// Assert
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Reason != "DEVICE_TYPE_IN_USE" || typed.Metadata["device_count"] != "3" {
+		t.Fatalf("Expected the device type to be in use by 3 devices, got %v", err)
+	}
+
+	catalog.AssertNotCalled(t, "DeleteDeviceType", mock.Anything, mock.Anything)
+}
+
+func TestDeviceTypeService_DeleteDeviceType_Race(t *testing.T) {
+	// Arrange
+	deviceType := &model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker"}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("CountDevicesByType", mock.Anything, "gps-tracker").Return(int64(0), nil).Once()
+	// A device is created with the type while it is deleted.
+	deviceRepository.On("CountDevicesByType", mock.Anything, "gps-tracker").Return(int64(1), nil).Once()
+
+	catalog := newCatalog(deviceType)
+	catalog.On("DeleteDeviceType", mock.Anything, "gps-tracker").Return(nil)
+	catalog.On("CreateDeviceType", mock.Anything, deviceType).Return(nil)
+	deviceTypeService := service.NewDeviceTypeService(catalog, deviceRepository)
+
+	// Act
+	err := deviceTypeService.DeleteDeviceType(callerContext("admin", adminClaims), "gps-tracker")
+
+	// Assert
+	if !errs.Is(err, errs.FailedPrecondition) {
+		t.Fatalf("Expected the device type to be in use, got %v", err)
+	}
+
+	// The type is put back for the device that has it.
+	catalog.AssertCalled(t, "CreateDeviceType", mock.Anything, deviceType)
+}
+
+func TestDeviceService_CreateDevice_DeviceType(t *testing.T) {
+	catalog := newCatalog(&model.DeviceType{ID: "gps-tracker", DisplayName: "GPS Tracker", SerialNumberPattern: `GT-[0-9]{6}`})
+
+	tests := []struct {
+		name   string
+		device *model.Device
+		field  string
+	}{
+		{"missing type", &model.Device{SerialNumber: "GT-000001"}, "device.device_type"},
+		{"unknown type", &model.Device{SerialNumber: "GT-000001", DeviceType: "GPS Tracker"}, "device.device_type"},
+		{"serial number of another type", &model.Device{SerialNumber: "XGT-000001", DeviceType: "gps-tracker"}, "device.serial_number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repository := new(DeviceRepositoryMock)
+			deviceService := service.NewDeviceService(repository, catalog, nil)
+
+			// Act
+			err := deviceService.CreateDevice(callerContext("caller", nil), tt.device)
+
+			// Assert
+			var typed *errs.Error
+			if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument {
+				t.Fatalf("Expected invalid argument error, got %v", err)
+			}
+
+			if len(typed.FieldViolations) != 1 || typed.FieldViolations[0].Field != tt.field {
+				t.Errorf("Expected a violation of %s, got %+v", tt.field, typed.FieldViolations)
+			}
+
+			repository.AssertNotCalled(t, "CreateDevice", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDeviceService_CreateDevice_Attributes(t *testing.T) {
+	// Arrange
+	cellular := &model.DeviceType{
+		ID:               "cellular-tracker",
+		DisplayName:      "Cellular Tracker",
+		AttributesSchema: `{"type": "object", "required": ["imei"], "properties": {"imei": {"type": "string", "pattern": "^[0-9]{15}$"}, "bands": {"type": "array", "items": {"type": "integer"}}}}`,
+	}
+
+	valid := &model.Device{SerialNumber: "CT-1", DeviceType: cellular.ID, Attributes: map[string]interface{}{"imei": "490154203237518"}}
+	invalid := &model.Device{SerialNumber: "CT-2", DeviceType: cellular.ID, Attributes: map[string]interface{}{"bands": []interface{}{3.0, "20"}}}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("CreateDevice", mock.Anything, valid).Return(nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(cellular), nil)
+
+	// Act
+	err := deviceService.CreateDevice(callerContext("caller", nil), valid)
+	invalidErr := deviceService.CreateDevice(callerContext("caller", nil), invalid)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while creating a device with valid attributes: %s", err)
+	}
+
+	var typed *errs.Error
+	if !errors.As(invalidErr, &typed) || typed.Kind != errs.InvalidArgument {
+		t.Fatalf("Expected invalid argument error, got %v", invalidErr)
+	}
+
+	want := []errs.FieldViolation{
+		{Field: "device.attributes.bands[1]", Description: "must be an integer"},
+		{Field: "device.attributes.imei", Description: "is required"},
+	}
+	if !reflect.DeepEqual(typed.FieldViolations, want) {
+		t.Errorf("Expected violations %+v, got %+v", want, typed.FieldViolations)
+	}
+
+	repository.AssertNumberOfCalls(t, "CreateDevice", 1)
+}
+
+func TestDeviceService_PatchDevice_Attributes(t *testing.T) {
+	// Arrange
+	cellular := &model.DeviceType{
+		ID:               "cellular-tracker",
+		DisplayName:      "Cellular Tracker",
+		AttributesSchema: `{"properties": {"imei": {"type": "string", "maxLength": 15}}}`,
+	}
+
+	id := primitive.NewObjectID().Hex()
+	patch := &model.Device{ID: id, Attributes: map[string]interface{}{"imei": "4901542032375180000"}}
+
+	repository := new(DeviceRepositoryMock)
+	repository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", DeviceType: cellular.ID}, nil)
+
+	deviceService := service.NewDeviceService(repository, newCatalog(cellular), nil)
+
+	// Act
+	_, err := deviceService.PatchDevice(callerContext("caller", nil), patch, []string{"attributes"})
+
+	// Assert
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument error, got %v", err)
+	}
+
+	repository.AssertNotCalled(t, "PatchDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,105 @@
+// Package errs defines the typed errors shared by the repository and service
+// layers. Transports translate them into their own error representation, e.g.
+// gRPC status codes with error details.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind classifies an Error independently of any transport.
+type Kind int
+
+const (
+	Unknown Kind = iota
+	NotFound
+	AlreadyExists
+	InvalidArgument
+	PermissionDenied
+	Unauthenticated
+	FailedPrecondition
+	ResourceExhausted
+	Aborted
+)
+
+// Domain identifies this service in machine-readable error details.
+const Domain = "device.berrytracer"
+
+// FieldViolation describes why a single request field is invalid.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a classified error with a stable machine-readable reason.
+type Error struct {
+	Kind            Kind
+	Reason          string
+	Message         string
+	Metadata        map[string]string
+	FieldViolations []FieldViolation
+	Err             error
+}
+
+// New returns a new Error of the given kind.
+func New(kind Kind, reason, message string) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: message}
+}
+
+// Wrap returns a new Error of the given kind that wraps err.
+func Wrap(kind Kind, reason string, err error) *Error {
+	return &Error{Kind: kind, Reason: reason, Message: err.Error(), Err: err}
+}
+
+// InvalidField returns an InvalidArgument error for a single field.
+func InvalidField(field, description string) *Error {
+	return InvalidFields(FieldViolation{Field: field, Description: description})
+}
+
+// InvalidFields returns an InvalidArgument error listing every invalid field.
+func InvalidFields(violations ...FieldViolation) *Error {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, fmt.Sprintf("invalid %s: %s", violation.Field, violation.Description))
+	}
+
+	return &Error{
+		Kind:            InvalidArgument,
+		Reason:          "INVALID_ARGUMENT",
+		Message:         strings.Join(messages, "; "),
+		FieldViolations: violations,
+	}
+}
+
+// WithMetadata returns e with the given key/value pair added to its metadata.
+func (e *Error) WithMetadata(key, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = map[string]string{}
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the Kind of the first Error in err's chain, or Unknown.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Unknown
+}
+
+// Is reports whether err's chain contains an Error of the given kind.
+func Is(err error, kind Kind) bool {
+	return KindOf(err) == kind
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/BerryTracer/device-service/geofence"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+const (
+	// MaxGeofenceRadius is the largest radius of a circular geofence, in meters.
+	MaxGeofenceRadius = 100000
+	// MaxGeofenceVertices is the largest number of vertices of a polygonal geofence.
+	MaxGeofenceVertices = 1000
+)
+
+// ErrGeofenceAccessDenied is returned when the caller does not own the geofence.
+var ErrGeofenceAccessDenied = errs.New(errs.PermissionDenied, "GEOFENCE_ACCESS_DENIED", "geofence belongs to another user")
+
+type GeofenceService interface {
+	CreateGeofence(ctx context.Context, geofence *model.Geofence) error
+	GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error)
+	GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error)
+	UpdateGeofence(ctx context.Context, geofence *model.Geofence) error
+	DeleteGeofence(ctx context.Context, id string) error
+	ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error)
+}
+
+// GeofenceServiceImpl manages geofences and evaluates reported locations
+// against them. Geofences can only watch devices of their owner.
+type GeofenceServiceImpl struct {
+	GeofenceRepository repository.GeofenceRepository
+	DeviceRepository   repository.DeviceRepository
+	Clock              Clock
+}
+
+// NewGeofenceService returns a new GeofenceServiceImpl that uses the system clock.
+func NewGeofenceService(geofenceRepository repository.GeofenceRepository, deviceRepository repository.DeviceRepository) *GeofenceServiceImpl {
+	return &GeofenceServiceImpl{
+		GeofenceRepository: geofenceRepository,
+		DeviceRepository:   deviceRepository,
+		Clock:              time.Now,
+	}
+}
+
+// CreateGeofence implements GeofenceService.
+// The geofence is owned by the caller unless an admin creates it for another user.
+func (s *GeofenceServiceImpl) CreateGeofence(ctx context.Context, fence *model.Geofence) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if fence.ID != "" {
+		return errs.InvalidField("geofence.id", "is assigned by the server and must be empty")
+	}
+
+	if fence.UserID == "" {
+		fence.UserID = identity.UserID
+	}
+
+	if err := authorizeGeofenceOwner(ctx, fence.UserID); err != nil {
+		return err
+	}
+
+	if err := s.validateGeofence(ctx, fence); err != nil {
+		return err
+	}
+
+	fence.CreatedAt = s.Clock().Unix()
+	fence.States = nil
+
+	return s.GeofenceRepository.CreateGeofence(ctx, fence)
+}
+
+// GetGeofenceById implements GeofenceService.
+func (s *GeofenceServiceImpl) GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error) {
+	fence, err := s.GeofenceRepository.GetGeofenceById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGeofenceOwner(ctx, fence.UserID); err != nil {
+		return nil, err
+	}
+
+	return fence, nil
+}
+
+// GetGeofencesByUserId implements GeofenceService.
+// An empty userId lists the caller's own geofences.
+func (s *GeofenceServiceImpl) GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		userId = identity.UserID
+	}
+
+	if err := authorizeGeofenceOwner(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	return s.GeofenceRepository.GetGeofencesByUserId(ctx, userId)
+}
+
+// UpdateGeofence implements GeofenceService.
+// The owner and creation time are kept from the stored geofence.
+func (s *GeofenceServiceImpl) UpdateGeofence(ctx context.Context, fence *model.Geofence) error {
+	existing, err := s.GetGeofenceById(ctx, fence.ID)
+	if err != nil {
+		return err
+	}
+
+	fence.UserID = existing.UserID
+	fence.CreatedAt = existing.CreatedAt
+
+	if err := s.validateGeofence(ctx, fence); err != nil {
+		return err
+	}
+
+	return s.GeofenceRepository.UpdateGeofence(ctx, fence)
+}
+
+// DeleteGeofence implements GeofenceService.
+func (s *GeofenceServiceImpl) DeleteGeofence(ctx context.Context, id string) error {
+	if _, err := s.GetGeofenceById(ctx, id); err != nil {
+		return err
+	}
+
+	return s.GeofenceRepository.DeleteGeofence(ctx, id)
+}
+
+// ListGeofenceEvents implements GeofenceService.
+func (s *GeofenceServiceImpl) ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error) {
+	if _, err := s.GetGeofenceById(ctx, query.GeofenceID); err != nil {
+		return nil, err
+	}
+
+	if query.To != 0 && query.From >= query.To {
+		return nil, errs.InvalidField("end_time", "must be after start_time")
+	}
+
+	return s.GeofenceRepository.ListGeofenceEvents(ctx, query)
+}
+
+// geofenceStateAttempts is how many times the locations of a report are
+// evaluated against a geofence when other reports of the device change its
+// state in the meantime.
+const geofenceStateAttempts = 3
+
+// LocationsReported implements LocationListener.
+// The locations are evaluated in time order against every geofence watching the
+// device; the resulting events are stored and the device's state is saved.
+func (s *GeofenceServiceImpl) LocationsReported(ctx context.Context, device *model.Device, locations []*model.Location) error {
+	fences, err := s.GeofenceRepository.GetGeofencesByDeviceId(ctx, device.ID)
+	if err != nil || len(fences) == 0 {
+		return err
+	}
+
+	ordered := make([]*model.Location, len(locations))
+	copy(ordered, locations)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Timestamp < ordered[j].Timestamp })
+
+	var events []*model.GeofenceEvent
+	for _, fence := range fences {
+		// A device that changed hands stays attached to the previous owner's
+		// fences, which must not learn where it goes.
+		if fence.UserID != device.UserID {
+			continue
+		}
+
+		fenceEvents, err := s.evaluateGeofence(ctx, fence, device.ID, ordered)
+		if err != nil {
+			return err
+		}
+		events = append(events, fenceEvents...)
+	}
+
+	return s.GeofenceRepository.InsertGeofenceEvents(ctx, events)
+}
+
+// evaluateGeofence evaluates the ordered locations of a device against fence
+// and saves the device's new state. The events are only returned once the
+// state they follow from is saved: when another report changed the state
+// first, the geofence is read again and the locations evaluated anew. A
+// geofence deleted in the meantime has no events.
+func (s *GeofenceServiceImpl) evaluateGeofence(ctx context.Context, fence *model.Geofence, deviceID string, ordered []*model.Location) ([]*model.GeofenceEvent, error) {
+	for attempt := 1; ; attempt++ {
+		previous := fence.States[deviceID]
+		next, events := evaluateLocations(fence, deviceID, previous, ordered)
+		if next == previous {
+			return events, nil
+		}
+
+		err := s.GeofenceRepository.UpdateGeofenceState(ctx, fence.ID, deviceID, previous, next)
+		if err == nil {
+			return events, nil
+		}
+		if attempt == geofenceStateAttempts || !errors.Is(err, repository.ErrGeofenceStateConflict) {
+			return nil, err
+		}
+
+		fence, err = s.GeofenceRepository.GetGeofenceById(ctx, fence.ID)
+		if errors.Is(err, repository.ErrGeofenceNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// evaluateLocations evaluates the ordered locations of a device against fence,
+// starting from the previous state, and returns the next state and the events.
+func evaluateLocations(fence *model.Geofence, deviceID string, previous model.GeofenceState, ordered []*model.Location) (model.GeofenceState, []*model.GeofenceEvent) {
+	shape := geofenceShape(fence)
+	state := toEvaluationState(previous)
+
+	var events []*model.GeofenceEvent
+	for _, location := range ordered {
+		var transitions []geofence.Event
+		point := geofence.Point{Lat: location.Latitude, Lon: location.Longitude}
+		state, transitions = geofence.Evaluate(shape, fence.DwellSeconds, state, point, location.Timestamp)
+
+		for _, transition := range transitions {
+			events = append(events, &model.GeofenceEvent{
+				GeofenceID: fence.ID,
+				DeviceID:   deviceID,
+				UserID:     fence.UserID,
+				Type:       model.GeofenceEventType(transition.Type),
+				Position:   model.LatLng{Latitude: transition.Point.Lat, Longitude: transition.Point.Lon},
+				Time:       transition.Time,
+			})
+		}
+	}
+
+	return fromEvaluationState(state), events
+}
+
+// validateGeofence checks the shape and settings of a geofence and that every
+// attached device belongs to its owner.
+func (s *GeofenceServiceImpl) validateGeofence(ctx context.Context, fence *model.Geofence) error {
+	var violations []errs.FieldViolation
+
+	if fence.Name == "" {
+		violations = append(violations, errs.FieldViolation{Field: "geofence.name", Description: "must not be empty"})
+	}
+
+	switch fence.Shape {
+	case model.GeofenceCircle:
+		if fence.Center == nil {
+			violations = append(violations, errs.FieldViolation{Field: "geofence.circle.center", Description: "must be set"})
+		} else {
+			violations = append(violations, validateLatLng("geofence.circle.center", *fence.Center)...)
+		}
+		if !(fence.Radius > 0 && fence.Radius <= MaxGeofenceRadius) {
+			violations = append(violations, errs.FieldViolation{Field: "geofence.circle.radius", Description: fmt.Sprintf("must be above 0 and at most %d meters", MaxGeofenceRadius)})
+		}
+		fence.Vertices = nil
+	case model.GeofencePolygon:
+		if len(fence.Vertices) < 3 || len(fence.Vertices) > MaxGeofenceVertices {
+			violations = append(violations, errs.FieldViolation{Field: "geofence.polygon.vertices", Description: fmt.Sprintf("must have between 3 and %d vertices", MaxGeofenceVertices)})
+		}
+		for i, vertex := range fence.Vertices {
+			violations = append(violations, validateLatLng(fmt.Sprintf("geofence.polygon.vertices[%d]", i), vertex)...)
+		}
+		fence.Center = nil
+		fence.Radius = 0
+	default:
+		violations = append(violations, errs.FieldViolation{Field: "geofence.shape", Description: "must be a circle or a polygon"})
+	}
+
+	if fence.DwellSeconds < 0 {
+		violations = append(violations, errs.FieldViolation{Field: "geofence.dwell_seconds", Description: "must not be negative"})
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	seen := make(map[string]bool, len(fence.DeviceIDs))
+	for i, deviceID := range fence.DeviceIDs {
+		if seen[deviceID] {
+			return errs.InvalidField(fmt.Sprintf("geofence.device_ids[%d]", i), "is listed more than once")
+		}
+		seen[deviceID] = true
+
+		device, err := s.DeviceRepository.GetDeviceById(ctx, deviceID)
+		if err != nil {
+			return err
+		}
+
+		if device.UserID != fence.UserID {
+			return errs.InvalidField(fmt.Sprintf("geofence.device_ids[%d]", i), "must be a device of the geofence owner")
+		}
+	}
+
+	return nil
+}
+
+// authorizeGeofenceOwner checks that the caller owns geofences of ownerID or is an admin.
+func authorizeGeofenceOwner(ctx context.Context, ownerID string) error {
+	err := authorizeOwner(ctx, ownerID)
+	if errs.Is(err, errs.PermissionDenied) {
+		return ErrGeofenceAccessDenied
+	}
+	return err
+}
+
+// validateLatLng returns the field violations of a position.
+func validateLatLng(field string, position model.LatLng) []errs.FieldViolation {
+	var violations []errs.FieldViolation
+
+	if !(position.Latitude >= -90 && position.Latitude <= 90) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".latitude", Description: "must be between -90 and 90"})
+	}
+
+	if !(position.Longitude >= -180 && position.Longitude <= 180) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".longitude", Description: "must be between -180 and 180"})
+	}
+
+	return violations
+}
+
+// geofenceShape returns the evaluation shape of a geofence.
+func geofenceShape(fence *model.Geofence) geofence.Shape {
+	if fence.Shape == model.GeofenceCircle && fence.Center != nil {
+		return geofence.Circle{
+			Center: geofence.Point{Lat: fence.Center.Latitude, Lon: fence.Center.Longitude},
+			Radius: fence.Radius,
+		}
+	}
+
+	polygon := geofence.Polygon{}
+	for _, vertex := range fence.Vertices {
+		polygon.Vertices = append(polygon.Vertices, geofence.Point{Lat: vertex.Latitude, Lon: vertex.Longitude})
+	}
+	return polygon
+}
+
+func toEvaluationState(state model.GeofenceState) geofence.State {
+	return geofence.State{Inside: state.Inside, Since: state.Since, Dwelled: state.Dwelled, UpdatedAt: state.UpdatedAt}
+}
+
+func fromEvaluationState(state geofence.State) model.GeofenceState {
+	return model.GeofenceState{Inside: state.Inside, Since: state.Since, Dwelled: state.Dwelled, UpdatedAt: state.UpdatedAt}
+}
+
+// Ensure GeofenceServiceImpl implements GeofenceService and LocationListener interfaces
+var (
+	_ GeofenceService  = &GeofenceServiceImpl{}
+	_ LocationListener = &GeofenceServiceImpl{}
+)
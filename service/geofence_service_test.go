@@ -0,0 +1,349 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the geofence repository
+type GeofenceRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *GeofenceRepositoryMock) CreateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	args := r.Called(ctx, geofence)
+	return args.Error(0)
+}
+
+func (r *GeofenceRepositoryMock) GetGeofenceById(ctx context.Context, id string) (*model.Geofence, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(*model.Geofence), args.Error(1)
+}
+
+func (r *GeofenceRepositoryMock) GetGeofencesByUserId(ctx context.Context, userId string) ([]*model.Geofence, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]*model.Geofence), args.Error(1)
+}
+
+func (r *GeofenceRepositoryMock) GetGeofencesByDeviceId(ctx context.Context, deviceId string) ([]*model.Geofence, error) {
+	args := r.Called(ctx, deviceId)
+	return args.Get(0).([]*model.Geofence), args.Error(1)
+}
+
+func (r *GeofenceRepositoryMock) UpdateGeofence(ctx context.Context, geofence *model.Geofence) error {
+	args := r.Called(ctx, geofence)
+	return args.Error(0)
+}
+
+func (r *GeofenceRepositoryMock) UpdateGeofenceState(ctx context.Context, id string, deviceId string, previous model.GeofenceState, state model.GeofenceState) error {
+	args := r.Called(ctx, id, deviceId, previous, state)
+	return args.Error(0)
+}
+
+func (r *GeofenceRepositoryMock) DeleteGeofence(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *GeofenceRepositoryMock) InsertGeofenceEvents(ctx context.Context, events []*model.GeofenceEvent) error {
+	args := r.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (r *GeofenceRepositoryMock) ListGeofenceEvents(ctx context.Context, query *model.GeofenceEventQuery) (*model.GeofenceEventPage, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).(*model.GeofenceEventPage), args.Error(1)
+}
+
+func TestGeofenceService_CreateGeofence(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	deviceID := primitive.NewObjectID().Hex()
+	fence := &model.Geofence{
+		Name:      "Home",
+		Shape:     model.GeofenceCircle,
+		Center:    &model.LatLng{Latitude: 52.52, Longitude: 13.40},
+		Radius:    200,
+		DeviceIDs: []string{deviceID},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, deviceID).Return(&model.Device{ID: deviceID, UserID: "caller"}, nil)
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("CreateGeofence", mock.Anything, fence).Return(nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, deviceRepository)
+	geofenceService.Clock = func() time.Time { return now }
+
+	// Act
+	err := geofenceService.CreateGeofence(callerContext("caller", nil), fence)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while creating geofence: %s", err)
+	}
+
+	if fence.UserID != "caller" || fence.CreatedAt != now.Unix() {
+		t.Errorf("Expected the caller as owner and the clock as creation time, got %+v", fence)
+	}
+
+	geofenceRepository.AssertExpectations(t)
+}
+
+func TestGeofenceService_CreateGeofence_Invalid(t *testing.T) {
+	otherDevice := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name  string
+		fence *model.Geofence
+	}{
+		{name: "no name", fence: &model.Geofence{Shape: model.GeofenceCircle, Center: &model.LatLng{}, Radius: 10}},
+		{name: "no shape", fence: &model.Geofence{Name: "Home"}},
+		{name: "circle without center", fence: &model.Geofence{Name: "Home", Shape: model.GeofenceCircle, Radius: 10}},
+		{name: "circle too large", fence: &model.Geofence{Name: "Home", Shape: model.GeofenceCircle, Center: &model.LatLng{}, Radius: 200000}},
+		{name: "polygon too small", fence: &model.Geofence{Name: "Home", Shape: model.GeofencePolygon, Vertices: []model.LatLng{{}, {Latitude: 1}}}},
+		{name: "vertex out of range", fence: &model.Geofence{Name: "Home", Shape: model.GeofencePolygon, Vertices: []model.LatLng{{}, {Latitude: 1}, {Longitude: 181}}}},
+		{name: "negative dwell", fence: &model.Geofence{Name: "Home", Shape: model.GeofenceCircle, Center: &model.LatLng{}, Radius: 10, DwellSeconds: -1}},
+		{name: "device of another user", fence: &model.Geofence{Name: "Home", Shape: model.GeofenceCircle, Center: &model.LatLng{}, Radius: 10, DeviceIDs: []string{otherDevice}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			deviceRepository := new(DeviceRepositoryMock)
+			deviceRepository.On("GetDeviceById", mock.Anything, otherDevice).Return(&model.Device{ID: otherDevice, UserID: "someone else"}, nil)
+
+			geofenceService := service.NewGeofenceService(new(GeofenceRepositoryMock), deviceRepository)
+
+			// Act
+			err := geofenceService.CreateGeofence(callerContext("caller", nil), tt.fence)
+
+			// Assert
+			if !errs.Is(err, errs.InvalidArgument) {
+				t.Errorf("Expected invalid argument, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGeofenceService_GetGeofenceById_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofenceById", mock.Anything, id).Return(&model.Geofence{ID: id, UserID: "owner"}, nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, new(DeviceRepositoryMock))
+
+	// Act
+	_, err := geofenceService.GetGeofenceById(callerContext("intruder", nil), id)
+	_, unauthenticatedErr := geofenceService.GetGeofenceById(context.Background(), id)
+
+	// Assert
+	if !errors.Is(err, service.ErrGeofenceAccessDenied) {
+		t.Errorf("Expected geofence access denied, got %v", err)
+	}
+
+	if !errs.Is(unauthenticatedErr, errs.Unauthenticated) {
+		t.Errorf("Expected unauthenticated, got %v", unauthenticatedErr)
+	}
+}
+
+func TestGeofenceService_UpdateGeofence_KeepsOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	fence := &model.Geofence{
+		ID:       id,
+		UserID:   "someone else",
+		Name:     "School",
+		Shape:    model.GeofencePolygon,
+		Vertices: []model.LatLng{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 1}, {Latitude: 1, Longitude: 1}},
+	}
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofenceById", mock.Anything, id).Return(&model.Geofence{ID: id, UserID: "caller", CreatedAt: 42}, nil)
+	geofenceRepository.On("UpdateGeofence", mock.Anything, fence).Return(nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, new(DeviceRepositoryMock))
+
+	// Act
+	err := geofenceService.UpdateGeofence(callerContext("caller", nil), fence)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while updating geofence: %s", err)
+	}
+
+	if fence.UserID != "caller" || fence.CreatedAt != 42 {
+		t.Errorf("Expected the owner and creation time to be kept, got %+v", fence)
+	}
+}
+
+func TestGeofenceService_LocationsReported(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	fenceID := primitive.NewObjectID().Hex()
+	fence := &model.Geofence{
+		ID:           fenceID,
+		UserID:       "caller",
+		Shape:        model.GeofenceCircle,
+		Center:       &model.LatLng{Latitude: 0, Longitude: 0},
+		Radius:       1000,
+		DwellSeconds: 60,
+	}
+
+	// Reported out of order: the device enters at 100, dwells at 200 and leaves at 300.
+	locations := []*model.Location{
+		{Latitude: 0, Longitude: 0.001, Timestamp: 200},
+		{Latitude: 0, Longitude: 0.1, Timestamp: 300},
+		{Latitude: 0, Longitude: 0, Timestamp: 100},
+	}
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofencesByDeviceId", mock.Anything, deviceID).Return([]*model.Geofence{fence}, nil)
+	geofenceRepository.On("UpdateGeofenceState", mock.Anything, fenceID, deviceID, model.GeofenceState{}, model.GeofenceState{UpdatedAt: 300}).Return(nil)
+
+	var stored []*model.GeofenceEvent
+	geofenceRepository.On("InsertGeofenceEvents", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).([]*model.GeofenceEvent) }).
+		Return(nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, new(DeviceRepositoryMock))
+
+	// Act
+	err := geofenceService.LocationsReported(context.Background(), &model.Device{ID: deviceID, UserID: "caller"}, locations)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while evaluating locations: %s", err)
+	}
+
+	expected := []model.GeofenceEventType{model.GeofenceEnter, model.GeofenceDwell, model.GeofenceExit}
+	if len(stored) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(stored))
+	}
+
+	for i, event := range stored {
+		if event.Type != expected[i] || event.GeofenceID != fenceID || event.DeviceID != deviceID {
+			t.Errorf("Unexpected event %d: %+v", i, event)
+		}
+	}
+
+	geofenceRepository.AssertExpectations(t)
+}
+
+func TestGeofenceService_LocationsReported_Conflict(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	fenceID := primitive.NewObjectID().Hex()
+	fence := &model.Geofence{
+		ID:     fenceID,
+		UserID: "caller",
+		Shape:  model.GeofenceCircle,
+		Center: &model.LatLng{Latitude: 0, Longitude: 0},
+		Radius: 1000,
+	}
+
+	// Another report saw the device enter at 100 before this one was saved.
+	entered := model.GeofenceState{Inside: true, Since: 100, UpdatedAt: 100}
+	reread := *fence
+	reread.States = map[string]model.GeofenceState{deviceID: entered}
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofencesByDeviceId", mock.Anything, deviceID).Return([]*model.Geofence{fence}, nil)
+	geofenceRepository.On("UpdateGeofenceState", mock.Anything, fenceID, deviceID, model.GeofenceState{}, mock.Anything).Return(repository.ErrGeofenceStateConflict)
+	geofenceRepository.On("GetGeofenceById", mock.Anything, fenceID).Return(&reread, nil)
+	geofenceRepository.On("UpdateGeofenceState", mock.Anything, fenceID, deviceID, entered, model.GeofenceState{Inside: true, Since: 100, UpdatedAt: 200}).Return(nil)
+
+	var stored []*model.GeofenceEvent
+	geofenceRepository.On("InsertGeofenceEvents", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).([]*model.GeofenceEvent) }).
+		Return(nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, new(DeviceRepositoryMock))
+
+	// Act
+	err := geofenceService.LocationsReported(context.Background(), &model.Device{ID: deviceID, UserID: "caller"}, []*model.Location{{Timestamp: 200}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while evaluating locations: %s", err)
+	}
+
+	// The enter event was the other report's to emit.
+	if len(stored) != 0 {
+		t.Errorf("Expected no events once the state was read again, got %+v", stored)
+	}
+
+	geofenceRepository.AssertExpectations(t)
+}
+
+func TestGeofenceService_LocationsReported_PreviousOwner(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	fence := &model.Geofence{
+		ID:     primitive.NewObjectID().Hex(),
+		UserID: "seller",
+		Shape:  model.GeofenceCircle,
+		Center: &model.LatLng{Latitude: 0, Longitude: 0},
+		Radius: 1000,
+	}
+
+	// The device was transferred but is still attached to the seller's fence.
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofencesByDeviceId", mock.Anything, deviceID).Return([]*model.Geofence{fence}, nil)
+	geofenceRepository.On("InsertGeofenceEvents", mock.Anything, ([]*model.GeofenceEvent)(nil)).Return(nil)
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, new(DeviceRepositoryMock))
+
+	// Act
+	err := geofenceService.LocationsReported(context.Background(), &model.Device{ID: deviceID, UserID: "buyer"}, []*model.Location{{Timestamp: 100}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while evaluating locations: %s", err)
+	}
+
+	geofenceRepository.AssertExpectations(t)
+	geofenceRepository.AssertNotCalled(t, "UpdateGeofenceState", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLocationService_ReportLocation_NotifiesListeners(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: id, UserID: "caller"}
+	locations := []*model.Location{{Latitude: 1, Longitude: 2, Timestamp: 100}}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(device, nil)
+	deviceRepository.On("UpdateLocation", mock.Anything, id, locations[0]).Return(nil)
+
+	locationRepository := new(LocationRepositoryMock)
+	locationRepository.On("InsertLocations", mock.Anything, locations).Return(nil)
+
+	geofenceRepository := new(GeofenceRepositoryMock)
+	geofenceRepository.On("GetGeofencesByDeviceId", mock.Anything, id).Return([]*model.Geofence(nil), errors.New("unavailable"))
+
+	geofenceService := service.NewGeofenceService(geofenceRepository, deviceRepository)
+	locationService := service.NewLocationService(locationRepository, deviceRepository, geofenceService)
+
+	// Act
+	err := locationService.ReportLocation(callerContext("caller", nil), id, locations)
+
+	// Assert: the listener was called, and its failure does not fail the report.
+	if err != nil {
+		t.Errorf("Error was not expected while reporting locations: %s", err)
+	}
+
+	geofenceRepository.AssertExpectations(t)
+}
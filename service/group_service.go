@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// MaxGroupMembershipChange is the largest number of devices added to or removed
+// from a group in one call.
+const MaxGroupMembershipChange = 100
+
+// ErrGroupAccessDenied is returned when the caller does not own the device group.
+var ErrGroupAccessDenied = errs.New(errs.PermissionDenied, "DEVICE_GROUP_ACCESS_DENIED", "device group belongs to another user")
+
+type GroupService interface {
+	CreateGroup(ctx context.Context, group *model.DeviceGroup) error
+	GetGroupById(ctx context.Context, id string) (*model.DeviceGroup, error)
+	GetGroupsByUserId(ctx context.Context, userId string) ([]*model.DeviceGroup, error)
+	UpdateGroup(ctx context.Context, group *model.DeviceGroup) error
+	DeleteGroup(ctx context.Context, id string) error
+	AddDevicesToGroup(ctx context.Context, groupId string, deviceIds []string) error
+	RemoveDevicesFromGroup(ctx context.Context, groupId string, deviceIds []string) error
+	ChangeGroupStatus(ctx context.Context, groupId string, status model.DeviceStatus, reason string) (*model.GroupStatusResult, error)
+}
+
+// GroupServiceImpl organizes a user's devices into groups. Like geofences,
+// groups can only hold devices of their owner. Status changes of a whole group
+// go through the DeviceService, so each device is authorized and checked
+// against the status lifecycle on its own.
+type GroupServiceImpl struct {
+	GroupRepository  repository.GroupRepository
+	DeviceRepository repository.DeviceRepository
+	DeviceService    DeviceService
+	Clock            Clock
+}
+
+// NewGroupService returns a new GroupServiceImpl that uses the system clock.
+func NewGroupService(groupRepository repository.GroupRepository, deviceRepository repository.DeviceRepository, deviceService DeviceService) *GroupServiceImpl {
+	return &GroupServiceImpl{
+		GroupRepository:  groupRepository,
+		DeviceRepository: deviceRepository,
+		DeviceService:    deviceService,
+		Clock:            time.Now,
+	}
+}
+
+// CreateGroup implements GroupService.
+// The group is owned by the caller unless an admin creates it for another user.
+func (s *GroupServiceImpl) CreateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if group.ID != "" {
+		return errs.InvalidField("group.id", "is assigned by the server and must be empty")
+	}
+
+	if group.UserID == "" {
+		group.UserID = identity.UserID
+	}
+
+	if err := authorizeGroupOwner(ctx, group.UserID); err != nil {
+		return err
+	}
+
+	if group.Name == "" {
+		return errs.InvalidField("group.name", "must not be empty")
+	}
+
+	group.CreatedAt = s.Clock().Unix()
+
+	return s.GroupRepository.CreateGroup(ctx, group)
+}
+
+// GetGroupById implements GroupService.
+func (s *GroupServiceImpl) GetGroupById(ctx context.Context, id string) (*model.DeviceGroup, error) {
+	group, err := s.GroupRepository.GetGroupById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeGroupOwner(ctx, group.UserID); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetGroupsByUserId implements GroupService.
+// An empty userId lists the caller's own groups.
+func (s *GroupServiceImpl) GetGroupsByUserId(ctx context.Context, userId string) ([]*model.DeviceGroup, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		userId = identity.UserID
+	}
+
+	if err := authorizeGroupOwner(ctx, userId); err != nil {
+		return nil, err
+	}
+
+	return s.GroupRepository.GetGroupsByUserId(ctx, userId)
+}
+
+// UpdateGroup implements GroupService.
+// The owner and creation time are kept from the stored group.
+func (s *GroupServiceImpl) UpdateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	existing, err := s.GetGroupById(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+
+	group.UserID = existing.UserID
+	group.CreatedAt = existing.CreatedAt
+
+	if group.Name == "" {
+		return errs.InvalidField("group.name", "must not be empty")
+	}
+
+	return s.GroupRepository.UpdateGroup(ctx, group)
+}
+
+// DeleteGroup implements GroupService.
+// The devices of the group leave it first; the devices themselves are kept.
+func (s *GroupServiceImpl) DeleteGroup(ctx context.Context, id string) error {
+	group, err := s.GetGroupById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.DeviceRepository.RemoveDevicesFromGroup(ctx, group.UserID, group.ID, nil); err != nil {
+		return err
+	}
+
+	return s.GroupRepository.DeleteGroup(ctx, group.ID)
+}
+
+// AddDevicesToGroup implements GroupService.
+// Every device must belong to the owner of the group.
+func (s *GroupServiceImpl) AddDevicesToGroup(ctx context.Context, groupId string, deviceIds []string) error {
+	group, err := s.GetGroupById(ctx, groupId)
+	if err != nil {
+		return err
+	}
+
+	if err := validateMembershipChange(deviceIds); err != nil {
+		return err
+	}
+
+	for i, deviceID := range deviceIds {
+		device, err := s.DeviceRepository.GetDeviceById(ctx, deviceID)
+		if err != nil {
+			return err
+		}
+
+		if device.UserID != group.UserID {
+			return errs.InvalidField(fmt.Sprintf("device_ids[%d]", i), "must be a device of the group owner")
+		}
+	}
+
+	return s.DeviceRepository.AddDevicesToGroup(ctx, group.UserID, group.ID, deviceIds)
+}
+
+// RemoveDevicesFromGroup implements GroupService.
+// Devices that are not in the group are ignored.
+func (s *GroupServiceImpl) RemoveDevicesFromGroup(ctx context.Context, groupId string, deviceIds []string) error {
+	group, err := s.GetGroupById(ctx, groupId)
+	if err != nil {
+		return err
+	}
+
+	if err := validateMembershipChange(deviceIds); err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.RemoveDevicesFromGroup(ctx, group.UserID, group.ID, deviceIds)
+}
+
+// ChangeGroupStatus implements GroupService.
+// Every device of the group that is not in status yet is moved to it. Devices
+// that cannot make the transition, or that disappeared meanwhile, are reported
+// as failures and do not stop the others.
+func (s *GroupServiceImpl) ChangeGroupStatus(ctx context.Context, groupId string, status model.DeviceStatus, reason string) (*model.GroupStatusResult, error) {
+	group, err := s.GetGroupById(ctx, groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateStatus("status", status); err != nil {
+		return nil, err
+	}
+
+	devices, err := s.DeviceRepository.GetDevicesByGroupId(ctx, group.UserID, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.GroupStatusResult{}
+	for _, device := range devices {
+		if device.Status == status {
+			continue
+		}
+
+		if _, err := s.DeviceService.ChangeDeviceStatus(ctx, device.ID, status, reason, 0); err != nil {
+			if !errs.Is(err, errs.FailedPrecondition) && !errs.Is(err, errs.NotFound) {
+				return nil, err
+			}
+			result.Failures = append(result.Failures, model.GroupStatusFailure{DeviceID: device.ID, Reason: err.Error()})
+			continue
+		}
+
+		result.ChangedDeviceIDs = append(result.ChangedDeviceIDs, device.ID)
+	}
+
+	return result, nil
+}
+
+// validateMembershipChange checks the device IDs of a membership change.
+func validateMembershipChange(deviceIds []string) error {
+	if len(deviceIds) == 0 || len(deviceIds) > MaxGroupMembershipChange {
+		return errs.InvalidField("device_ids", fmt.Sprintf("must list between 1 and %d devices", MaxGroupMembershipChange))
+	}
+
+	seen := make(map[string]bool, len(deviceIds))
+	for i, deviceID := range deviceIds {
+		if seen[deviceID] {
+			return errs.InvalidField(fmt.Sprintf("device_ids[%d]", i), "is listed more than once")
+		}
+		seen[deviceID] = true
+	}
+
+	return nil
+}
+
+// authorizeGroupOwner checks that the caller owns groups of ownerID or is an admin.
+func authorizeGroupOwner(ctx context.Context, ownerID string) error {
+	err := authorizeOwner(ctx, ownerID)
+	if errs.Is(err, errs.PermissionDenied) {
+		return ErrGroupAccessDenied
+	}
+	return err
+}
+
+// Ensure GroupServiceImpl implements GroupService interface
+var _ GroupService = &GroupServiceImpl{}
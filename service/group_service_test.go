@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the group repository
+type GroupRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *GroupRepositoryMock) CreateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	args := r.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (r *GroupRepositoryMock) GetGroupById(ctx context.Context, id string) (*model.DeviceGroup, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(*model.DeviceGroup), args.Error(1)
+}
+
+func (r *GroupRepositoryMock) GetGroupsByUserId(ctx context.Context, userId string) ([]*model.DeviceGroup, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]*model.DeviceGroup), args.Error(1)
+}
+
+func (r *GroupRepositoryMock) UpdateGroup(ctx context.Context, group *model.DeviceGroup) error {
+	args := r.Called(ctx, group)
+	return args.Error(0)
+}
+
+func (r *GroupRepositoryMock) DeleteGroup(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestGroupService_CreateGroup(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	group := &model.DeviceGroup{Name: "Vans"}
+
+	groupRepository := new(GroupRepositoryMock)
+	groupRepository.On("CreateGroup", mock.Anything, group).Return(nil)
+
+	groupService := service.NewGroupService(groupRepository, new(DeviceRepositoryMock), nil)
+	groupService.Clock = func() time.Time { return now }
+
+	// Act
+	err := groupService.CreateGroup(callerContext("caller", nil), group)
+	unnamed := groupService.CreateGroup(callerContext("caller", nil), &model.DeviceGroup{})
+	other := groupService.CreateGroup(callerContext("caller", nil), &model.DeviceGroup{UserID: "owner", Name: "Bikes"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while creating the group: %s", err)
+	}
+
+	if group.UserID != "caller" || group.CreatedAt != now.Unix() {
+		t.Errorf("Expected the group to be owned by the caller and stamped, got %+v", group)
+	}
+
+	if !errs.Is(unnamed, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument for a group without a name, got %v", unnamed)
+	}
+
+	if !errors.Is(other, service.ErrGroupAccessDenied) {
+		t.Errorf("Expected group access denied for another user's group, got %v", other)
+	}
+
+	groupRepository.AssertNumberOfCalls(t, "CreateGroup", 1)
+}
+
+func TestGroupService_GetGroupById_OtherOwner(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+
+	groupRepository := new(GroupRepositoryMock)
+	groupRepository.On("GetGroupById", mock.Anything, id).Return(&model.DeviceGroup{ID: id, UserID: "owner"}, nil)
+
+	groupService := service.NewGroupService(groupRepository, new(DeviceRepositoryMock), nil)
+
+	_, err := groupService.GetGroupById(callerContext("caller", nil), id)
+	_, adminErr := groupService.GetGroupById(callerContext("admin", adminClaims), id)
+
+	if !errors.Is(err, service.ErrGroupAccessDenied) {
+		t.Errorf("Expected group access denied, got %v", err)
+	}
+
+	if adminErr != nil {
+		t.Errorf("Error was not expected while an admin reads the group: %s", adminErr)
+	}
+}
+
+func TestGroupService_AddDevicesToGroup(t *testing.T) {
+	// Arrange
+	groupID := primitive.NewObjectID().Hex()
+	own := primitive.NewObjectID().Hex()
+	foreign := primitive.NewObjectID().Hex()
+
+	groupRepository := new(GroupRepositoryMock)
+	groupRepository.On("GetGroupById", mock.Anything, groupID).Return(&model.DeviceGroup{ID: groupID, UserID: "caller"}, nil)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, own).Return(&model.Device{ID: own, UserID: "caller"}, nil)
+	deviceRepository.On("GetDeviceById", mock.Anything, foreign).Return(&model.Device{ID: foreign, UserID: "other"}, nil)
+	deviceRepository.On("AddDevicesToGroup", mock.Anything, "caller", groupID, []string{own}).Return(nil)
+
+	groupService := service.NewGroupService(groupRepository, deviceRepository, nil)
+	ctx := callerContext("caller", nil)
+
+	// Act
+	err := groupService.AddDevicesToGroup(ctx, groupID, []string{own})
+	foreignErr := groupService.AddDevicesToGroup(ctx, groupID, []string{own, foreign})
+	duplicateErr := groupService.AddDevicesToGroup(ctx, groupID, []string{own, own})
+	emptyErr := groupService.AddDevicesToGroup(ctx, groupID, nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while adding devices to the group: %s", err)
+	}
+
+	for name, err := range map[string]error{"foreign": foreignErr, "duplicate": duplicateErr, "empty": emptyErr} {
+		if !errs.Is(err, errs.InvalidArgument) {
+			t.Errorf("Expected invalid argument for %s devices, got %v", name, err)
+		}
+	}
+
+	deviceRepository.AssertNumberOfCalls(t, "AddDevicesToGroup", 1)
+}
+
+func TestGroupService_DeleteGroup(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+
+	groupRepository := new(GroupRepositoryMock)
+	groupRepository.On("GetGroupById", mock.Anything, id).Return(&model.DeviceGroup{ID: id, UserID: "caller"}, nil)
+	groupRepository.On("DeleteGroup", mock.Anything, id).Return(nil)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("RemoveDevicesFromGroup", mock.Anything, "caller", id, []string(nil)).Return(nil)
+
+	groupService := service.NewGroupService(groupRepository, deviceRepository, nil)
+
+	if err := groupService.DeleteGroup(callerContext("caller", nil), id); err != nil {
+		t.Fatalf("Error was not expected while deleting the group: %s", err)
+	}
+
+	deviceRepository.AssertExpectations(t)
+	groupRepository.AssertExpectations(t)
+}
+
+func TestGroupService_ChangeGroupStatus(t *testing.T) {
+	// Arrange
+	groupID := primitive.NewObjectID().Hex()
+	active := &model.Device{ID: primitive.NewObjectID().Hex(), UserID: "caller", Status: model.StatusActive}
+	inactive := &model.Device{ID: primitive.NewObjectID().Hex(), UserID: "caller", Status: model.StatusInactive}
+	retired := &model.Device{ID: primitive.NewObjectID().Hex(), UserID: "caller", Status: model.StatusDecommissioned}
+
+	groupRepository := new(GroupRepositoryMock)
+	groupRepository.On("GetGroupById", mock.Anything, groupID).Return(&model.DeviceGroup{ID: groupID, UserID: "caller"}, nil)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDevicesByGroupId", mock.Anything, "caller", groupID).Return([]*model.Device{active, inactive, retired}, nil)
+	for _, device := range []*model.Device{active, inactive, retired} {
+		copied := *device
+		deviceRepository.On("GetDeviceById", mock.Anything, device.ID).Return(&copied, nil)
+	}
+	deviceRepository.On("ChangeDeviceStatus", mock.Anything, inactive.ID, mock.Anything, int64(0)).Return(&model.Device{ID: inactive.ID, UserID: "caller", Status: model.StatusActive}, nil)
+
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+	groupService := service.NewGroupService(groupRepository, deviceRepository, deviceService)
+
+	// Act
+	result, err := groupService.ChangeGroupStatus(callerContext("caller", nil), groupID, model.StatusActive, "back in service")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while changing the group status: %s", err)
+	}
+
+	if len(result.ChangedDeviceIDs) != 1 || result.ChangedDeviceIDs[0] != inactive.ID {
+		t.Errorf("Expected only the inactive device to change, got %v", result.ChangedDeviceIDs)
+	}
+
+	if len(result.Failures) != 1 || result.Failures[0].DeviceID != retired.ID {
+		t.Errorf("Expected the decommissioned device to fail, got %+v", result.Failures)
+	}
+}
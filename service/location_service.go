@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"time"
+
+	"github.com/BerryTracer/device-service/export"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+type LocationService interface {
+	ReportLocation(ctx context.Context, deviceId string, locations []*model.Location) error
+	GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error)
+	ExportLocationHistory(ctx context.Context, query *model.LocationHistoryQuery, format export.Format, w io.Writer) error
+}
+
+// LocationListener is notified of the locations of a device after they are stored.
+type LocationListener interface {
+	LocationsReported(ctx context.Context, device *model.Device, locations []*model.Location) error
+}
+
+// LocationServiceImpl records where devices are. Viewers of a device may read
+// its locations; reporting them takes an editor.
+type LocationServiceImpl struct {
+	LocationRepository repository.LocationRepository
+	DeviceRepository   repository.DeviceRepository
+	Listeners          []LocationListener
+	Clock              Clock
+}
+
+// NewLocationService returns a new LocationServiceImpl that uses the system clock
+// and notifies listeners of every report.
+func NewLocationService(locationRepository repository.LocationRepository, deviceRepository repository.DeviceRepository, listeners ...LocationListener) *LocationServiceImpl {
+	return &LocationServiceImpl{
+		LocationRepository: locationRepository,
+		DeviceRepository:   deviceRepository,
+		Listeners:          listeners,
+		Clock:              time.Now,
+	}
+}
+
+// ReportLocation implements LocationService.
+// Locations without a timestamp are stamped with the current time. The newest
+// location becomes the device's last location. Listener failures are logged
+// rather than returned, because the locations are already stored and a retry
+// would record them twice.
+func (s *LocationServiceImpl) ReportLocation(ctx context.Context, deviceId string, locations []*model.Location) error {
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, model.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	if len(locations) == 0 {
+		return errs.InvalidField("locations", "must contain at least one location")
+	}
+
+	now := s.Clock()
+	var violations []errs.FieldViolation
+	var latest *model.Location
+	for i, location := range locations {
+		location.DeviceID = deviceId
+		if location.Timestamp == 0 {
+			location.Timestamp = now.Unix()
+		}
+
+		violations = append(violations, validateLocation(fmt.Sprintf("locations[%d]", i), location, now)...)
+
+		if latest == nil || location.Timestamp > latest.Timestamp {
+			latest = location
+		}
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	if err := s.LocationRepository.InsertLocations(ctx, locations); err != nil {
+		return err
+	}
+
+	if err := s.DeviceRepository.UpdateLocation(ctx, deviceId, latest); err != nil {
+		return err
+	}
+
+	for _, listener := range s.Listeners {
+		if err := listener.LocationsReported(ctx, device, locations); err != nil {
+			log.Printf("failed to process locations of device %s: %v", deviceId, err)
+		}
+	}
+
+	return nil
+}
+
+// GetLocationHistory implements LocationService.
+func (s *LocationServiceImpl) GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error) {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, query.DeviceID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if query.To != 0 && query.From >= query.To {
+		return nil, errs.InvalidField("end_time", "must be after start_time")
+	}
+
+	return s.LocationRepository.GetLocationHistory(ctx, query)
+}
+
+// ExportLocationHistory implements LocationService.
+// The history within the query's time range is read page by page and written
+// to w as it is read, so an export of any length uses little memory. The
+// device name titles the track. The page size and token of the query are ignored.
+func (s *LocationServiceImpl) ExportLocationHistory(ctx context.Context, query *model.LocationHistoryQuery, format export.Format, w io.Writer) error {
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, query.DeviceID, model.RoleViewer)
+	if err != nil {
+		return err
+	}
+
+	if query.To != 0 && query.From >= query.To {
+		return errs.InvalidField("end_time", "must be after start_time")
+	}
+
+	if !isExportFormat(format) {
+		return errs.InvalidField("format", "must be GPX, KML, GEOJSON or CSV")
+	}
+
+	encoder, err := export.NewEncoder(format, w, device.Name)
+	if err != nil {
+		return err
+	}
+
+	pageQuery := *query
+	pageQuery.PageSize = repository.MaxPageSize
+	pageQuery.PageToken = ""
+	for {
+		page, err := s.LocationRepository.GetLocationHistory(ctx, &pageQuery)
+		if err != nil {
+			return err
+		}
+
+		for _, location := range page.Locations {
+			if err := encoder.Encode(location); err != nil {
+				return err
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return encoder.Close()
+		}
+		pageQuery.PageToken = page.NextPageToken
+	}
+}
+
+// isExportFormat reports whether locations can be exported in format.
+func isExportFormat(format export.Format) bool {
+	for _, known := range export.Formats {
+		if format == known {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLocation returns the field violations of a location reported at now.
+// The comparisons are written so that NaN values are rejected as well.
+func validateLocation(field string, location *model.Location, now time.Time) []errs.FieldViolation {
+	var violations []errs.FieldViolation
+
+	if !(location.Latitude >= -90 && location.Latitude <= 90) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".latitude", Description: "must be between -90 and 90"})
+	}
+
+	if !(location.Longitude >= -180 && location.Longitude <= 180) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".longitude", Description: "must be between -180 and 180"})
+	}
+
+	if !(location.Accuracy >= 0) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".accuracy", Description: "must not be negative"})
+	}
+
+	if !(location.Speed >= 0) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".speed", Description: "must not be negative"})
+	}
+
+	if !(location.Heading >= 0 && location.Heading < 360) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".heading", Description: "must be at least 0 and below 360"})
+	}
+
+	if math.IsNaN(location.Altitude) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".altitude", Description: "must be a number"})
+	}
+
+	if time.Unix(location.Timestamp, 0).After(now.Add(maxClockSkew)) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".timestamp", Description: "must not be in the future"})
+	}
+
+	return violations
+}
+
+// Ensure LocationServiceImpl implements LocationService interface
+var _ LocationService = &LocationServiceImpl{}
@@ -0,0 +1,207 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/export"
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the location repository
+type LocationRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *LocationRepositoryMock) InsertLocations(ctx context.Context, locations []*model.Location) error {
+	args := r.Called(ctx, locations)
+	return args.Error(0)
+}
+
+func (r *LocationRepositoryMock) GetLocationHistory(ctx context.Context, query *model.LocationHistoryQuery) (*model.LocationPage, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).(*model.LocationPage), args.Error(1)
+}
+
+func TestLocationService_ReportLocation(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := primitive.NewObjectID().Hex()
+	locations := []*model.Location{
+		{Latitude: 52.52, Longitude: 13.40, Accuracy: 5, Heading: 90, Timestamp: now.Add(-time.Minute).Unix()},
+		{Latitude: 52.53, Longitude: 13.41, Accuracy: 5, Speed: 1.5, Heading: 359.9},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+	deviceRepository.On("UpdateLocation", mock.Anything, id, locations[1]).Return(nil)
+
+	locationRepository := new(LocationRepositoryMock)
+	locationRepository.On("InsertLocations", mock.Anything, locations).Return(nil)
+
+	locationService := service.NewLocationService(locationRepository, deviceRepository)
+	locationService.Clock = func() time.Time { return now }
+
+	// Act
+	err := locationService.ReportLocation(callerContext("caller", nil), id, locations)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while reporting locations: %s", err)
+	}
+
+	if locations[1].Timestamp != now.Unix() || locations[1].DeviceID != id {
+		t.Errorf("Expected the location without a timestamp to be stamped with the clock, got %+v", locations[1])
+	}
+
+	deviceRepository.AssertExpectations(t)
+	locationRepository.AssertExpectations(t)
+}
+
+func TestLocationService_ReportLocation_InvalidLocations(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := primitive.NewObjectID().Hex()
+	locations := []*model.Location{
+		{Latitude: 91},
+		{Longitude: -180.5},
+		{Latitude: math.NaN()},
+		{Accuracy: -1},
+		{Speed: -0.1},
+		{Heading: 360},
+		{Altitude: math.NaN()},
+		{Timestamp: now.Add(time.Hour).Unix()},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	locationService := service.NewLocationService(new(LocationRepositoryMock), deviceRepository)
+	locationService.Clock = func() time.Time { return now }
+
+	// Act
+	err := locationService.ReportLocation(callerContext("caller", nil), id, locations)
+
+	// Assert
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument {
+		t.Fatalf("Expected invalid argument, got %v", err)
+	}
+
+	if len(typed.FieldViolations) != len(locations) {
+		t.Errorf("Expected one violation per location, got %v", typed.FieldViolations)
+	}
+}
+
+func TestLocationService_GetLocationHistory_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+
+	locationService := service.NewLocationService(new(LocationRepositoryMock), deviceRepository)
+
+	// Act
+	_, err := locationService.GetLocationHistory(callerContext("intruder", nil), &model.LocationHistoryQuery{DeviceID: id})
+
+	// Assert
+	if !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+}
+
+func TestLocationService_GetLocationHistory(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	query := &model.LocationHistoryQuery{DeviceID: id, From: 1000, To: 2000, PageSize: 10}
+	page := &model.LocationPage{Locations: []*model.Location{{DeviceID: id, Timestamp: 1500}}, NextPageToken: "next"}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	locationRepository := new(LocationRepositoryMock)
+	locationRepository.On("GetLocationHistory", mock.Anything, query).Return(page, nil)
+
+	locationService := service.NewLocationService(locationRepository, deviceRepository)
+
+	// Act
+	result, err := locationService.GetLocationHistory(callerContext("caller", nil), query)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while getting location history: %s", err)
+	}
+
+	if result != page {
+		t.Errorf("Expected the repository page, got %+v", result)
+	}
+}
+
+func TestLocationService_ExportLocationHistory(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	query := &model.LocationHistoryQuery{DeviceID: id, From: 1000, To: 2000, PageSize: 5, PageToken: "ignored"}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller", Name: "Tracker"}, nil)
+
+	// The history is read in the largest pages, following the page tokens.
+	locationRepository := new(LocationRepositoryMock)
+	locationRepository.On("GetLocationHistory", mock.Anything, mock.MatchedBy(func(q *model.LocationHistoryQuery) bool {
+		return q.PageToken == "" && q.PageSize == 1000 && q.From == 1000 && q.To == 2000
+	})).Return(&model.LocationPage{Locations: []*model.Location{{Latitude: 1, Longitude: 2, Timestamp: 1000}}, NextPageToken: "next"}, nil).Once()
+	locationRepository.On("GetLocationHistory", mock.Anything, mock.MatchedBy(func(q *model.LocationHistoryQuery) bool {
+		return q.PageToken == "next"
+	})).Return(&model.LocationPage{Locations: []*model.Location{{Latitude: 3, Longitude: 4, Timestamp: 1060}}}, nil).Once()
+
+	locationService := service.NewLocationService(locationRepository, deviceRepository)
+
+	// Act
+	var output bytes.Buffer
+	err := locationService.ExportLocationHistory(callerContext("caller", nil), query, export.CSV, &output)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while exporting location history: %s", err)
+	}
+
+	rows := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(rows) != 3 || !strings.HasPrefix(rows[1], "1970-01-01T00:16:40Z,1,2,") || !strings.HasPrefix(rows[2], "1970-01-01T00:17:40Z,3,4,") {
+		t.Errorf("Expected a header and both locations, got %q", output.String())
+	}
+
+	if query.PageToken != "ignored" {
+		t.Errorf("Expected the query to be left unchanged, got %+v", query)
+	}
+
+	locationRepository.AssertExpectations(t)
+}
+
+func TestLocationService_ExportLocationHistory_UnknownFormat(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	locationService := service.NewLocationService(new(LocationRepositoryMock), deviceRepository)
+
+	// Act
+	var output bytes.Buffer
+	err := locationService.ExportLocationHistory(callerContext("caller", nil), &model.LocationHistoryQuery{DeviceID: id}, "", &output)
+
+	// Assert
+	if !errs.Is(err, errs.InvalidArgument) || output.Len() != 0 {
+		t.Errorf("Expected invalid argument without output, got %v and %q", err, output.String())
+	}
+}
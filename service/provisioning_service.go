@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+const (
+	// MaxPreregisteredDevices is the largest batch PreregisterDevices accepts.
+	MaxPreregisteredDevices = 1000
+	// MaxClaimAttempts is the number of wrong claim codes after which a device is locked.
+	MaxClaimAttempts = 5
+	// ClaimLockout is how long a device stays locked after too many wrong claim codes.
+	ClaimLockout = 15 * time.Minute
+)
+
+// claimCodeAlphabet is Crockford's base32 alphabet, which leaves out letters
+// that are easily confused with digits.
+const claimCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	// ErrInvalidClaimCode is returned when the serial number and claim code do not match an unclaimed device.
+	ErrInvalidClaimCode = errs.New(errs.InvalidArgument, "INVALID_CLAIM_CODE", "serial number or claim code is invalid")
+	// ErrClaimLocked is returned while a device refuses claims after too many wrong codes.
+	ErrClaimLocked = repository.ErrClaimLocked
+)
+
+type ProvisioningService interface {
+	PreregisterDevices(ctx context.Context, devices []*model.Device) (*model.PreregistrationResult, error)
+	ClaimDevice(ctx context.Context, serialNumber string, claimCode string) (*model.Device, error)
+}
+
+// ProvisioningServiceImpl lets admins preregister unowned devices at the
+// factory and users claim them with the one-time code printed on the device.
+type ProvisioningServiceImpl struct {
+	DeviceRepository     repository.DeviceRepository
+	DeviceTypeRepository repository.DeviceTypeRepository
+	Clock                Clock
+}
+
+// NewProvisioningService returns a new ProvisioningServiceImpl that uses the system clock.
+func NewProvisioningService(deviceRepository repository.DeviceRepository, deviceTypeRepository repository.DeviceTypeRepository) *ProvisioningServiceImpl {
+	return &ProvisioningServiceImpl{
+		DeviceRepository:     deviceRepository,
+		DeviceTypeRepository: deviceTypeRepository,
+		Clock:                time.Now,
+	}
+}
+
+// PreregisterDevices implements ProvisioningService.
+// Every device is created without an owner and gets a claim code and a device
+// key, which are only ever returned here. Serial numbers that already exist are
+// skipped and reported, so a batch can be retried safely.
+func (s *ProvisioningServiceImpl) PreregisterDevices(ctx context.Context, devices []*model.Device) (*model.PreregistrationResult, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := validatePreregistration(devices); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDeviceTypes(ctx, devices); err != nil {
+		return nil, err
+	}
+
+	now := s.Clock()
+	for _, device := range devices {
+		if device.Status == "" {
+			device.Status = model.StatusProvisioned
+		}
+
+		var err error
+		device.ClaimCode, device.Claim, err = newClaimCode()
+		if err != nil {
+			return nil, err
+		}
+
+		device.DeviceKey, device.Credential, err = newDeviceKey(now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.DeviceRepository.CreateDevices(ctx, devices)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.PreregistrationResult{Devices: created}
+	createdSerials := make(map[string]bool, len(created))
+	for _, device := range created {
+		createdSerials[device.SerialNumber] = true
+	}
+	for _, device := range devices {
+		if !createdSerials[device.SerialNumber] {
+			result.ExistingSerials = append(result.ExistingSerials, device.SerialNumber)
+		}
+	}
+
+	return result, nil
+}
+
+// validatePreregistration reports every invalid field of a preregistration batch.
+func validatePreregistration(devices []*model.Device) error {
+	if len(devices) == 0 {
+		return errs.InvalidField("devices", "must not be empty")
+	}
+	if len(devices) > MaxPreregisteredDevices {
+		return errs.InvalidField("devices", fmt.Sprintf("must not contain more than %d devices", MaxPreregisteredDevices))
+	}
+
+	var violations []errs.FieldViolation
+	serials := make(map[string]bool, len(devices))
+	for i, device := range devices {
+		field := func(name string) string { return fmt.Sprintf("devices[%d].%s", i, name) }
+
+		switch {
+		case device.SerialNumber == "":
+			violations = append(violations, errs.FieldViolation{Field: field("serial_number"), Description: "is required"})
+		case serials[device.SerialNumber]:
+			violations = append(violations, errs.FieldViolation{Field: field("serial_number"), Description: "is listed more than once"})
+		}
+		serials[device.SerialNumber] = true
+
+		if device.ID != "" {
+			violations = append(violations, errs.FieldViolation{Field: field("id"), Description: "is assigned by the server and must be empty"})
+		}
+		if device.UserID != "" {
+			violations = append(violations, errs.FieldViolation{Field: field("user_id"), Description: "must be empty; the device is owned by the user who claims it"})
+		}
+		if device.RegistrationDate != 0 {
+			violations = append(violations, errs.FieldViolation{Field: field("registration_date"), Description: "is assigned when the device is claimed and must be empty"})
+		}
+		if device.Status != "" && !device.Status.Valid() {
+			violations = append(violations, errs.FieldViolation{Field: field("status"), Description: fmt.Sprintf("%q is not a valid status", device.Status)})
+		}
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	return nil
+}
+
+// checkDeviceTypes checks that every device has a device type of the catalog,
+// as CreateDevice does.
+func (s *ProvisioningServiceImpl) checkDeviceTypes(ctx context.Context, devices []*model.Device) error {
+	checker := newDeviceTypeChecker(s.DeviceTypeRepository)
+
+	var violations []errs.FieldViolation
+	for i, device := range devices {
+		deviceViolations, err := checker.check(ctx, fmt.Sprintf("devices[%d]", i), device)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, deviceViolations...)
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	return nil
+}
+
+// ClaimDevice implements ProvisioningService.
+// The caller becomes the owner of the unclaimed device with the given serial
+// number if the claim code matches; the code cannot be used again. Unknown
+// serial numbers, wrong codes and devices that were already claimed all fail
+// with ErrInvalidClaimCode, so a claim does not tell whether a serial number
+// exists. Every failed claim of a device with a claim code counts as a wrong
+// code: after MaxClaimAttempts of them the device refuses claims for ClaimLockout.
+func (s *ProvisioningServiceImpl) ClaimDevice(ctx context.Context, serialNumber string, claimCode string) (*model.Device, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.IsDevice() {
+		return nil, ErrPermissionDenied
+	}
+
+	device, err := s.DeviceRepository.GetDeviceBySerialNumber(ctx, serialNumber)
+	if errs.Is(err, errs.NotFound) {
+		return nil, ErrInvalidClaimCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Devices created without a claim code have no attempts to count.
+	if device.Claim == nil {
+		return nil, ErrInvalidClaimCode
+	}
+
+	now := s.Clock()
+	if device.Claim.LockedUntil > now.Unix() {
+		locked := *ErrClaimLocked
+		return nil, locked.WithMetadata("locked_until", strconv.FormatInt(device.Claim.LockedUntil, 10))
+	}
+
+	codeHash := hashClaimCode(claimCode)
+	matches := subtle.ConstantTimeCompare([]byte(codeHash), []byte(device.Claim.CodeHash)) == 1
+	if !matches || device.Claim.Claimed() || device.UserID != "" {
+		// Attempts racing this one may have locked claims since the device was read.
+		if err := s.DeviceRepository.RecordClaimFailure(ctx, device.ID, MaxClaimAttempts, now.Unix(), now.Add(ClaimLockout).Unix()); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidClaimCode
+	}
+
+	// Another caller may have claimed the device, or locked claims, since it was read.
+	err = s.DeviceRepository.ClaimDevice(ctx, device.ID, codeHash, identity.UserID, now.Unix())
+	if errors.Is(err, repository.ErrDeviceAlreadyClaimed) {
+		return nil, ErrInvalidClaimCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.DeviceRepository.GetDeviceById(ctx, device.ID)
+}
+
+// newClaimCode generates a random claim code, formatted as XXXX-XXXX-XXXX,
+// and the claim that stores its hash.
+func newClaimCode() (string, *model.DeviceClaim, error) {
+	random := make([]byte, 12)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		// 256 is a multiple of the alphabet size, so every symbol is equally likely.
+		code.WriteByte(claimCodeAlphabet[int(b)%len(claimCodeAlphabet)])
+	}
+
+	return code.String(), &model.DeviceClaim{CodeHash: hashClaimCode(code.String())}, nil
+}
+
+// hashClaimCode returns the hash a claim code is stored by. Codes are compared
+// without separators and case, so that typed codes match scanned ones.
+func hashClaimCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure ProvisioningServiceImpl implements ProvisioningService interface
+var _ ProvisioningService = &ProvisioningServiceImpl{}
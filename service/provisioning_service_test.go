@@ -0,0 +1,282 @@
+package service_test
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var adminClaims = map[string]string{"role": "admin"}
+
+func TestProvisioningService_PreregisterDevices(t *testing.T) {
+	// Arrange
+	devices := []*model.Device{
+		{SerialNumber: "SN-1", DeviceType: "tracker"},
+		{SerialNumber: "SN-2", DeviceType: "tracker"},
+	}
+
+	// SN-2 was preregistered by an earlier batch.
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("CreateDevices", mock.Anything, devices).
+		Return(devices[:1], nil)
+
+	provisioningService := service.NewProvisioningService(deviceRepository, newCatalog(trackerType))
+
+	// Act
+	result, err := provisioningService.PreregisterDevices(callerContext("admin", adminClaims), devices)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while preregistering devices: %s", err)
+	}
+
+	if len(result.Devices) != 1 || len(result.ExistingSerials) != 1 || result.ExistingSerials[0] != "SN-2" {
+		t.Fatalf("Expected SN-1 to be created and SN-2 to be skipped, got %+v", result)
+	}
+
+	created := result.Devices[0]
+	if !regexp.MustCompile(`^[0-9A-Z]{4}-[0-9A-Z]{4}-[0-9A-Z]{4}$`).MatchString(created.ClaimCode) {
+		t.Errorf("Expected a formatted claim code, got %q", created.ClaimCode)
+	}
+
+	if created.Claim == nil || created.Claim.CodeHash != keyHash(strings.ReplaceAll(created.ClaimCode, "-", "")) {
+		t.Errorf("Expected the hash of the claim code to be stored, got %+v", created.Claim)
+	}
+
+	if created.UserID != "" || created.Status != model.StatusProvisioned || created.DeviceKey == "" {
+		t.Errorf("Expected an unowned provisioned device with a key, got %+v", created)
+	}
+}
+
+func TestProvisioningService_PreregisterDevices_Invalid(t *testing.T) {
+	deviceRepository := new(DeviceRepositoryMock)
+	provisioningService := service.NewProvisioningService(deviceRepository, nil)
+
+	// Only admins can preregister devices.
+	_, err := provisioningService.PreregisterDevices(callerContext("caller", nil), []*model.Device{{SerialNumber: "SN-1"}})
+	if !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	// Serial numbers must be unique within the batch and devices unowned.
+	_, err = provisioningService.PreregisterDevices(callerContext("admin", adminClaims), []*model.Device{
+		{SerialNumber: "SN-1"},
+		{SerialNumber: "SN-1", UserID: "someone"},
+	})
+
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument || len(typed.FieldViolations) != 2 {
+		t.Errorf("Expected two field violations, got %v", err)
+	}
+
+	deviceRepository.AssertNotCalled(t, "CreateDevices", mock.Anything, mock.Anything)
+}
+
+func TestProvisioningService_ClaimDevice(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	hash := keyHash("ABCD1234WXYZ")
+	unclaimed := &model.Device{ID: id, SerialNumber: "SN-1", Claim: &model.DeviceClaim{CodeHash: hash}}
+	claimed := &model.Device{ID: id, SerialNumber: "SN-1", UserID: "caller"}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceBySerialNumber", mock.Anything, "SN-1").Return(unclaimed, nil)
+	deviceRepository.On("ClaimDevice", mock.Anything, id, hash, "caller", now.Unix()).Return(nil)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(claimed, nil)
+
+	provisioningService := service.NewProvisioningService(deviceRepository, nil)
+	provisioningService.Clock = func() time.Time { return now }
+
+	// Act: the code is typed in lower case.
+	result, err := provisioningService.ClaimDevice(callerContext("caller", nil), "SN-1", "abcd-1234-wxyz")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while claiming the device: %s", err)
+	}
+
+	if result != claimed {
+		t.Errorf("Expected the claimed device, got %+v", result)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestProvisioningService_ClaimDevice_WrongCode(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	device := &model.Device{ID: id, SerialNumber: "SN-1", Claim: &model.DeviceClaim{CodeHash: keyHash("ABCD1234WXYZ")}}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceBySerialNumber", mock.Anything, "SN-1").Return(device, nil)
+	deviceRepository.On("RecordClaimFailure", mock.Anything, id, service.MaxClaimAttempts, now.Unix(), now.Add(service.ClaimLockout).Unix()).Return(nil)
+
+	provisioningService := service.NewProvisioningService(deviceRepository, nil)
+	provisioningService.Clock = func() time.Time { return now }
+
+	// Act
+	_, err := provisioningService.ClaimDevice(callerContext("caller", nil), "SN-1", "AAAA-AAAA-AAAA")
+
+	// Assert
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected an invalid claim code, got %v", err)
+	}
+
+	deviceRepository.AssertExpectations(t)
+	deviceRepository.AssertNotCalled(t, "ClaimDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProvisioningService_ClaimDevice_Refused(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	hash := keyHash("ABCD1234WXYZ")
+
+	tests := []struct {
+		name     string
+		device   *model.Device
+		err      error
+		wantKind errs.Kind
+		counted  bool
+	}{
+		{name: "unknown serial number", device: (*model.Device)(nil), err: repository.ErrDeviceNotFound, wantKind: errs.InvalidArgument},
+		{name: "already claimed", device: &model.Device{ID: "1", UserID: "owner", Claim: &model.DeviceClaim{CodeHash: hash, ClaimedAt: 1}}, wantKind: errs.InvalidArgument, counted: true},
+		{name: "created without a claim code", device: &model.Device{ID: "1", UserID: "owner"}, wantKind: errs.InvalidArgument},
+		{name: "locked", device: &model.Device{ID: "1", Claim: &model.DeviceClaim{CodeHash: hash, LockedUntil: now.Unix() + 60}}, wantKind: errs.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deviceRepository := new(DeviceRepositoryMock)
+			deviceRepository.On("GetDeviceBySerialNumber", mock.Anything, "SN-1").Return(tt.device, tt.err)
+			deviceRepository.On("RecordClaimFailure", mock.Anything, "1", service.MaxClaimAttempts, now.Unix(), now.Add(service.ClaimLockout).Unix()).Return(nil)
+
+			provisioningService := service.NewProvisioningService(deviceRepository, nil)
+			provisioningService.Clock = func() time.Time { return now }
+
+			// Even the right code is refused.
+			_, err := provisioningService.ClaimDevice(callerContext("caller", nil), "SN-1", "ABCD-1234-WXYZ")
+
+			if !errs.Is(err, tt.wantKind) {
+				t.Errorf("Expected kind %v, got %v", tt.wantKind, err)
+			}
+
+			// A claimed device answers like an unknown one, and the attempt counts.
+			if tt.wantKind == errs.InvalidArgument && !errors.Is(err, service.ErrInvalidClaimCode) {
+				t.Errorf("Expected ErrInvalidClaimCode, got %v", err)
+			}
+
+			if tt.counted {
+				deviceRepository.AssertNumberOfCalls(t, "RecordClaimFailure", 1)
+			} else {
+				deviceRepository.AssertNotCalled(t, "RecordClaimFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			deviceRepository.AssertNotCalled(t, "ClaimDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestProvisioningService_ClaimDevice_Race(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	hash := keyHash("ABCD1234WXYZ")
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceBySerialNumber", mock.Anything, "SN-1").Return(&model.Device{ID: id, Claim: &model.DeviceClaim{CodeHash: hash}}, nil)
+	// Another caller claims the device first.
+	deviceRepository.On("ClaimDevice", mock.Anything, id, hash, "caller", now.Unix()).Return(repository.ErrDeviceAlreadyClaimed)
+
+	provisioningService := service.NewProvisioningService(deviceRepository, nil)
+	provisioningService.Clock = func() time.Time { return now }
+
+	// Act
+	_, err := provisioningService.ClaimDevice(callerContext("caller", nil), "SN-1", "ABCD-1234-WXYZ")
+
+	// Assert
+	if !errors.Is(err, service.ErrInvalidClaimCode) {
+		t.Errorf("Expected ErrInvalidClaimCode, got %v", err)
+	}
+}
+
+func TestProvisioningService_ClaimDevice_ConcurrentWrongCodes(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	device := &model.Device{ID: id, SerialNumber: "SN-1", Claim: &model.DeviceClaim{CodeHash: keyHash("ABCD1234WXYZ")}}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	// Every attempt reads the device before any of them locked it.
+	deviceRepository.On("GetDeviceBySerialNumber", mock.Anything, "SN-1").Return(device, nil)
+	// The repository counts the allowed attempts and refuses the rest once locked.
+	deviceRepository.On("RecordClaimFailure", mock.Anything, id, service.MaxClaimAttempts, now.Unix(), now.Add(service.ClaimLockout).Unix()).Return(nil).Times(service.MaxClaimAttempts)
+	deviceRepository.On("RecordClaimFailure", mock.Anything, id, service.MaxClaimAttempts, now.Unix(), now.Add(service.ClaimLockout).Unix()).Return(repository.ErrClaimLocked)
+
+	provisioningService := service.NewProvisioningService(deviceRepository, nil)
+	provisioningService.Clock = func() time.Time { return now }
+
+	// Act
+	attempts := 3 * service.MaxClaimAttempts
+	results := make(chan error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provisioningService.ClaimDevice(callerContext("caller", nil), "SN-1", "AAAA-AAAA-AAAA")
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	// Assert: only the allowed attempts were judged; the others found claims locked.
+	wrong, locked := 0, 0
+	for err := range results {
+		switch {
+		case errors.Is(err, service.ErrInvalidClaimCode):
+			wrong++
+		case errors.Is(err, service.ErrClaimLocked):
+			locked++
+		default:
+			t.Errorf("Expected a wrong code or a lockout, got %v", err)
+		}
+	}
+
+	if wrong != service.MaxClaimAttempts || locked != attempts-service.MaxClaimAttempts {
+		t.Errorf("Expected %d wrong codes before the lockout, got %d wrong and %d locked", service.MaxClaimAttempts, wrong, locked)
+	}
+}
+
+func TestProvisioningService_PreregisterDevices_UnknownDeviceType(t *testing.T) {
+	// Arrange
+	devices := []*model.Device{
+		{SerialNumber: "SN-1", DeviceType: "tracker"},
+		{SerialNumber: "SN-2", DeviceType: "Tracker"},
+		{SerialNumber: "SN-3"},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	provisioningService := service.NewProvisioningService(deviceRepository, newCatalog(trackerType))
+
+	// Act
+	_, err := provisioningService.PreregisterDevices(callerContext("admin", adminClaims), devices)
+
+	// Assert
+	var typed *errs.Error
+	if !errors.As(err, &typed) || len(typed.FieldViolations) != 2 || typed.FieldViolations[0].Field != "devices[1].device_type" || typed.FieldViolations[1].Field != "devices[2].device_type" {
+		t.Fatalf("Expected the unknown and the missing device types to be rejected, got %v", err)
+	}
+
+	deviceRepository.AssertNotCalled(t, "CreateDevices", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// MaxDeviceShares is the largest number of users a device can be shared with.
+const MaxDeviceShares = 20
+
+type SharingService interface {
+	ShareDevice(ctx context.Context, deviceId string, share *model.DeviceShare) (*model.Device, error)
+	UnshareDevice(ctx context.Context, deviceId string, userId string) error
+	ListSharedDevices(ctx context.Context) ([]*model.Device, error)
+}
+
+// SharingServiceImpl lets owners grant other users viewer or editor access to
+// their devices. Sharing a device again with the same user replaces the earlier
+// share.
+type SharingServiceImpl struct {
+	DeviceRepository repository.DeviceRepository
+	Clock            Clock
+}
+
+// NewSharingService returns a new SharingServiceImpl that uses the system clock.
+func NewSharingService(deviceRepository repository.DeviceRepository) *SharingServiceImpl {
+	return &SharingServiceImpl{
+		DeviceRepository: deviceRepository,
+		Clock:            time.Now,
+	}
+}
+
+// ShareDevice implements SharingService.
+// Only the owner, or an admin, may share a device. The grantor and grant time
+// are assigned by the server.
+func (s *SharingServiceImpl) ShareDevice(ctx context.Context, deviceId string, share *model.DeviceShare) (*model.Device, error) {
+	device, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, model.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.Clock().Unix()
+	var violations []errs.FieldViolation
+	if share.UserID == "" {
+		violations = append(violations, errs.FieldViolation{Field: "user_id", Description: "is required"})
+	} else if share.UserID == device.UserID {
+		violations = append(violations, errs.FieldViolation{Field: "user_id", Description: "must be another user than the owner"})
+	}
+	if !share.Role.Shareable() {
+		violations = append(violations, errs.FieldViolation{Field: "role", Description: "must be VIEWER or EDITOR"})
+	}
+	if share.ExpiresAt != 0 && share.ExpiresAt <= now {
+		violations = append(violations, errs.FieldViolation{Field: "expires_at", Description: "must be in the future"})
+	}
+	if len(violations) > 0 {
+		return nil, errs.InvalidFields(violations...)
+	}
+
+	// Expired shares are dropped rather than counted against the limit.
+	shares := make([]model.DeviceShare, 0, len(device.Shares)+1)
+	for _, existing := range device.Shares {
+		if existing.UserID != share.UserID && existing.Active(now) {
+			shares = append(shares, existing)
+		}
+	}
+	if len(shares) >= MaxDeviceShares {
+		return nil, repository.ErrShareLimitReached
+	}
+
+	share.GrantedBy = identity.UserID
+	share.GrantedAt = now
+
+	// The repository checks the limit again, against shares added since the device was read.
+	if err := s.DeviceRepository.ShareDevice(ctx, device.ID, device.UserID, share, MaxDeviceShares, now); err != nil {
+		return nil, err
+	}
+
+	device.Shares = append(shares, *share)
+	device.Role = device.RoleOf(identity.UserID, now)
+	return device, nil
+}
+
+// UnshareDevice implements SharingService.
+// The owner, or an admin, may remove any share; a user may also give up a
+// device shared with them.
+func (s *SharingServiceImpl) UnshareDevice(ctx context.Context, deviceId string, userId string) error {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userId == "" {
+		return errs.InvalidField("user_id", "is required")
+	}
+
+	role := model.RoleOwner
+	if !identity.IsDevice() && userId == identity.UserID {
+		role = model.RoleViewer
+	}
+
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, role); err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.UnshareDevice(ctx, deviceId, userId)
+}
+
+// ListSharedDevices implements SharingService.
+// It returns the devices shared with the caller, each with the caller's role.
+func (s *SharingServiceImpl) ListSharedDevices(ctx context.Context) ([]*model.Device, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.IsDevice() {
+		return nil, ErrPermissionDenied
+	}
+
+	now := s.Clock().Unix()
+	devices, err := s.DeviceRepository.GetSharedDevices(ctx, identity.UserID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return withRoles(devices, identity.UserID, now), nil
+}
+
+// Ensure SharingServiceImpl implements SharingService interface
+var _ SharingService = &SharingServiceImpl{}
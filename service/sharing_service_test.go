@@ -0,0 +1,287 @@
+package service_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSharingService_ShareDevice(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	earlier := model.DeviceShare{UserID: "friend", Role: model.RoleViewer, GrantedBy: "owner", GrantedAt: 1}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner", Shares: []model.DeviceShare{earlier}}, nil)
+
+	expected := &model.DeviceShare{UserID: "friend", Role: model.RoleEditor, GrantedBy: "owner", GrantedAt: now.Unix()}
+	deviceRepository.On("ShareDevice", mock.Anything, id, "owner", expected, service.MaxDeviceShares, now.Unix()).Return(nil)
+
+	sharingService := service.NewSharingService(deviceRepository)
+	sharingService.Clock = func() time.Time { return now }
+
+	// Act
+	device, err := sharingService.ShareDevice(callerContext("owner", nil), id, &model.DeviceShare{UserID: "friend", Role: model.RoleEditor})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while sharing the device: %s", err)
+	}
+
+	if len(device.Shares) != 1 || device.Shares[0] != *expected {
+		t.Errorf("Expected the new share to replace the earlier one, got %+v", device.Shares)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestSharingService_ShareDevice_LimitReached(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+	// Other shares were added since the device was read.
+	deviceRepository.On("ShareDevice", mock.Anything, id, "owner", mock.Anything, service.MaxDeviceShares, mock.Anything).Return(repository.ErrShareLimitReached)
+
+	sharingService := service.NewSharingService(deviceRepository)
+
+	// Act
+	_, err := sharingService.ShareDevice(callerContext("owner", nil), id, &model.DeviceShare{UserID: "friend", Role: model.RoleViewer})
+
+	// Assert
+	if !errors.Is(err, repository.ErrShareLimitReached) || !errs.Is(err, errs.FailedPrecondition) {
+		t.Errorf("Expected a failed precondition once the device is shared with too many users, got %v", err)
+	}
+}
+
+func TestSharingService_ShareDevice_ExpiredSharesNotCounted(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+
+	expired := make([]model.DeviceShare, 0, service.MaxDeviceShares)
+	for i := 0; i < service.MaxDeviceShares; i++ {
+		expired = append(expired, model.DeviceShare{UserID: fmt.Sprintf("user%d", i), Role: model.RoleViewer, ExpiresAt: now.Unix() - 60})
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner", Shares: expired}, nil)
+	deviceRepository.On("ShareDevice", mock.Anything, id, "owner", mock.Anything, service.MaxDeviceShares, now.Unix()).Return(nil)
+
+	sharingService := service.NewSharingService(deviceRepository)
+	sharingService.Clock = func() time.Time { return now }
+
+	// Act
+	device, err := sharingService.ShareDevice(callerContext("owner", nil), id, &model.DeviceShare{UserID: "friend", Role: model.RoleViewer})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while the other shares have expired: %s", err)
+	}
+
+	if len(device.Shares) != 1 || device.Shares[0].UserID != "friend" {
+		t.Errorf("Expected the expired shares to be dropped, got %+v", device.Shares)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestSharingService_ShareDevice_Invalid(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{
+		ID:     id,
+		UserID: "owner",
+		Shares: []model.DeviceShare{{UserID: "editor", Role: model.RoleEditor}},
+	}, nil)
+
+	sharingService := service.NewSharingService(deviceRepository)
+	sharingService.Clock = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		caller string
+		share  *model.DeviceShare
+		kind   errs.Kind
+	}{
+		{"missing user", "owner", &model.DeviceShare{Role: model.RoleViewer}, errs.InvalidArgument},
+		{"owner", "owner", &model.DeviceShare{UserID: "owner", Role: model.RoleViewer}, errs.InvalidArgument},
+		{"owner role", "owner", &model.DeviceShare{UserID: "friend", Role: model.RoleOwner}, errs.InvalidArgument},
+		{"expired", "owner", &model.DeviceShare{UserID: "friend", Role: model.RoleViewer, ExpiresAt: now.Unix()}, errs.InvalidArgument},
+		{"editor", "editor", &model.DeviceShare{UserID: "friend", Role: model.RoleViewer}, errs.PermissionDenied},
+		{"stranger", "stranger", &model.DeviceShare{UserID: "friend", Role: model.RoleViewer}, errs.PermissionDenied},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := sharingService.ShareDevice(callerContext(test.caller, nil), id, test.share)
+
+			if !errs.Is(err, test.kind) {
+				t.Errorf("Expected %v, got %v", test.kind, err)
+			}
+		})
+	}
+
+	deviceRepository.AssertNotCalled(t, "ShareDevice", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSharingService_UnshareDevice(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	shares := []model.DeviceShare{
+		{UserID: "viewer", Role: model.RoleViewer},
+		{UserID: "editor", Role: model.RoleEditor},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner", Shares: shares}, nil)
+	deviceRepository.On("UnshareDevice", mock.Anything, id, mock.Anything).Return(nil)
+
+	sharingService := service.NewSharingService(deviceRepository)
+
+	// The owner may remove anyone, and users may remove themselves, but not each other.
+	if err := sharingService.UnshareDevice(callerContext("owner", nil), id, "editor"); err != nil {
+		t.Errorf("Error was not expected while the owner unshares the device: %s", err)
+	}
+
+	if err := sharingService.UnshareDevice(callerContext("viewer", nil), id, "viewer"); err != nil {
+		t.Errorf("Error was not expected while a viewer leaves the device: %s", err)
+	}
+
+	if err := sharingService.UnshareDevice(callerContext("editor", nil), id, "viewer"); !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied while an editor removes another user, got %v", err)
+	}
+
+	deviceRepository.AssertNumberOfCalls(t, "UnshareDevice", 2)
+}
+
+func TestSharingService_ListSharedDevices(t *testing.T) {
+	// Arrange
+	now := time.Unix(1700000000, 0)
+	shared := &model.Device{
+		ID:     primitive.NewObjectID().Hex(),
+		UserID: "owner",
+		Shares: []model.DeviceShare{
+			{UserID: "caller", Role: model.RoleEditor},
+			{UserID: "other", Role: model.RoleViewer},
+		},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetSharedDevices", mock.Anything, "caller", now.Unix()).Return([]*model.Device{shared}, nil)
+
+	sharingService := service.NewSharingService(deviceRepository)
+	sharingService.Clock = func() time.Time { return now }
+
+	// Act
+	devices, err := sharingService.ListSharedDevices(callerContext("caller", nil))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while listing shared devices: %s", err)
+	}
+
+	if len(devices) != 1 || devices[0].Role != model.RoleEditor {
+		t.Fatalf("Expected the shared device with the caller's role, got %+v", devices)
+	}
+
+	if devices[0].Shares != nil {
+		t.Errorf("Expected the other users of a shared device to be hidden, got %+v", devices[0].Shares)
+	}
+}
+
+func TestDeviceService_SharedAccess(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	device := &model.Device{
+		ID:     id,
+		UserID: "owner",
+		Status: model.StatusActive,
+		Shares: []model.DeviceShare{
+			{UserID: "viewer", Role: model.RoleViewer},
+			{UserID: "editor", Role: model.RoleEditor},
+			{UserID: "expired", Role: model.RoleEditor, ExpiresAt: 1},
+		},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(device, nil)
+	deviceRepository.On("PatchDevice", mock.Anything, mock.Anything, []string{"name"}, (*model.StatusChange)(nil)).Return(nil)
+
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+	patch := &model.Device{ID: id, Name: "Renamed"}
+
+	// Act
+	_, viewerPatch := deviceService.PatchDevice(callerContext("viewer", nil), patch, []string{"name"})
+	_, editorPatch := deviceService.PatchDevice(callerContext("editor", nil), patch, []string{"name"})
+	editorDelete := deviceService.DeleteDevice(callerContext("editor", nil), id, 0)
+	_, expiredErr := deviceService.GetDeviceById(callerContext("expired", nil), id)
+	// Reading last, because it hides the shares of the device the mock returns.
+	viewed, viewErr := deviceService.GetDeviceById(callerContext("viewer", nil), id)
+
+	// Assert
+	if viewErr != nil {
+		t.Fatalf("Error was not expected while a viewer reads the device: %s", viewErr)
+	}
+
+	if viewed.Role != model.RoleViewer || viewed.Shares != nil {
+		t.Errorf("Expected the viewer's role without the other shares, got %s and %+v", viewed.Role, viewed.Shares)
+	}
+
+	if !errs.Is(viewerPatch, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied while a viewer patches the device, got %v", viewerPatch)
+	}
+
+	if editorPatch != nil {
+		t.Errorf("Error was not expected while an editor patches the device: %s", editorPatch)
+	}
+
+	if !errs.Is(editorDelete, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied while an editor deletes the device, got %v", editorDelete)
+	}
+
+	if !errs.Is(expiredErr, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied for an expired share, got %v", expiredErr)
+	}
+
+	deviceRepository.AssertNumberOfCalls(t, "PatchDevice", 1)
+}
+
+func TestDeviceService_GetDevicesByUserId_Shared(t *testing.T) {
+	// Arrange
+	owned := &model.Device{ID: primitive.NewObjectID().Hex(), UserID: "caller"}
+	shared := &model.Device{
+		ID:     primitive.NewObjectID().Hex(),
+		UserID: "owner",
+		Shares: []model.DeviceShare{{UserID: "caller", Role: model.RoleViewer}},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDevicesByUserId", mock.Anything, "caller").Return([]*model.Device{owned}, nil)
+	deviceRepository.On("GetSharedDevices", mock.Anything, "caller", mock.Anything).Return([]*model.Device{shared}, nil)
+
+	deviceService := service.NewDeviceService(deviceRepository, nil, nil)
+
+	// Act
+	devices, err := deviceService.GetDevicesByUserId(callerContext("caller", nil), "")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while getting devices by user id: %s", err)
+	}
+
+	if len(devices) != 2 || devices[0].Role != model.RoleOwner || devices[1].Role != model.RoleViewer {
+		t.Errorf("Expected the owned device followed by the shared one, got %+v", devices)
+	}
+}
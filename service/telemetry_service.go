@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// maxClockSkew is how far in the future a sample timestamp may lie before it is
+// rejected, to tolerate device clocks that run slightly fast.
+const maxClockSkew = 5 * time.Minute
+
+// chargingStates lists the charging states a device may report.
+var chargingStates = map[model.ChargingState]bool{
+	model.ChargingUnknown:     true,
+	model.ChargingCharging:    true,
+	model.ChargingDischarging: true,
+	model.ChargingFull:        true,
+}
+
+type TelemetryService interface {
+	ReportTelemetry(ctx context.Context, deviceId string, samples []*model.TelemetrySample) error
+	GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error)
+}
+
+// TelemetryServiceImpl records device telemetry. Like DeviceServiceImpl, it
+// lets viewers of a device read its telemetry and editors report it.
+type TelemetryServiceImpl struct {
+	TelemetryRepository repository.TelemetryRepository
+	DeviceRepository    repository.DeviceRepository
+	Clock               Clock
+}
+
+// NewTelemetryService returns a new TelemetryServiceImpl that uses the system clock.
+func NewTelemetryService(telemetryRepository repository.TelemetryRepository, deviceRepository repository.DeviceRepository) *TelemetryServiceImpl {
+	return &TelemetryServiceImpl{
+		TelemetryRepository: telemetryRepository,
+		DeviceRepository:    deviceRepository,
+		Clock:               time.Now,
+	}
+}
+
+// ReportTelemetry implements TelemetryService.
+// Samples without a timestamp are stamped with the current time. The newest
+// sample becomes the device's latest telemetry.
+func (s *TelemetryServiceImpl) ReportTelemetry(ctx context.Context, deviceId string, samples []*model.TelemetrySample) error {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, deviceId, model.RoleEditor); err != nil {
+		return err
+	}
+
+	if len(samples) == 0 {
+		return errs.InvalidField("samples", "must contain at least one sample")
+	}
+
+	now := s.Clock()
+	var violations []errs.FieldViolation
+	var latest *model.TelemetrySample
+	for i, sample := range samples {
+		sample.DeviceID = deviceId
+		if sample.Timestamp == 0 {
+			sample.Timestamp = now.Unix()
+		}
+
+		violations = append(violations, validateSample(fmt.Sprintf("samples[%d]", i), sample, now)...)
+
+		if latest == nil || sample.Timestamp > latest.Timestamp {
+			latest = sample
+		}
+	}
+
+	if len(violations) > 0 {
+		return errs.InvalidFields(violations...)
+	}
+
+	if err := s.TelemetryRepository.InsertSamples(ctx, samples); err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.UpdateTelemetry(ctx, deviceId, latest)
+}
+
+// GetTelemetryHistory implements TelemetryService.
+func (s *TelemetryServiceImpl) GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error) {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, query.DeviceID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if query.To != 0 && query.From >= query.To {
+		return nil, errs.InvalidField("end_time", "must be after start_time")
+	}
+
+	return s.TelemetryRepository.GetTelemetryHistory(ctx, query)
+}
+
+// validateSample returns the field violations of a sample reported at now.
+func validateSample(field string, sample *model.TelemetrySample, now time.Time) []errs.FieldViolation {
+	var violations []errs.FieldViolation
+
+	if sample.BatteryLevel < 0 || sample.BatteryLevel > 100 {
+		violations = append(violations, errs.FieldViolation{Field: field + ".battery_level", Description: "must be between 0 and 100"})
+	}
+
+	if !chargingStates[sample.ChargingState] {
+		violations = append(violations, errs.FieldViolation{Field: field + ".charging_state", Description: fmt.Sprintf("unknown charging state %q", sample.ChargingState)})
+	}
+
+	if sample.SignalStrength > 0 {
+		violations = append(violations, errs.FieldViolation{Field: field + ".signal_strength", Description: "must be a dBm value of 0 or below"})
+	}
+
+	if time.Unix(sample.Timestamp, 0).After(now.Add(maxClockSkew)) {
+		violations = append(violations, errs.FieldViolation{Field: field + ".timestamp", Description: "must not be in the future"})
+	}
+
+	return violations
+}
+
+// Ensure TelemetryServiceImpl implements TelemetryService interface
+var _ TelemetryService = &TelemetryServiceImpl{}
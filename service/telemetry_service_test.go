@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the telemetry repository
+type TelemetryRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *TelemetryRepositoryMock) InsertSamples(ctx context.Context, samples []*model.TelemetrySample) error {
+	args := r.Called(ctx, samples)
+	return args.Error(0)
+}
+
+func (r *TelemetryRepositoryMock) GetTelemetryHistory(ctx context.Context, query *model.TelemetryQuery) ([]*model.TelemetrySample, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).([]*model.TelemetrySample), args.Error(1)
+}
+
+func TestTelemetryService_ReportTelemetry(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := primitive.NewObjectID().Hex()
+	samples := []*model.TelemetrySample{
+		{Timestamp: now.Add(-time.Minute).Unix(), BatteryLevel: 81, ChargingState: model.ChargingDischarging, SignalStrength: -70},
+		{BatteryLevel: 80, ChargingState: model.ChargingDischarging, SignalStrength: -72},
+		{Timestamp: now.Add(-2 * time.Minute).Unix(), BatteryLevel: 82, ChargingState: model.ChargingDischarging, SignalStrength: -69},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+	deviceRepository.On("UpdateTelemetry", mock.Anything, id, samples[1]).Return(nil)
+
+	telemetryRepository := new(TelemetryRepositoryMock)
+	telemetryRepository.On("InsertSamples", mock.Anything, samples).Return(nil)
+
+	telemetryService := service.NewTelemetryService(telemetryRepository, deviceRepository)
+	telemetryService.Clock = func() time.Time { return now }
+
+	// Act
+	err := telemetryService.ReportTelemetry(callerContext("caller", nil), id, samples)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while reporting telemetry: %s", err)
+	}
+
+	if samples[1].Timestamp != now.Unix() || samples[1].DeviceID != id {
+		t.Errorf("Expected the sample without a timestamp to be stamped with the clock, got %+v", samples[1])
+	}
+
+	deviceRepository.AssertExpectations(t)
+	telemetryRepository.AssertExpectations(t)
+}
+
+func TestTelemetryService_ReportTelemetry_InvalidSamples(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := primitive.NewObjectID().Hex()
+	samples := []*model.TelemetrySample{
+		{BatteryLevel: 101},
+		{BatteryLevel: 50, ChargingState: "overheating"},
+		{BatteryLevel: 50, SignalStrength: 10},
+		{BatteryLevel: 50, Timestamp: now.Add(time.Hour).Unix()},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	telemetryService := service.NewTelemetryService(new(TelemetryRepositoryMock), deviceRepository)
+	telemetryService.Clock = func() time.Time { return now }
+
+	// Act
+	err := telemetryService.ReportTelemetry(callerContext("caller", nil), id, samples)
+
+	// Assert
+	var typed *errs.Error
+	if !errors.As(err, &typed) || typed.Kind != errs.InvalidArgument {
+		t.Fatalf("Expected invalid argument, got %v", err)
+	}
+
+	if len(typed.FieldViolations) != len(samples) {
+		t.Errorf("Expected one violation per sample, got %v", typed.FieldViolations)
+	}
+
+	if err := telemetryService.ReportTelemetry(callerContext("caller", nil), id, nil); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument for an empty report, got %v", err)
+	}
+}
+
+func TestTelemetryService_ReportTelemetry_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "owner"}, nil)
+
+	telemetryService := service.NewTelemetryService(new(TelemetryRepositoryMock), deviceRepository)
+
+	// Act
+	err := telemetryService.ReportTelemetry(callerContext("intruder", nil), id, []*model.TelemetrySample{{BatteryLevel: 50}})
+
+	// Assert
+	if !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+}
+
+func TestTelemetryService_GetTelemetryHistory(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	query := &model.TelemetryQuery{DeviceID: id, From: 1000, To: 2000, Interval: 60}
+	samples := []*model.TelemetrySample{{DeviceID: id, Timestamp: 1020, BatteryLevel: 50}}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	telemetryRepository := new(TelemetryRepositoryMock)
+	telemetryRepository.On("GetTelemetryHistory", mock.Anything, query).Return(samples, nil)
+
+	telemetryService := service.NewTelemetryService(telemetryRepository, deviceRepository)
+
+	// Act
+	result, err := telemetryService.GetTelemetryHistory(callerContext("caller", nil), query)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while getting telemetry history: %s", err)
+	}
+
+	if len(result) != 1 {
+		t.Errorf("Expected 1 sample, got %d", len(result))
+	}
+
+	_, err = telemetryService.GetTelemetryHistory(callerContext("caller", nil), &model.TelemetryQuery{DeviceID: id, From: 2000, To: 1000})
+	if !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument for an empty time range, got %v", err)
+	}
+}
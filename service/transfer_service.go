@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+)
+
+// DefaultTransferExpiry is how long the recipient of a transfer has to answer it.
+const DefaultTransferExpiry = 7 * 24 * time.Hour
+
+var (
+	// ErrNoTransfer is returned when a transfer is answered that was not offered to the caller or has expired.
+	ErrNoTransfer = errs.New(errs.NotFound, "DEVICE_TRANSFER_NOT_FOUND", "no pending transfer of this device to the caller")
+	// ErrTransferToOwner is returned when the owner offers a device to themselves.
+	ErrTransferToOwner = errs.InvalidField("to_user_id", "must be another user than the owner")
+)
+
+type TransferService interface {
+	StartTransfer(ctx context.Context, deviceId string, toUserId string, wipeHistory bool) (*model.Device, error)
+	AcceptTransfer(ctx context.Context, deviceId string) (*model.Device, error)
+	RejectTransfer(ctx context.Context, deviceId string) error
+	ListIncomingTransfers(ctx context.Context) ([]*model.Device, error)
+}
+
+// TransferServiceImpl hands devices over between users. The owner, or an
+// admin, offers a device to another user, who accepts or rejects it before the
+// offer expires. Every answer is recorded in the device's ownership history.
+type TransferServiceImpl struct {
+	DeviceRepository repository.DeviceRepository
+	History          []repository.DeviceHistory
+	Expiry           time.Duration
+	Clock            Clock
+}
+
+// NewTransferService returns a new TransferServiceImpl that uses the system
+// clock and DefaultTransferExpiry. The history repositories are erased for
+// transfers that ask for it.
+func NewTransferService(deviceRepository repository.DeviceRepository, history ...repository.DeviceHistory) *TransferServiceImpl {
+	return &TransferServiceImpl{
+		DeviceRepository: deviceRepository,
+		History:          history,
+		Expiry:           DefaultTransferExpiry,
+		Clock:            time.Now,
+	}
+}
+
+// StartTransfer implements TransferService.
+// A new transfer replaces the one offered before, if any.
+func (s *TransferServiceImpl) StartTransfer(ctx context.Context, deviceId string, toUserId string, wipeHistory bool) (*model.Device, error) {
+	device, err := s.DeviceRepository.GetDeviceById(ctx, deviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwner(ctx, device.UserID); err != nil {
+		return nil, err
+	}
+
+	if toUserId == "" {
+		return nil, errs.InvalidField("to_user_id", "is required")
+	}
+	if toUserId == device.UserID {
+		return nil, ErrTransferToOwner
+	}
+
+	now := s.Clock()
+	transfer := &model.DeviceTransfer{
+		ToUserID:    toUserId,
+		WipeHistory: wipeHistory,
+		CreatedAt:   now.Unix(),
+		ExpiresAt:   now.Add(s.Expiry).Unix(),
+	}
+
+	if err := s.DeviceRepository.StartTransfer(ctx, device.ID, device.UserID, transfer); err != nil {
+		return nil, err
+	}
+
+	device.Transfer = transfer
+	return device, nil
+}
+
+// AcceptTransfer implements TransferService.
+// The caller becomes the owner of the device. If the transfer asks for it, the
+// history of the device is erased once the owner has changed, so a transfer
+// that fails to change hands keeps its history. A wipe that fails is finished
+// later by the DevicePurger.
+func (s *TransferServiceImpl) AcceptTransfer(ctx context.Context, deviceId string) (*model.Device, error) {
+	device, change, err := s.pendingTransfer(ctx, deviceId, model.TransferAccepted)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DeviceRepository.AnswerTransfer(ctx, device.ID, change); err != nil {
+		return nil, err
+	}
+
+	if change.HistoryWiped {
+		if err := wipeDeviceHistory(ctx, s.DeviceRepository, s.History, device.ID, change.AnsweredAt); err != nil {
+			log.Printf("failed to wipe the history of device %s, it will be retried: %v", device.ID, err)
+		}
+	}
+
+	return s.DeviceRepository.GetDeviceById(ctx, device.ID)
+}
+
+// RejectTransfer implements TransferService.
+// The device stays with its owner.
+func (s *TransferServiceImpl) RejectTransfer(ctx context.Context, deviceId string) error {
+	device, change, err := s.pendingTransfer(ctx, deviceId, model.TransferRejected)
+	if err != nil {
+		return err
+	}
+
+	return s.DeviceRepository.AnswerTransfer(ctx, device.ID, change)
+}
+
+// wipeDeviceHistory erases the history of a device up to before, when the
+// transfer whose wipe is pending was accepted, and records that it is done.
+// Erasing is idempotent and leaves later data alone, so a wipe that fails can
+// be repeated after the new owner's device has reported.
+func wipeDeviceHistory(ctx context.Context, deviceRepository repository.DeviceRepository, histories []repository.DeviceHistory, id string, before int64) error {
+	for _, history := range histories {
+		if err := history.DeleteDeviceHistory(ctx, id, before); err != nil {
+			return err
+		}
+	}
+
+	return deviceRepository.CompleteHistoryWipe(ctx, id, before)
+}
+
+// pendingTransfer returns a device with a transfer to the caller that has not
+// expired, and the change that answers it with outcome.
+func (s *TransferServiceImpl) pendingTransfer(ctx context.Context, deviceId string, outcome model.TransferOutcome) (*model.Device, *model.OwnershipChange, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if identity.IsDevice() {
+		return nil, nil, ErrPermissionDenied
+	}
+
+	device, err := s.DeviceRepository.GetDeviceById(ctx, deviceId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Callers without a transfer learn no more about the device than that there is none.
+	now := s.Clock().Unix()
+	transfer := device.Transfer
+	if transfer == nil || transfer.ToUserID != identity.UserID || transfer.Expired(now) {
+		return nil, nil, ErrNoTransfer
+	}
+
+	return device, &model.OwnershipChange{
+		FromUserID:   device.UserID,
+		ToUserID:     transfer.ToUserID,
+		Outcome:      outcome,
+		HistoryWiped: outcome == model.TransferAccepted && transfer.WipeHistory,
+		OfferedAt:    transfer.CreatedAt,
+		AnsweredAt:   now,
+	}, nil
+}
+
+// ListIncomingTransfers implements TransferService.
+// It returns the devices offered to the caller that can still be accepted.
+// Until the caller owns a device, only what identifies it and its transfer is
+// returned, not its telemetry or location.
+func (s *TransferServiceImpl) ListIncomingTransfers(ctx context.Context) ([]*model.Device, error) {
+	identity, err := callerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if identity.IsDevice() {
+		return nil, ErrPermissionDenied
+	}
+
+	devices, err := s.DeviceRepository.GetIncomingTransfers(ctx, identity.UserID, s.Clock().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	offered := make([]*model.Device, 0, len(devices))
+	for _, device := range devices {
+		offered = append(offered, &model.Device{
+			ID:           device.ID,
+			UserID:       device.UserID,
+			SerialNumber: device.SerialNumber,
+			DeviceType:   device.DeviceType,
+			Name:         device.Name,
+			Status:       device.Status,
+			Transfer:     device.Transfer,
+		})
+	}
+
+	return offered, nil
+}
+
+// Ensure TransferServiceImpl implements TransferService interface
+var _ TransferService = &TransferServiceImpl{}
@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeDeviceHistory records the devices whose history was deleted, and up to when.
+type fakeDeviceHistory struct {
+	deleted []string
+	before  []int64
+	err     error
+}
+
+func (h *fakeDeviceHistory) DeleteDeviceHistory(_ context.Context, deviceId string, before int64) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.deleted = append(h.deleted, deviceId)
+	h.before = append(h.before, before)
+	return nil
+}
+
+func TestTransferService_StartTransfer(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller"}, nil)
+
+	expected := &model.DeviceTransfer{ToUserID: "buyer", WipeHistory: true, CreatedAt: now.Unix(), ExpiresAt: now.Add(service.DefaultTransferExpiry).Unix()}
+	deviceRepository.On("StartTransfer", mock.Anything, id, "seller", expected).Return(nil)
+
+	transferService := service.NewTransferService(deviceRepository)
+	transferService.Clock = func() time.Time { return now }
+
+	// Act
+	device, err := transferService.StartTransfer(callerContext("seller", nil), id, "buyer", true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while starting the transfer: %s", err)
+	}
+
+	if device.Transfer == nil || *device.Transfer != *expected {
+		t.Errorf("Expected the device with its transfer, got %+v", device.Transfer)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestTransferService_StartTransfer_Invalid(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller"}, nil)
+
+	transferService := service.NewTransferService(deviceRepository)
+
+	// Only the owner can give the device away.
+	if _, err := transferService.StartTransfer(callerContext("buyer", nil), id, "buyer", false); !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+
+	// The recipient must be someone else.
+	if _, err := transferService.StartTransfer(callerContext("seller", nil), id, "seller", false); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument, got %v", err)
+	}
+
+	deviceRepository.AssertNotCalled(t, "StartTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferService_AcceptTransfer(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	transfer := &model.DeviceTransfer{ToUserID: "buyer", WipeHistory: true, CreatedAt: now.Unix() - 60, ExpiresAt: now.Unix() + 60}
+	transferred := &model.Device{ID: id, UserID: "buyer"}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller", Transfer: transfer}, nil).Once()
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(transferred, nil).Once()
+
+	var change *model.OwnershipChange
+	deviceRepository.On("AnswerTransfer", mock.Anything, id, mock.Anything).
+		Run(func(args mock.Arguments) { change = args.Get(2).(*model.OwnershipChange) }).
+		Return(nil)
+	deviceRepository.On("CompleteHistoryWipe", mock.Anything, id, now.Unix()).Return(nil).Once()
+
+	telemetry, locations := &fakeDeviceHistory{}, &fakeDeviceHistory{}
+	transferService := service.NewTransferService(deviceRepository, telemetry, locations)
+	transferService.Clock = func() time.Time { return now }
+
+	// Act
+	device, err := transferService.AcceptTransfer(callerContext("buyer", nil), id)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while accepting the transfer: %s", err)
+	}
+
+	if device != transferred {
+		t.Errorf("Expected the transferred device, got %+v", device)
+	}
+
+	expected := model.OwnershipChange{FromUserID: "seller", ToUserID: "buyer", Outcome: model.TransferAccepted, HistoryWiped: true, OfferedAt: transfer.CreatedAt, AnsweredAt: now.Unix()}
+	if change == nil || *change != expected {
+		t.Errorf("Expected %+v to be recorded, got %+v", expected, change)
+	}
+
+	if len(telemetry.deleted) != 1 || len(locations.deleted) != 1 || telemetry.deleted[0] != id {
+		t.Errorf("Expected the history to be deleted, got %v and %v", telemetry.deleted, locations.deleted)
+	}
+
+	// Only what was reported until the transfer was accepted is deleted.
+	if telemetry.before[0] != now.Unix() || locations.before[0] != now.Unix() {
+		t.Errorf("Expected the history up to %d to be deleted, got %v and %v", now.Unix(), telemetry.before, locations.before)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestTransferService_AcceptTransfer_Conflict(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	transfer := &model.DeviceTransfer{ToUserID: "buyer", WipeHistory: true, CreatedAt: now.Unix() - 60, ExpiresAt: now.Unix() + 60}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller", Transfer: transfer}, nil)
+	// The transfer was cancelled after it was read.
+	deviceRepository.On("AnswerTransfer", mock.Anything, id, mock.Anything).Return(repository.ErrTransferConflict)
+
+	history := &fakeDeviceHistory{}
+	transferService := service.NewTransferService(deviceRepository, history)
+	transferService.Clock = func() time.Time { return now }
+
+	if _, err := transferService.AcceptTransfer(callerContext("buyer", nil), id); !errors.Is(err, repository.ErrTransferConflict) {
+		t.Errorf("Expected a transfer conflict, got %v", err)
+	}
+
+	if len(history.deleted) != 0 {
+		t.Errorf("Expected the history of a device that kept its owner to be kept, got %v", history.deleted)
+	}
+}
+
+func TestTransferService_AcceptTransfer_NotOffered(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	transfer := &model.DeviceTransfer{ToUserID: "buyer", CreatedAt: now.Unix() - 120, ExpiresAt: now.Unix() - 60}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller", Transfer: transfer}, nil)
+
+	transferService := service.NewTransferService(deviceRepository)
+	transferService.Clock = func() time.Time { return now }
+
+	// The transfer has expired for its recipient and was never offered to anyone else.
+	for _, caller := range []string{"buyer", "intruder"} {
+		if _, err := transferService.AcceptTransfer(callerContext(caller, nil), id); !errs.Is(err, errs.NotFound) {
+			t.Errorf("Expected no transfer for %s, got %v", caller, err)
+		}
+	}
+
+	deviceRepository.AssertNotCalled(t, "AnswerTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransferService_RejectTransfer(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	now := time.Unix(1700000000, 0)
+	transfer := &model.DeviceTransfer{ToUserID: "buyer", WipeHistory: true, CreatedAt: now.Unix() - 60, ExpiresAt: now.Unix() + 60}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "seller", Transfer: transfer}, nil)
+	deviceRepository.On("AnswerTransfer", mock.Anything, id, &model.OwnershipChange{
+		FromUserID: "seller", ToUserID: "buyer", Outcome: model.TransferRejected, OfferedAt: transfer.CreatedAt, AnsweredAt: now.Unix(),
+	}).Return(nil)
+
+	history := &fakeDeviceHistory{}
+	transferService := service.NewTransferService(deviceRepository, history)
+	transferService.Clock = func() time.Time { return now }
+
+	// Act
+	err := transferService.RejectTransfer(callerContext("buyer", nil), id)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while rejecting the transfer: %s", err)
+	}
+
+	if len(history.deleted) != 0 {
+		t.Errorf("Expected the history to be kept, got %v", history.deleted)
+	}
+
+	deviceRepository.AssertExpectations(t)
+}
+
+func TestTransferService_ListIncomingTransfers(t *testing.T) {
+	// Arrange
+	now := time.Unix(1700000000, 0)
+	offered := &model.Device{
+		ID:           primitive.NewObjectID().Hex(),
+		UserID:       "seller",
+		SerialNumber: "SN-1",
+		LastLocation: &model.Location{Latitude: 52.52, Longitude: 13.40},
+		Transfer:     &model.DeviceTransfer{ToUserID: "buyer", ExpiresAt: now.Unix() + 60},
+	}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetIncomingTransfers", mock.Anything, "buyer", now.Unix()).Return([]*model.Device{offered}, nil)
+
+	transferService := service.NewTransferService(deviceRepository)
+	transferService.Clock = func() time.Time { return now }
+
+	// Act
+	devices, err := transferService.ListIncomingTransfers(callerContext("buyer", nil))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while listing transfers: %s", err)
+	}
+
+	if len(devices) != 1 || devices[0].SerialNumber != "SN-1" || devices[0].Transfer != offered.Transfer {
+		t.Fatalf("Expected the offered device, got %+v", devices)
+	}
+
+	if devices[0].LastLocation != nil {
+		t.Errorf("Expected the location to be hidden until the transfer is accepted")
+	}
+}
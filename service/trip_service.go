@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/BerryTracer/device-service/trip"
+)
+
+type TripService interface {
+	GetTrip(ctx context.Context, id string) (*model.Trip, error)
+	ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error)
+}
+
+// TripThresholds holds the trip detection thresholds of each device type.
+// Device types without an entry use trip.DefaultThresholds.
+type TripThresholds map[string]trip.Thresholds
+
+// For returns the thresholds of a device type.
+func (t TripThresholds) For(deviceType string) trip.Thresholds {
+	if thresholds, ok := t[deviceType]; ok {
+		return thresholds
+	}
+	return trip.DefaultThresholds
+}
+
+// ParseTripThresholds parses thresholds from a JSON object keyed by device type,
+// such as {"bike": {"moving_speed": 2, "stop_seconds": 120}}. Fields left out
+// keep their default.
+func ParseTripThresholds(data string) (TripThresholds, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, fmt.Errorf("invalid trip thresholds: %w", err)
+	}
+
+	thresholds := make(TripThresholds, len(entries))
+	for deviceType, entry := range entries {
+		parsed := trip.DefaultThresholds
+		if err := json.Unmarshal(entry, &parsed); err != nil {
+			return nil, fmt.Errorf("invalid trip thresholds for %q: %w", deviceType, err)
+		}
+
+		if !(parsed.MovingSpeed > 0) || parsed.StopSeconds <= 0 || !(parsed.MinDistance >= 0) {
+			return nil, fmt.Errorf("invalid trip thresholds for %q: moving_speed and stop_seconds must be positive and min_distance must not be negative", deviceType)
+		}
+
+		thresholds[deviceType] = parsed
+	}
+
+	return thresholds, nil
+}
+
+// TripServiceImpl groups the locations of devices into trips and stops as they
+// are reported. Viewers of a device may read its trips.
+type TripServiceImpl struct {
+	TripRepository   repository.TripRepository
+	DeviceRepository repository.DeviceRepository
+	Thresholds       TripThresholds
+	Clock            Clock
+}
+
+// NewTripService returns a new TripServiceImpl.
+func NewTripService(tripRepository repository.TripRepository, deviceRepository repository.DeviceRepository, thresholds TripThresholds) *TripServiceImpl {
+	return &TripServiceImpl{
+		TripRepository:   tripRepository,
+		DeviceRepository: deviceRepository,
+		Thresholds:       thresholds,
+		Clock:            time.Now,
+	}
+}
+
+// GetTrip implements TripService.
+func (s *TripServiceImpl) GetTrip(ctx context.Context, id string) (*model.Trip, error) {
+	found, err := s.TripRepository.GetTripById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, found.DeviceID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// ListTrips implements TripService.
+func (s *TripServiceImpl) ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error) {
+	if _, err := authorizeDevice(ctx, s.DeviceRepository, s.Clock, query.DeviceID, model.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	if query.To != 0 && query.From >= query.To {
+		return nil, errs.InvalidField("end_time", "must be after start_time")
+	}
+
+	return s.TripRepository.ListTrips(ctx, query)
+}
+
+// tripStateAttempts is how many times the locations of a report are detected
+// when other reports of the device save the detection state in the meantime.
+const tripStateAttempts = 3
+
+// LocationsReported implements LocationListener.
+// The locations continue the detection saved for the device, in time order,
+// with the thresholds of its type. Completed trips and stops are stored before
+// the state, so a failure never loses a segment; a segment detected again is
+// not stored twice. Locations older than the latest one seen are ignored. When
+// another report of the device saved the state first, the detection starts
+// again from that state.
+func (s *TripServiceImpl) LocationsReported(ctx context.Context, device *model.Device, locations []*model.Location) error {
+	ordered := make([]*model.Location, len(locations))
+	copy(ordered, locations)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Timestamp < ordered[j].Timestamp })
+
+	for attempt := 1; ; attempt++ {
+		err := s.detectTrips(ctx, device, ordered)
+		if attempt == tripStateAttempts || !errors.Is(err, repository.ErrTripStateConflict) {
+			return err
+		}
+	}
+}
+
+// detectTrips continues the saved detection of device with the ordered locations.
+func (s *TripServiceImpl) detectTrips(ctx context.Context, device *model.Device, ordered []*model.Location) error {
+	saved, err := s.TripRepository.GetTripState(ctx, device.ID)
+	if err != nil {
+		return err
+	}
+
+	thresholds := s.Thresholds.For(device.DeviceType)
+	state := toDetectionState(saved)
+
+	var trips []*model.Trip
+	for _, location := range ordered {
+		var completed []trip.Segment
+		point := trip.Point{Lat: location.Latitude, Lon: location.Longitude, Speed: location.Speed, Time: location.Timestamp}
+		state, completed = trip.Advance(thresholds, state, point)
+
+		for _, segment := range completed {
+			trips = append(trips, &model.Trip{
+				DeviceID:      device.ID,
+				UserID:        device.UserID,
+				Kind:          model.TripKind(segment.Kind),
+				StartPosition: model.LatLng{Latitude: segment.Start.Lat, Longitude: segment.Start.Lon},
+				StartTime:     segment.Start.Time,
+				EndPosition:   model.LatLng{Latitude: segment.End.Lat, Longitude: segment.End.Lon},
+				EndTime:       segment.End.Time,
+				Distance:      segment.Distance,
+				Duration:      segment.Duration(),
+				MaxSpeed:      segment.MaxSpeed,
+				AverageSpeed:  segment.AverageSpeed(),
+			})
+		}
+	}
+
+	if err := s.TripRepository.InsertTrips(ctx, trips); err != nil {
+		return err
+	}
+
+	next := fromDetectionState(state)
+	next.Version = saved.Version
+	return s.TripRepository.SaveTripState(ctx, device.ID, next)
+}
+
+func toDetectionState(state *model.TripState) trip.State {
+	return trip.State{
+		Current:            toDetectionSegment(state.Current),
+		Pending:            toDetectionSegment(state.Pending),
+		Stationary:         toDetectionPoint(state.Stationary),
+		StationaryDistance: state.StationaryDistance,
+		Last:               toDetectionPoint(state.Last),
+	}
+}
+
+func fromDetectionState(state trip.State) *model.TripState {
+	return &model.TripState{
+		Current:            fromDetectionSegment(state.Current),
+		Pending:            fromDetectionSegment(state.Pending),
+		Stationary:         fromDetectionPoint(state.Stationary),
+		StationaryDistance: state.StationaryDistance,
+		Last:               fromDetectionPoint(state.Last),
+	}
+}
+
+func toDetectionSegment(segment *model.TripSegment) *trip.Segment {
+	if segment == nil {
+		return nil
+	}
+	return &trip.Segment{
+		Kind:     trip.Kind(segment.Kind),
+		Start:    *toDetectionPoint(&segment.Start),
+		End:      *toDetectionPoint(&segment.End),
+		Distance: segment.Distance,
+		MaxSpeed: segment.MaxSpeed,
+	}
+}
+
+func fromDetectionSegment(segment *trip.Segment) *model.TripSegment {
+	if segment == nil {
+		return nil
+	}
+	return &model.TripSegment{
+		Kind:     model.TripKind(segment.Kind),
+		Start:    *fromDetectionPoint(&segment.Start),
+		End:      *fromDetectionPoint(&segment.End),
+		Distance: segment.Distance,
+		MaxSpeed: segment.MaxSpeed,
+	}
+}
+
+func toDetectionPoint(point *model.TripPoint) *trip.Point {
+	if point == nil {
+		return nil
+	}
+	return &trip.Point{Lat: point.Latitude, Lon: point.Longitude, Speed: point.Speed, Time: point.Time}
+}
+
+func fromDetectionPoint(point *trip.Point) *model.TripPoint {
+	if point == nil {
+		return nil
+	}
+	return &model.TripPoint{Latitude: point.Lat, Longitude: point.Lon, Speed: point.Speed, Time: point.Time}
+}
+
+// Ensure TripServiceImpl implements TripService and LocationListener interfaces
+var (
+	_ TripService      = &TripServiceImpl{}
+	_ LocationListener = &TripServiceImpl{}
+)
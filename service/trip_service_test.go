@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BerryTracer/device-service/model"
+	"github.com/BerryTracer/device-service/repository"
+	"github.com/BerryTracer/device-service/service"
+	"github.com/BerryTracer/device-service/service/errs"
+	"github.com/BerryTracer/device-service/trip"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mocking the trip repository
+type TripRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *TripRepositoryMock) InsertTrips(ctx context.Context, trips []*model.Trip) error {
+	args := r.Called(ctx, trips)
+	return args.Error(0)
+}
+
+func (r *TripRepositoryMock) GetTripById(ctx context.Context, id string) (*model.Trip, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(*model.Trip), args.Error(1)
+}
+
+func (r *TripRepositoryMock) ListTrips(ctx context.Context, query *model.TripQuery) (*model.TripPage, error) {
+	args := r.Called(ctx, query)
+	return args.Get(0).(*model.TripPage), args.Error(1)
+}
+
+func (r *TripRepositoryMock) GetTripState(ctx context.Context, deviceId string) (*model.TripState, error) {
+	args := r.Called(ctx, deviceId)
+	return args.Get(0).(*model.TripState), args.Error(1)
+}
+
+func (r *TripRepositoryMock) SaveTripState(ctx context.Context, deviceId string, state *model.TripState) error {
+	args := r.Called(ctx, deviceId, state)
+	return args.Error(0)
+}
+
+func TestTripService_LocationsReported(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: deviceID, UserID: "caller", DeviceType: "bike"}
+
+	// The device was parked at the origin until 600 when the report arrives.
+	origin := model.TripPoint{Time: 0}
+	parked := model.TripPoint{Time: 600}
+	saved := &model.TripState{Current: &model.TripSegment{Kind: model.TripKindStop, Start: origin, End: parked}, Last: &parked}
+
+	// Reported out of order: it rides 0.01° (about 1.1 km) east and parks again.
+	locations := []*model.Location{
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1000},
+		{Latitude: 0, Longitude: 0.005, Timestamp: 700},
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1100},
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1300},
+	}
+
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("GetTripState", mock.Anything, deviceID).Return(saved, nil)
+
+	var stored []*model.Trip
+	tripRepository.On("InsertTrips", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).([]*model.Trip) }).
+		Return(nil)
+
+	var state *model.TripState
+	tripRepository.On("SaveTripState", mock.Anything, deviceID, mock.Anything).
+		Run(func(args mock.Arguments) { state = args.Get(2).(*model.TripState) }).
+		Return(nil)
+
+	// Bikes stop after 200 seconds in place.
+	thresholds := service.TripThresholds{"bike": {MovingSpeed: 1, StopSeconds: 200, MinDistance: 100}}
+	tripService := service.NewTripService(tripRepository, new(DeviceRepositoryMock), thresholds)
+
+	// Act
+	err := tripService.LocationsReported(context.Background(), device, locations)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while detecting trips: %s", err)
+	}
+
+	if len(stored) != 2 || stored[0].Kind != model.TripKindStop || stored[1].Kind != model.TripKindTrip {
+		t.Fatalf("Expected a stop and a trip, got %+v", stored)
+	}
+
+	ride := stored[1]
+	if ride.DeviceID != deviceID || ride.UserID != "caller" || ride.StartTime != 600 || ride.EndTime != 1100 || ride.Duration != 500 {
+		t.Errorf("Unexpected trip %+v", ride)
+	}
+
+	if ride.Distance < 1100 || ride.Distance > 1120 || ride.EndPosition.Longitude != 0.01 {
+		t.Errorf("Expected the trip to cover about 1.1 km, got %+v", ride)
+	}
+
+	if state.Current == nil || state.Current.Kind != model.TripKindStop || state.Last.Time != 1300 || state.Pending != nil {
+		t.Errorf("Expected an open stop to be saved, got %+v", state)
+	}
+
+	tripRepository.AssertExpectations(t)
+}
+
+func TestTripService_LocationsReported_Conflict(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: deviceID, UserID: "caller", DeviceType: "bike"}
+
+	// The device rides 0.01° east from where it was parked and parks again.
+	parked := model.TripPoint{Time: 600}
+	arrived := model.TripPoint{Longitude: 0.01, Time: 1300}
+	locations := []*model.Location{
+		{Latitude: 0, Longitude: 0.005, Timestamp: 700},
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1000},
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1100},
+		{Latitude: 0, Longitude: 0.01, Timestamp: 1300},
+	}
+
+	// A retry of the same report saved its state first, so the second read
+	// already holds every location.
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("GetTripState", mock.Anything, deviceID).
+		Return(&model.TripState{Current: &model.TripSegment{Kind: model.TripKindStop, Start: model.TripPoint{}, End: parked}, Last: &parked, Version: 1}, nil).Once()
+	tripRepository.On("GetTripState", mock.Anything, deviceID).
+		Return(&model.TripState{Current: &model.TripSegment{Kind: model.TripKindStop, Start: arrived, End: arrived}, Last: &arrived, Version: 2}, nil).Once()
+
+	var stored []*model.Trip
+	tripRepository.On("InsertTrips", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = append(stored, args.Get(1).([]*model.Trip)...) }).
+		Return(nil)
+
+	tripRepository.On("SaveTripState", mock.Anything, deviceID, mock.MatchedBy(func(state *model.TripState) bool { return state.Version == 1 })).
+		Return(repository.ErrTripStateConflict).Once()
+	tripRepository.On("SaveTripState", mock.Anything, deviceID, mock.MatchedBy(func(state *model.TripState) bool { return state.Version == 2 })).
+		Return(nil).Once()
+
+	thresholds := service.TripThresholds{"bike": {MovingSpeed: 1, StopSeconds: 200, MinDistance: 100}}
+	tripService := service.NewTripService(tripRepository, new(DeviceRepositoryMock), thresholds)
+
+	// Act
+	err := tripService.LocationsReported(context.Background(), device, locations)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected once the detection was retried: %s", err)
+	}
+
+	// The retry detects nothing new, so the stop and the ride are stored once.
+	if len(stored) != 2 || stored[0].Kind != model.TripKindStop || stored[1].Kind != model.TripKindTrip {
+		t.Errorf("Expected a stop and a trip stored once, got %+v", stored)
+	}
+
+	tripRepository.AssertExpectations(t)
+}
+
+func TestTripService_LocationsReported_ConflictPersists(t *testing.T) {
+	// Arrange
+	deviceID := primitive.NewObjectID().Hex()
+	device := &model.Device{ID: deviceID, UserID: "caller"}
+
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("GetTripState", mock.Anything, deviceID).Return(&model.TripState{}, nil)
+	tripRepository.On("InsertTrips", mock.Anything, mock.Anything).Return(nil)
+	tripRepository.On("SaveTripState", mock.Anything, deviceID, mock.Anything).Return(repository.ErrTripStateConflict)
+
+	tripService := service.NewTripService(tripRepository, new(DeviceRepositoryMock), nil)
+
+	// Act
+	err := tripService.LocationsReported(context.Background(), device, []*model.Location{{Timestamp: 100}})
+
+	// Assert
+	if !errors.Is(err, repository.ErrTripStateConflict) {
+		t.Errorf("Expected the conflict after the last attempt, got %v", err)
+	}
+
+	tripRepository.AssertNumberOfCalls(t, "SaveTripState", 3)
+}
+
+func TestTripService_GetTrip_OtherOwner(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	deviceID := primitive.NewObjectID().Hex()
+
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("GetTripById", mock.Anything, id).Return(&model.Trip{ID: id, DeviceID: deviceID}, nil)
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, deviceID).Return(&model.Device{ID: deviceID, UserID: "owner"}, nil)
+
+	tripService := service.NewTripService(tripRepository, deviceRepository, nil)
+
+	// Act
+	_, err := tripService.GetTrip(callerContext("intruder", nil), id)
+
+	// Assert
+	if !errs.Is(err, errs.PermissionDenied) {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+}
+
+func TestTripService_ListTrips(t *testing.T) {
+	// Arrange
+	id := primitive.NewObjectID().Hex()
+	query := &model.TripQuery{DeviceID: id, From: 1000, To: 2000, IncludeStops: true}
+	page := &model.TripPage{Trips: []*model.Trip{{DeviceID: id, StartTime: 1500}}}
+
+	deviceRepository := new(DeviceRepositoryMock)
+	deviceRepository.On("GetDeviceById", mock.Anything, id).Return(&model.Device{ID: id, UserID: "caller"}, nil)
+
+	tripRepository := new(TripRepositoryMock)
+	tripRepository.On("ListTrips", mock.Anything, query).Return(page, nil)
+
+	tripService := service.NewTripService(tripRepository, deviceRepository, nil)
+
+	// Act
+	result, err := tripService.ListTrips(callerContext("caller", nil), query)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Error was not expected while listing trips: %s", err)
+	}
+
+	if result != page {
+		t.Errorf("Expected the repository page, got %+v", result)
+	}
+
+	// An empty range is rejected.
+	if _, err := tripService.ListTrips(callerContext("caller", nil), &model.TripQuery{DeviceID: id, From: 2000, To: 1000}); !errs.Is(err, errs.InvalidArgument) {
+		t.Errorf("Expected invalid argument for an empty range, got %v", err)
+	}
+}
+
+func TestParseTripThresholds(t *testing.T) {
+	thresholds, err := service.ParseTripThresholds(`{"bike": {"moving_speed": 2, "stop_seconds": 120}}`)
+	if err != nil {
+		t.Fatalf("Error was not expected while parsing thresholds: %s", err)
+	}
+
+	bike := thresholds.For("bike")
+	if bike.MovingSpeed != 2 || bike.StopSeconds != 120 || bike.MinDistance != trip.DefaultThresholds.MinDistance {
+		t.Errorf("Expected the bike thresholds with the default min distance, got %+v", bike)
+	}
+
+	if thresholds.For("tracker") != trip.DefaultThresholds {
+		t.Errorf("Expected the default thresholds for an unknown type, got %+v", thresholds.For("tracker"))
+	}
+
+	for _, invalid := range []string{`[]`, `{"bike": {"moving_speed": 0}}`, `{"bike": {"stop_seconds": -1}}`} {
+		if _, err := service.ParseTripThresholds(invalid); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package trip groups a sequence of positions into trips, where a device is
+// moving, and stops, where it stays in place. Segmentation is incremental: the
+// State carries everything needed to continue with the next position, so
+// history never has to be scanned again. It has no dependencies on storage or
+// transport.
+package trip
+
+import (
+	"github.com/BerryTracer/device-service/geofence"
+)
+
+// Thresholds tune the segmentation for a kind of device.
+type Thresholds struct {
+	// MovingSpeed is the speed, in meters per second, at or above which a
+	// device counts as moving.
+	MovingSpeed float64 `json:"moving_speed"`
+	// StopSeconds is how long a device must stay below MovingSpeed before the
+	// trip it was on ends in a stop.
+	StopSeconds int64 `json:"stop_seconds"`
+	// MinDistance is the distance, in meters, a trip must cover to be kept.
+	// Shorter movements, such as GPS drift, are folded into the surrounding stop.
+	MinDistance float64 `json:"min_distance"`
+}
+
+// DefaultThresholds suit a device carried by a person or a vehicle.
+var DefaultThresholds = Thresholds{MovingSpeed: 1, StopSeconds: 300, MinDistance: 200}
+
+// Kind tells trips and stops apart.
+type Kind string
+
+const (
+	Trip Kind = "trip"
+	Stop Kind = "stop"
+)
+
+// Point is a position seen at a time.
+type Point struct {
+	Lat   float64
+	Lon   float64
+	Speed float64 // Reported speed in meters per second, 0 when unknown
+	Time  int64   // Unix timestamp (seconds since epoch)
+}
+
+// Segment is a trip or a stop. A stop is located at its start position and has
+// no distance.
+type Segment struct {
+	Kind     Kind
+	Start    Point
+	End      Point
+	Distance float64 // Meters
+	MaxSpeed float64 // Meters per second
+}
+
+// Duration returns the length of the segment in seconds.
+func (s Segment) Duration() int64 {
+	return s.End.Time - s.Start.Time
+}
+
+// AverageSpeed returns the distance covered per second, or 0 for an instant segment.
+func (s Segment) AverageSpeed() float64 {
+	if s.Duration() <= 0 {
+		return 0
+	}
+	return s.Distance / float64(s.Duration())
+}
+
+// State is the segmentation in progress. The zero State means no position has
+// been seen yet.
+type State struct {
+	// Current is the open segment, which the next position extends.
+	Current *Segment
+	// Pending is the stop before the open trip. It is completed only once the
+	// trip is long enough, so that drift does not split a stop in two.
+	Pending *Segment
+	// Stationary is the first position of the open trip since which the device
+	// has stayed below the moving speed, and StationaryDistance the distance
+	// of the trip up to it.
+	Stationary         *Point
+	StationaryDistance float64
+	// Last is the latest position applied.
+	Last *Point
+}
+
+// Advance applies the position p to state and returns the new state with the
+// segments it completed, oldest first. Positions not newer than the latest one
+// applied are ignored, so a late report cannot rewrite completed segments.
+//
+// The speed at p is the reported speed or, when none is reported, the average
+// speed since the previous position.
+func Advance(thresholds Thresholds, state State, p Point) (State, []Segment) {
+	if state.Last == nil {
+		state.Current = &Segment{Kind: Stop, Start: p, End: p}
+		state.Last = &p
+		return state, nil
+	}
+
+	last := *state.Last
+	if p.Time <= last.Time {
+		return state, nil
+	}
+
+	distance := geofence.Distance(geofence.Point{Lat: last.Lat, Lon: last.Lon}, geofence.Point{Lat: p.Lat, Lon: p.Lon})
+	speed := p.Speed
+	if speed == 0 {
+		speed = distance / float64(p.Time-last.Time)
+	}
+	moving := speed >= thresholds.MovingSpeed
+	state.Last = &p
+
+	var completed []Segment
+	current := *state.Current
+
+	if current.Kind == Stop {
+		if !moving {
+			current.End = p
+			state.Current = &current
+			return state, nil
+		}
+
+		// The trip leaves from the last stationary position.
+		state.Pending = &current
+		state.Current = &Segment{Kind: Trip, Start: last, End: p, Distance: distance, MaxSpeed: speed}
+		state.Stationary = nil
+		state.StationaryDistance = 0
+		return state, nil
+	}
+
+	current.End = p
+	current.Distance += distance
+	if speed > current.MaxSpeed {
+		current.MaxSpeed = speed
+	}
+
+	if moving {
+		state.Current = &current
+		state.Stationary = nil
+		state.StationaryDistance = 0
+		return state, nil
+	}
+
+	if state.Stationary == nil {
+		state.Stationary = &p
+		state.StationaryDistance = current.Distance
+	}
+
+	if p.Time-state.Stationary.Time < thresholds.StopSeconds {
+		state.Current = &current
+		return state, nil
+	}
+
+	// The device has stayed put long enough: the trip ended where it first slowed down.
+	stationary := *state.Stationary
+	current.End = stationary
+	current.Distance = state.StationaryDistance
+
+	if current.Distance < thresholds.MinDistance {
+		// Too short to be a trip, so the device never left the stop.
+		stop := Segment{Kind: Stop, Start: current.Start, End: p}
+		if state.Pending != nil {
+			stop.Start = state.Pending.Start
+		}
+		state.Current = &stop
+	} else {
+		if state.Pending != nil && state.Pending.Duration() >= thresholds.StopSeconds {
+			completed = append(completed, *state.Pending)
+		}
+		completed = append(completed, current)
+		state.Current = &Segment{Kind: Stop, Start: stationary, End: p}
+	}
+
+	state.Pending = nil
+	state.Stationary = nil
+	state.StationaryDistance = 0
+	return state, completed
+}
@@ -0,0 +1,121 @@
+package trip_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/BerryTracer/device-service/trip"
+)
+
+var thresholds = trip.Thresholds{MovingSpeed: 1, StopSeconds: 300, MinDistance: 200}
+
+// metersPerDegree is the length of one degree of longitude at the equator.
+const metersPerDegree = 111195.08
+
+// at returns the position x meters east of the origin at time t.
+func at(x float64, t int64) trip.Point {
+	return trip.Point{Lat: 0, Lon: x / metersPerDegree, Time: t}
+}
+
+func advanceAll(state trip.State, points ...trip.Point) (trip.State, []trip.Segment) {
+	var completed []trip.Segment
+	for _, p := range points {
+		var segments []trip.Segment
+		state, segments = trip.Advance(thresholds, state, p)
+		completed = append(completed, segments...)
+	}
+	return state, completed
+}
+
+func TestAdvance_TripBetweenStops(t *testing.T) {
+	state, segments := advanceAll(trip.State{},
+		at(0, 0), at(0, 600), // Parked for 10 minutes
+		at(1000, 700), at(3000, 800), at(3500, 900), // Driving 3.5 km
+		at(3500, 1000), at(3500, 1300), // Parked again
+	)
+
+	if len(segments) != 2 {
+		t.Fatalf("expected a stop and a trip, got %+v", segments)
+	}
+
+	stop, drive := segments[0], segments[1]
+	if stop.Kind != trip.Stop || stop.Start.Time != 0 || stop.End.Time != 600 || stop.Distance != 0 {
+		t.Errorf("unexpected stop %+v", stop)
+	}
+
+	if drive.Kind != trip.Trip || drive.Start.Time != 600 || drive.End.Time != 1000 {
+		t.Errorf("unexpected trip %+v", drive)
+	}
+	if math.Abs(drive.Distance-3500) > 1 {
+		t.Errorf("trip distance = %v, want 3500", drive.Distance)
+	}
+	if math.Abs(drive.MaxSpeed-20) > 0.01 {
+		t.Errorf("trip max speed = %v, want 20", drive.MaxSpeed)
+	}
+	if drive.Duration() != 400 || math.Abs(drive.AverageSpeed()-8.75) > 0.01 {
+		t.Errorf("trip duration = %d, average speed = %v", drive.Duration(), drive.AverageSpeed())
+	}
+
+	if state.Current == nil || state.Current.Kind != trip.Stop || state.Current.Start.Time != 1000 || state.Current.End.Time != 1300 {
+		t.Errorf("expected an open stop since the end of the trip, got %+v", state.Current)
+	}
+}
+
+func TestAdvance_ShortPauseDoesNotEndTrip(t *testing.T) {
+	state, segments := advanceAll(trip.State{},
+		at(0, 0), at(1000, 100),
+		at(1000, 200), // Traffic light
+		at(2000, 300), at(3000, 400),
+	)
+
+	if len(segments) != 0 {
+		t.Fatalf("expected the trip to stay open, got %+v", segments)
+	}
+
+	if state.Current.Kind != trip.Trip || state.Stationary != nil || math.Abs(state.Current.Distance-3000) > 1 {
+		t.Errorf("unexpected state %+v", state.Current)
+	}
+}
+
+func TestAdvance_DriftIsFoldedIntoStop(t *testing.T) {
+	state, segments := advanceAll(trip.State{},
+		at(0, 0), at(0, 600),
+		at(50, 610), // A jump of 50 m in 10 s
+		at(50, 700), at(50, 1000),
+	)
+
+	if len(segments) != 0 {
+		t.Fatalf("expected no completed segments, got %+v", segments)
+	}
+
+	if state.Current.Kind != trip.Stop || state.Current.Start.Time != 0 || state.Current.End.Time != 1000 {
+		t.Errorf("expected the stop to continue, got %+v", state.Current)
+	}
+}
+
+func TestAdvance_ReportedSpeed(t *testing.T) {
+	moving := at(10, 60)
+	moving.Speed = 5
+
+	state, _ := advanceAll(trip.State{}, at(0, 0), moving)
+
+	if state.Current.Kind != trip.Trip || state.Current.MaxSpeed != 5 {
+		t.Errorf("expected the reported speed to start a trip, got %+v", state.Current)
+	}
+}
+
+func TestAdvance_IgnoresOldPositions(t *testing.T) {
+	state, _ := advanceAll(trip.State{}, at(0, 0), at(0, 100))
+
+	next, segments := trip.Advance(thresholds, state, at(5000, 50))
+	if len(segments) != 0 || next.Last.Time != 100 || next.Current.Kind != trip.Stop {
+		t.Errorf("expected an old position to be ignored, got %+v", next)
+	}
+}
+
+func TestSegment_AverageSpeed_Instant(t *testing.T) {
+	segment := trip.Segment{Kind: trip.Trip, Start: at(0, 10), End: at(0, 10), Distance: 5}
+	if segment.AverageSpeed() != 0 {
+		t.Errorf("expected 0 for an instant segment, got %v", segment.AverageSpeed())
+	}
+}